@@ -25,7 +25,9 @@ import (
 
 	"go.uber.org/nilaway/annotation"
 	"go.uber.org/nilaway/assertion"
+	"go.uber.org/nilaway/assertion/function"
 	"go.uber.org/nilaway/assertion/function/assertiontree"
+	"go.uber.org/nilaway/assertion/function/functioncontracts"
 	"go.uber.org/nilaway/config"
 	"go.uber.org/nilaway/diagnostic"
 	"go.uber.org/nilaway/inference"
@@ -45,8 +47,18 @@ var Analyzer = &analysis.Analyzer{
 	Doc:        _doc,
 	Run:        run,
 	FactTypes:  []analysis.Fact{new(inference.InferredMap)},
-	Requires:   []*analysis.Analyzer{config.Analyzer, assertion.Analyzer, annotation.Analyzer, diagnostic.NoLintAnalyzer},
-	ResultType: reflect.TypeOf(([]analysis.Diagnostic)(nil)),
+	Requires:   []*analysis.Analyzer{config.Analyzer, assertion.Analyzer, function.Analyzer, functioncontracts.Analyzer, annotation.Analyzer, diagnostic.NoLintAnalyzer},
+	ResultType: reflect.TypeOf((*Result)(nil)),
+}
+
+// Result is the result of the accumulation analyzer.
+type Result struct {
+	// Diagnostics is the list of potential diagnostics for the upper-level analyzers to report.
+	Diagnostics []diagnostic.Diagnostic
+	// InferredMap is the final state of the inference for this package, which maps the annotation
+	// sites to their inferred nilabilities (see [inference.Report]). It is nil if the
+	// package is not in scope or the analysis failed.
+	InferredMap *inference.InferredMap
 }
 
 // run is the primary driver function for NilAway's analysis.
@@ -61,7 +73,7 @@ var Analyzer = &analysis.Analyzer{
 // any information from analyses of upstream dependencies, and load any manual annotations for the
 // current (local) package. Then, we start the inference depending on the mode:
 //
-// - Mode inference.NoInfer: No inference
+// - Mode inference.NoInfer: No inference (i.e., the strict mode, see inference.DetermineMode)
 // We simply check all assertions against the manual annotations and upstream values (which can
 // possibly determine upstream values but cannot determine the already-determined local
 // values) and report errors if there are any.
@@ -83,19 +95,18 @@ func run(p *analysis.Pass) (result interface{}, _ error) {
 			// Deferred functions are executed after a result is generated, so here we modify the
 			// return value `result` in-place.
 			// Diagnostics with invalid positions (<= 0) will be silently suppressed, so here we use 1.
-			d := analysis.Diagnostic{Pos: 1, Message: fmt.Sprintf("INTERNAL PANIC: %s\n%s", r, string(debug.Stack()))}
-			if diagnostics, ok := result.([]analysis.Diagnostic); ok {
-				result = append(diagnostics, d)
+			d := diagnostic.Diagnostic{Diagnostic: analysis.Diagnostic{Pos: 1, Message: fmt.Sprintf("INTERNAL PANIC: %s\n%s", r, string(debug.Stack()))}, Internal: true}
+			if res, ok := result.(*Result); ok && res != nil {
+				res.Diagnostics = append(res.Diagnostics, d)
 			} else {
-				result = []analysis.Diagnostic{d}
+				result = &Result{Diagnostics: []diagnostic.Diagnostic{d}}
 			}
 		}
 	}()
 
 	conf := pass.ResultOf[config.Analyzer].(*config.Config)
 	if !conf.IsPkgInScope(pass.Pkg) {
-		// Must return a typed nil since the driver is using reflection to retrieve the result.
-		return ([]analysis.Diagnostic)(nil), nil
+		return &Result{}, nil
 	}
 
 	assertionsResult := pass.ResultOf[assertion.Analyzer].(*analysishelper.Result[[]annotation.FullTrigger])
@@ -105,10 +116,26 @@ func run(p *analysis.Pass) (result interface{}, _ error) {
 		// errors. However, in the future we could implement error recovery and make use of the partial
 		// information to continue the analysis.
 		// Diagnostics with invalid positions (<= 0) will be silently suppressed, so here we use 1.
-		return []analysis.Diagnostic{{Pos: 1, Message: fmt.Sprintf("INTERNAL ERROR(s):\n%s", err)}}, nil
+		return &Result{Diagnostics: []diagnostic.Diagnostic{{Diagnostic: analysis.Diagnostic{Pos: 1, Message: fmt.Sprintf("INTERNAL ERROR(s):\n%s", err)}, Internal: true}}}, nil
 	}
 
 	diagnosticEngine := diagnostic.NewEngine(pass)
+	// The functions skipped by the function analyzer do not contribute any triggers (i.e., their
+	// parameters and results are left to the defaults), and are reported separately such that the
+	// rest of the package is still checked.
+	for _, f := range pass.ResultOf[function.Analyzer].(*analysishelper.Result[function.Result]).Res.Skipped {
+		diagnosticEngine.AddSkippedFunction(f.Pos, f.Name, f.Reason)
+	}
+	contractsResult := pass.ResultOf[functioncontracts.Analyzer].(*analysishelper.Result[functioncontracts.Result]).Res
+	for _, c := range contractsResult.Invalid {
+		diagnosticEngine.AddInvalidContract(c.Pos, c.Text, c.Reason)
+	}
+	for _, c := range contractsResult.Violated {
+		diagnosticEngine.AddContractViolation(c.Pos, c.Text, c.ReturnPos, c.Reason)
+	}
+	for _, s := range annotationsResult.Res.InvalidStubs() {
+		diagnosticEngine.AddInvalidStub(s.Pos, s.Reason)
+	}
 
 	// Create an inference engine and observe (load) information from upstream dependencies (i.e.,
 	// mappings between annotation sites and their inferred values).
@@ -124,13 +151,15 @@ func run(p *analysis.Pass) (result interface{}, _ error) {
 
 	var (
 		inferredMap *inference.InferredMap
-		diagnostics []analysis.Diagnostic
+		diagnostics []diagnostic.Diagnostic
 	)
 	switch mode {
 	case inference.FullInfer:
-		// TODO: This is a suppression added for handling of struct field assignments. We plan to add
-		//  object sensitivity to NilAway in the future, which will allow us to be more precise in struct fields'
-		//  handling. Remove this suppression once we have the object sensitivity implemented (issue #339).
+		// TODO: This is a suppression added for handling of struct field assignments. The fields of local variables
+		//  are tracked per object (see assertiontree.FunctionConfig.EnableObjectSensitiveFields) and hence do not
+		//  produce such triggers, but the assignments to the fields of other objects (e.g., `g.f = nil` for a global
+		//  variable `g`, or `get().f = nil`) would still affect the field `f` of every object. Remove this
+		//  suppression once we have the object sensitivity implemented for them as well (issue #339).
 		for _, t := range assertionsResult.Res {
 			if _, ok := t.Consumer.Annotation.(*annotation.FldAssign); ok {
 				// update its producer to be non-nil
@@ -149,9 +178,7 @@ func run(p *analysis.Pass) (result interface{}, _ error) {
 		// In non-inference case - use the classical assertionNode.CheckErrors method to determine error outputs
 		inferredMap = inferenceEngine.InferredMap()
 		checkErrors(assertionsResult.Res, inferredMap, diagnosticEngine)
-		// Retrieve the diagnostics from the engine. Note that we should not group the
-		// diagnostics for easier unit testing.
-		diagnostics = diagnosticEngine.Diagnostics(false /* grouping */)
+		diagnostics = diagnosticEngine.Diagnostics(conf.GroupErrorMessages)
 
 	default:
 		panic("Invalid mode for running NilAway")
@@ -168,7 +195,7 @@ func run(p *analysis.Pass) (result interface{}, _ error) {
 	// [gob encoding]: https://pkg.go.dev/encoding/gob#hdr-Basics
 	inferredMap.Export(pass)
 
-	return diagnostics, nil
+	return &Result{Diagnostics: diagnostics, InferredMap: inferredMap}, nil
 }
 
 type conflictHandler interface {
@@ -21,6 +21,7 @@ import (
 	"go/types"
 	"strings"
 
+	"go.uber.org/nilaway/config"
 	"go.uber.org/nilaway/guard"
 	"go.uber.org/nilaway/util/orderedmap"
 	"go.uber.org/nilaway/util/typeshelper"
@@ -77,6 +78,52 @@ type Prestring interface {
 	String() string
 }
 
+// ConsumerCategory returns the category of the consumer described by the given Prestring (which
+// may be wrapped in a LocatedPrestring), or config.CategoryOther if it does not describe a consumer.
+func ConsumerCategory(p Prestring) config.Category {
+	if l, ok := p.(LocatedPrestring); ok {
+		p = l.Contained
+	}
+	switch p.(type) {
+	case PtrLoadPrestring, FldAccessPrestring:
+		return config.CategoryDereference
+	case RecvPassPrestring:
+		return config.CategoryMethodReceiver
+	case MapAccessPrestring, MapWrittenToPrestring:
+		return config.CategoryMapAccess
+	case SliceAccessPrestring:
+		return config.CategorySliceAccess
+	case TypeAssertPrestring:
+		return config.CategoryTypeAssertion
+	case ClosedChanSendPrestring:
+		return config.CategoryClosedChannel
+	case UseAsErrorResultPrestring, UseAsNonErrorRetDependentOnErrorRetNilabilityPrestring,
+		UseAsErrorRetWithNilabilityUnknownPrestring:
+		return config.CategoryErrorReturn
+	case UseAsReturnPrestring, UseAsFldOfReturnPrestring, UseAsFuncValueResultPrestring,
+		UseAsClosureVarReturnPrestring:
+		return config.CategoryReturn
+	case ArgPassPrestring, ArgFldPassPrestring:
+		return config.CategoryArgument
+	case InterfaceResultFromImplementationPrestring, MethodParamFromInterfacePrestring:
+		return config.CategoryInterfaceContract
+	case FldAssignPrestring:
+		return config.CategoryFieldAssign
+	case GlobalVarAssignPrestring:
+		return config.CategoryGlobalAssign
+	case TriggerIfDeepNonNilPrestring, ArgPassDeepPrestring, UseAsReturnDeepPrestring,
+		SliceAssignPrestring, ArrayAssignPrestring, PtrAssignPrestring, MapAssignPrestring,
+		DeepAssignPrimitivePrestring, ParamAssignDeepPrestring, FuncRetAssignDeepPrestring,
+		VariadicParamAssignDeepPrestring, FieldAssignDeepPrestring, GlobalVarAssignDeepPrestring,
+		LocalVarAssignDeepPrestring, ChanSendPrestring:
+		return config.CategoryDeepNilability
+	case FldEscapePrestring:
+		return config.CategoryFieldEscape
+	default:
+		return config.CategoryOther
+	}
+}
+
 // Assignment is a struct that represents an assignment to an expression
 type Assignment struct {
 	LHSExprStr string
@@ -519,6 +566,86 @@ func (s SliceAccessPrestring) String() string {
 	return sb.String()
 }
 
+// TypeAssert is when an interface value flows to a single-value type assertion `x.(T)`, which panics
+// if the value is nil, and so it must be non-nil
+type TypeAssert struct {
+	*ConsumeTriggerTautology
+}
+
+// equals returns true if the passed ConsumingAnnotationTrigger is equal to this one
+func (t *TypeAssert) equals(other ConsumingAnnotationTrigger) bool {
+	if other, ok := other.(*TypeAssert); ok {
+		return t.ConsumeTriggerTautology.equals(other.ConsumeTriggerTautology)
+	}
+	return false
+}
+
+// Copy returns a deep copy of this ConsumingAnnotationTrigger
+func (t *TypeAssert) Copy() ConsumingAnnotationTrigger {
+	copyConsumer := *t
+	copyConsumer.ConsumeTriggerTautology = t.ConsumeTriggerTautology.Copy().(*ConsumeTriggerTautology)
+	return &copyConsumer
+}
+
+// Prestring returns this TypeAssert as a Prestring
+func (t *TypeAssert) Prestring() Prestring {
+	return TypeAssertPrestring{
+		AssignmentStr: t.String(),
+	}
+}
+
+// TypeAssertPrestring is a Prestring storing the needed information to compactly encode a TypeAssert
+type TypeAssertPrestring struct {
+	AssignmentStr string
+}
+
+func (t TypeAssertPrestring) String() string {
+	var sb strings.Builder
+	sb.WriteString("type-asserted without `ok` check")
+	sb.WriteString(t.AssignmentStr)
+	return sb.String()
+}
+
+// ClosedChanSend is when a channel that may have been closed in the same function flows to a send,
+// which panics. It is always paired with a ChanClose producer.
+type ClosedChanSend struct {
+	*ConsumeTriggerTautology
+}
+
+// equals returns true if the passed ConsumingAnnotationTrigger is equal to this one
+func (c *ClosedChanSend) equals(other ConsumingAnnotationTrigger) bool {
+	if other, ok := other.(*ClosedChanSend); ok {
+		return c.ConsumeTriggerTautology.equals(other.ConsumeTriggerTautology)
+	}
+	return false
+}
+
+// Copy returns a deep copy of this ConsumingAnnotationTrigger
+func (c *ClosedChanSend) Copy() ConsumingAnnotationTrigger {
+	copyConsumer := *c
+	copyConsumer.ConsumeTriggerTautology = c.ConsumeTriggerTautology.Copy().(*ConsumeTriggerTautology)
+	return &copyConsumer
+}
+
+// Prestring returns this ClosedChanSend as a Prestring
+func (c *ClosedChanSend) Prestring() Prestring {
+	return ClosedChanSendPrestring{
+		AssignmentStr: c.String(),
+	}
+}
+
+// ClosedChanSendPrestring is a Prestring storing the needed information to compactly encode a ClosedChanSend
+type ClosedChanSendPrestring struct {
+	AssignmentStr string
+}
+
+func (c ClosedChanSendPrestring) String() string {
+	var sb strings.Builder
+	sb.WriteString("sent to")
+	sb.WriteString(c.AssignmentStr)
+	return sb.String()
+}
+
 // FldAccess is when a value flows to a point where a field of it is accessed, and so it must be non-nil
 type FldAccess struct {
 	*ConsumeTriggerTautology
@@ -737,7 +864,7 @@ type ArgFldPassPrestring struct {
 
 func (f ArgFldPassPrestring) String() string {
 	var sb strings.Builder
-	prefix := ""
+	prefix := "passed as "
 	if f.IsPassed {
 		prefix = "assigned to "
 	}
@@ -1240,6 +1367,94 @@ func (u UseAsReturnDeep) customPos() (token.Pos, bool) {
 	return 0, false
 }
 
+// UseAsFuncValueResult is when a value flows from the result of a possible target of a function
+// value to the result of a call to the function value (see FuncValueRetAnnotationKey).
+type UseAsFuncValueResult struct {
+	*TriggerIfNonNil
+}
+
+// equals returns true if the passed ConsumingAnnotationTrigger is equal to this one
+func (u *UseAsFuncValueResult) equals(other ConsumingAnnotationTrigger) bool {
+	if other, ok := other.(*UseAsFuncValueResult); ok {
+		return u.TriggerIfNonNil.equals(other.TriggerIfNonNil)
+	}
+	return false
+}
+
+// Copy returns a deep copy of this ConsumingAnnotationTrigger
+func (u *UseAsFuncValueResult) Copy() ConsumingAnnotationTrigger {
+	copyConsumer := *u
+	copyConsumer.TriggerIfNonNil = u.TriggerIfNonNil.Copy().(*TriggerIfNonNil)
+	return &copyConsumer
+}
+
+// Prestring returns this UseAsFuncValueResult as a Prestring
+func (u *UseAsFuncValueResult) Prestring() Prestring {
+	key := u.Ann.(*FuncValueRetAnnotationKey)
+	return UseAsFuncValueResultPrestring{
+		key.FuncValue.Name(),
+		key.RetNum,
+		u.String(),
+	}
+}
+
+// UseAsFuncValueResultPrestring is a Prestring storing the needed information to compactly encode a UseAsFuncValueResult
+type UseAsFuncValueResultPrestring struct {
+	FuncValueName string
+	RetNum        int
+	AssignmentStr string
+}
+
+func (u UseAsFuncValueResultPrestring) String() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "returned via function value `%s()` in position %d", u.FuncValueName, u.RetNum)
+	sb.WriteString(u.AssignmentStr)
+	return sb.String()
+}
+
+// UseAsClosureVarReturn is when a value flows to a captured variable at a return of a function
+// literal that assigns it (see ClosureVarRetAnnotationKey).
+type UseAsClosureVarReturn struct {
+	*TriggerIfNonNil
+}
+
+// equals returns true if the passed ConsumingAnnotationTrigger is equal to this one
+func (u *UseAsClosureVarReturn) equals(other ConsumingAnnotationTrigger) bool {
+	if other, ok := other.(*UseAsClosureVarReturn); ok {
+		return u.TriggerIfNonNil.equals(other.TriggerIfNonNil)
+	}
+	return false
+}
+
+// Copy returns a deep copy of this ConsumingAnnotationTrigger
+func (u *UseAsClosureVarReturn) Copy() ConsumingAnnotationTrigger {
+	copyConsumer := *u
+	copyConsumer.TriggerIfNonNil = u.TriggerIfNonNil.Copy().(*TriggerIfNonNil)
+	return &copyConsumer
+}
+
+// Prestring returns this UseAsClosureVarReturn as a Prestring
+func (u *UseAsClosureVarReturn) Prestring() Prestring {
+	key := u.Ann.(*ClosureVarRetAnnotationKey)
+	return UseAsClosureVarReturnPrestring{
+		key.Var.Name(),
+		u.String(),
+	}
+}
+
+// UseAsClosureVarReturnPrestring is a Prestring storing the needed information to compactly encode a UseAsClosureVarReturn
+type UseAsClosureVarReturnPrestring struct {
+	VarName       string
+	AssignmentStr string
+}
+
+func (u UseAsClosureVarReturnPrestring) String() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "left in captured variable `%s` on return of function literal", u.VarName)
+	sb.WriteString(u.AssignmentStr)
+	return sb.String()
+}
+
 // UseAsFldOfReturn is when a struct field value (A.f) flows to a point where it is returned from a function with the
 // return expression of the same struct type (A)
 type UseAsFldOfReturn struct {
@@ -17,7 +17,9 @@ package annotation
 import (
 	"testing"
 
+	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
+	"go.uber.org/nilaway/config"
 )
 
 const _interfaceNameConsumingAnnotationTrigger = "ConsumingAnnotationTrigger"
@@ -31,6 +33,8 @@ var initStructsConsumingAnnotationTrigger = []any{
 	&MapAccess{ConsumeTriggerTautology: &ConsumeTriggerTautology{}},
 	&MapWrittenTo{ConsumeTriggerTautology: &ConsumeTriggerTautology{}},
 	&SliceAccess{ConsumeTriggerTautology: &ConsumeTriggerTautology{}},
+	&TypeAssert{ConsumeTriggerTautology: &ConsumeTriggerTautology{}},
+	&ClosedChanSend{ConsumeTriggerTautology: &ConsumeTriggerTautology{}},
 	&FldAccess{ConsumeTriggerTautology: &ConsumeTriggerTautology{}},
 	&UseAsErrorResult{TriggerIfNonNil: &TriggerIfNonNil{Ann: newMockKey()}},
 	&FldAssign{TriggerIfNonNil: &TriggerIfNonNil{Ann: newMockKey()}},
@@ -42,6 +46,8 @@ var initStructsConsumingAnnotationTrigger = []any{
 	&MethodParamFromInterface{TriggerIfNonNil: &TriggerIfNonNil{Ann: newMockKey()}},
 	&UseAsReturn{TriggerIfNonNil: &TriggerIfNonNil{Ann: newMockKey()}},
 	&UseAsFldOfReturn{TriggerIfNonNil: &TriggerIfNonNil{Ann: newMockKey()}},
+	&UseAsFuncValueResult{TriggerIfNonNil: &TriggerIfNonNil{Ann: newMockKey()}},
+	&UseAsClosureVarReturn{TriggerIfNonNil: &TriggerIfNonNil{Ann: newMockKey()}},
 	&SliceAssign{TriggerIfDeepNonNil: &TriggerIfDeepNonNil{Ann: newMockKey()}},
 	&ArrayAssign{TriggerIfDeepNonNil: &TriggerIfDeepNonNil{Ann: newMockKey()}},
 	&PtrAssign{TriggerIfDeepNonNil: &TriggerIfDeepNonNil{Ann: newMockKey()}},
@@ -91,3 +97,34 @@ func TestConsumingAnnotationTriggerCopySuite(t *testing.T) {
 	t.Parallel()
 	suite.Run(t, new(ConsumingAnnotationTriggerCopyTestSuite))
 }
+
+func TestConsumerCategory(t *testing.T) {
+	t.Parallel()
+
+	tests := map[Prestring]config.Category{
+		PtrLoadPrestring{}:                                       config.CategoryDereference,
+		FldAccessPrestring{}:                                     config.CategoryDereference,
+		RecvPassPrestring{}:                                      config.CategoryMethodReceiver,
+		MapWrittenToPrestring{}:                                  config.CategoryMapAccess,
+		SliceAccessPrestring{}:                                   config.CategorySliceAccess,
+		TypeAssertPrestring{}:                                    config.CategoryTypeAssertion,
+		ClosedChanSendPrestring{}:                                config.CategoryClosedChannel,
+		UseAsErrorResultPrestring{}:                              config.CategoryErrorReturn,
+		UseAsErrorRetWithNilabilityUnknownPrestring{}:            config.CategoryErrorReturn,
+		UseAsNonErrorRetDependentOnErrorRetNilabilityPrestring{}: config.CategoryErrorReturn,
+		UseAsReturnPrestring{}:                                   config.CategoryReturn,
+		ArgFldPassPrestring{}:                                    config.CategoryArgument,
+		MethodParamFromInterfacePrestring{}:                      config.CategoryInterfaceContract,
+		FldAssignPrestring{}:                                     config.CategoryFieldAssign,
+		GlobalVarAssignPrestring{}:                               config.CategoryGlobalAssign,
+		ChanSendPrestring{}:                                      config.CategoryDeepNilability,
+		LocalVarAssignDeepPrestring{}:                            config.CategoryDeepNilability,
+		FldEscapePrestring{}:                                     config.CategoryFieldEscape,
+		TriggerIfNonNilPrestring{}:                               config.CategoryOther,
+		FuncReturnPrestring{}:                                    config.CategoryOther,
+		LocatedPrestring{Contained: MapAccessPrestring{}}:        config.CategoryMapAccess,
+	}
+	for p, expected := range tests {
+		require.Equal(t, expected, ConsumerCategory(p), "%T", p)
+	}
+}
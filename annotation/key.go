@@ -340,6 +340,103 @@ func NewCallSiteRetKey(fdecl *types.Func, retNum int, location token.Position) *
 	}
 }
 
+// FuncValueRetAnnotationKey represents the site of a result of a call to a function value (e.g.,
+// `f()` where `f` is a variable or a struct field of function type). The results of all the
+// possible targets of the function value (see functionvalues.Analyzer) flow into this site, and
+// there is a new FuncValueRetAnnotationKey for every call.
+type FuncValueRetAnnotationKey struct {
+	// FuncValue is the variable (or struct field) of function type being called
+	FuncValue *types.Var
+	RetNum    int // which result
+	Location  token.Position
+}
+
+// Lookup looks this key up in the passed map, returning a Val.
+func (fk *FuncValueRetAnnotationKey) Lookup(_ Map) (Val, bool) {
+	return nonAnnotatedDefault, false
+}
+
+// Object returns the types.Object that this annotation can best be interpreted as annotating.
+func (fk *FuncValueRetAnnotationKey) Object() types.Object {
+	return fk.FuncValue
+}
+
+// equals returns true if the passed key is equal to this key
+func (fk *FuncValueRetAnnotationKey) equals(other Key) bool {
+	if other, ok := other.(*FuncValueRetAnnotationKey); ok {
+		return *fk == *other
+	}
+	return false
+}
+
+func (fk *FuncValueRetAnnotationKey) copy() Key {
+	copyKey := *fk
+	return &copyKey
+}
+
+func (fk *FuncValueRetAnnotationKey) String() string {
+	return fmt.Sprintf("Result %d of Function Value %s at Location %v",
+		fk.RetNum, fk.FuncValue.Name(), fk.Location)
+}
+
+// NewFuncValueRetKey returns a new instance of FuncValueRetAnnotationKey for the retNum-th result
+// of the call to the function value funcValue at the location.
+func NewFuncValueRetKey(funcValue *types.Var, retNum int, location token.Position) *FuncValueRetAnnotationKey {
+	return &FuncValueRetAnnotationKey{
+		FuncValue: funcValue,
+		RetNum:    retNum,
+		Location:  location,
+	}
+}
+
+// ClosureVarRetAnnotationKey represents the value of a captured variable when a function literal
+// that assigns it returns (e.g., `x` in `var x *T; once.Do(func() { x = new(T) })`). The values of
+// the variable at the returns of the function literal flow into this site, which in turn flows
+// into the variable in the enclosing function after each call that runs the literal synchronously.
+type ClosureVarRetAnnotationKey struct {
+	// FuncLit is the fake function object created for the function literal (see anonymousfunc.Analyzer)
+	FuncLit *types.Func
+	// Var is the captured variable
+	Var *types.Var
+}
+
+// Lookup looks this key up in the passed map, returning a Val.
+func (ck *ClosureVarRetAnnotationKey) Lookup(_ Map) (Val, bool) {
+	return nonAnnotatedDefault, false
+}
+
+// Object returns the types.Object that this annotation can best be interpreted as annotating.
+func (ck *ClosureVarRetAnnotationKey) Object() types.Object {
+	return ck.Var
+}
+
+// equals returns true if the passed key is equal to this key
+func (ck *ClosureVarRetAnnotationKey) equals(other Key) bool {
+	if other, ok := other.(*ClosureVarRetAnnotationKey); ok {
+		return *ck == *other
+	}
+	return false
+}
+
+func (ck *ClosureVarRetAnnotationKey) copy() Key {
+	copyKey := *ck
+	return &copyKey
+}
+
+func (ck *ClosureVarRetAnnotationKey) String() string {
+	return fmt.Sprintf("Captured Variable %s on Return of Function %s",
+		ck.Var.Name(), ck.FuncLit.Name())
+}
+
+// NewClosureVarRetKey returns a new instance of ClosureVarRetAnnotationKey for the captured
+// variable v of the function literal represented by the fake function object funcLit.
+func NewClosureVarRetKey(funcLit *types.Func, v *types.Var) *ClosureVarRetAnnotationKey {
+	return &ClosureVarRetAnnotationKey{
+		FuncLit: funcLit,
+		Var:     v,
+	}
+}
+
 // RetAnnotationKey allows the Lookup of a function's return Annotation in the Annotation Map
 type RetAnnotationKey struct {
 	FuncDecl *types.Func
@@ -489,7 +586,7 @@ func (lk *LocalVarAnnotationKey) String() string {
 
 // RetFieldAnnotationKey allows the Lookup of the Annotation on a specific field within a function's return of struct
 // (or pointer to struct) type, in the Annotation Map. This key is only effective when the struct initialization checking
-// is enabled.
+// or the object sensitive tracking of fields (in full inference mode) is enabled.
 //
 // TODO: Add support for field of function return with no inference (Currently, only works with inference)
 type RetFieldAnnotationKey struct {
@@ -28,6 +28,8 @@ var initStructsKey = []any{
 	&CallSiteParamAnnotationKey{},
 	&ParamAnnotationKey{},
 	&CallSiteRetAnnotationKey{},
+	&FuncValueRetAnnotationKey{},
+	&ClosureVarRetAnnotationKey{},
 	&RetAnnotationKey{},
 	&TypeNameAnnotationKey{},
 	&GlobalVarAnnotationKey{},
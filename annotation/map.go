@@ -164,6 +164,16 @@ type ObservedMap struct {
 	// funcCallSiteRetAnnMap maps a function call site to a slice with the annotations of its
 	// duplicated returns at the call site.
 	funcCallSiteRetAnnMap map[CallSite][]Val
+
+	// invalidStubs stores the declarations in the annotation stub files that do not match the
+	// packages imported by the current package, and are hence ignored.
+	invalidStubs []InvalidStub
+}
+
+// InvalidStubs returns the declarations in the annotation stub files that do not match any object
+// of the stubbed packages imported by the current package (see InvalidStub).
+func (m *ObservedMap) InvalidStubs() []InvalidStub {
+	return m.invalidStubs
 }
 
 // CallSite uniquely identifies a function call. It contains the called function object and the
@@ -275,6 +285,35 @@ var seqRegexStr = fmt.Sprintf("%s\\((\\s*%s\\s*(%s\\s*%s\\s*)*)\\)",
 	annotationKeyword, deepIdentRegexStr, sep, deepIdentRegexStr)
 var seqRegex = regexp.MustCompile(seqRegexStr)
 
+// wholeSeqRegex matches a comment text (including the leading "//") that consists of a single
+// annotation only.
+var wholeSeqRegex = regexp.MustCompile(fmt.Sprintf("^//\\s*%s\\s*$", seqRegexStr))
+
+// FormatAnnotation returns the comment text (including the leading "//") annotating the given
+// identifiers as nilable (or nonnil). The identifiers are in the forms accepted by the annotation
+// parser: names (e.g., "x"), positions of unnamed parameters and results (e.g., "result 0"), or
+// deep forms of them (e.g., "*x", "x[]" or "<-x").
+func FormatAnnotation(nilable bool, idents ...string) string {
+	keyword := nonNilKeyword
+	if nilable {
+		keyword = nilableKeyword
+	}
+	return fmt.Sprintf("// %s(%s)", keyword, strings.Join(idents, sep+" "))
+}
+
+// ParseAnnotation is the inverse of FormatAnnotation: it returns the annotated identifiers of a
+// comment text (including the leading "//") if it consists of a single annotation only.
+func ParseAnnotation(text string) (nilable bool, idents []string, ok bool) {
+	match := wholeSeqRegex.FindStringSubmatch(text)
+	if match == nil {
+		return false, nil, false
+	}
+	for ident := range strings.SplitSeq(match[2], sep) {
+		idents = append(idents, strings.TrimSpace(ident))
+	}
+	return match[1] == nilableKeyword, idents, true
+}
+
 type nilabilitySet map[string]Val
 
 // from a CommentGroup return a nilabilitySet of which identifiers are known annotated nilable
@@ -366,6 +405,20 @@ func TypeIsDefaultNilable(t types.Type) bool {
 		return true
 	}
 
+	// Similarly, type parameters whose type sets only contain slices, maps, or channels (e.g.,
+	// `S ~[]E`) should be nilable by default.
+	if tp, ok := t.(*types.TypeParam); ok {
+		terms := typeshelper.TypeParamTerms(tp)
+		for _, term := range terms {
+			switch term.(type) {
+			case *types.Slice, *types.Map, *types.Chan:
+			default:
+				return false
+			}
+		}
+		return len(terms) > 0
+	}
+
 	return false
 }
 
@@ -394,6 +447,10 @@ func TypeIsDeepDefaultNilable(t types.Type) bool {
 		return TypeIsDefaultNilable(t.Elem())
 	case *types.Named:
 		return TypeIsDeepDefaultNilable(t.Underlying())
+	case *types.TypeParam:
+		if core := typeshelper.CoreType(t); core != nil {
+			return TypeIsDeepDefaultNilable(core)
+		}
 	}
 	return false
 }
@@ -600,7 +657,8 @@ func newObservedMap(pass *analysishelper.EnhancedPass, files []*ast.File) *Obser
 								case *ast.ChanType:
 									// TODO - treat channel types as deeply nilable at the typedef level
 								case *ast.IndexExpr, *ast.IndexListExpr:
-									// TODO - handle generics
+									// instantiated generic type - do nothing, since the fields and
+									// methods are resolved to the ones of the generic type
 								case *ast.ParenExpr:
 									handleTypeVal(typeVal.X)
 								default:
@@ -682,7 +740,7 @@ func newObservedMap(pass *analysishelper.EnhancedPass, files []*ast.File) *Obser
 			return true
 		})
 	}
-	return &ObservedMap{
+	m := &ObservedMap{
 		fieldAnnMap:             fieldAnnMap,
 		funcParamAnnMap:         funcParamAnnMap,
 		funcRetAnnMap:           funcRetAnnMap,
@@ -692,6 +750,8 @@ func newObservedMap(pass *analysishelper.EnhancedPass, files []*ast.File) *Obser
 		funcCallSiteParamAnnMap: funcCallSiteParamAnnMap,
 		funcCallSiteRetAnnMap:   funcCallSiteRetAnnMap,
 	}
+	m.invalidStubs = invalidStubs(m.addStubAnnotations(pass.Pkg, conf), files)
+	return m
 }
 
 func getLineFromPos(pos token.Pos, pass *analysishelper.EnhancedPass) int {
@@ -0,0 +1,52 @@
+//  Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package annotation
+
+import (
+	"go/ast"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormatAndParseAnnotation(t *testing.T) {
+	t.Parallel()
+
+	text := FormatAnnotation(true, "x", "result 0", "*p", "s[]", "<-c")
+	require.Equal(t, "// nilable(x, result 0, *p, s[], <-c)", text)
+
+	// The formatted annotation must be understood by the annotation parser.
+	set := nilabilityFromCommentGroup(&ast.CommentGroup{List: []*ast.Comment{{Text: text}}})
+	require.True(t, set["x"].IsNilable)
+	require.True(t, set["result 0"].IsNilable)
+	require.True(t, set["p"].IsDeepNilable)
+	require.True(t, set["s"].IsDeepNilable)
+	require.True(t, set["c"].IsDeepNilable)
+
+	nilable, idents, ok := ParseAnnotation(text)
+	require.True(t, ok)
+	require.True(t, nilable)
+	require.Equal(t, []string{"x", "result 0", "*p", "s[]", "<-c"}, idents)
+
+	nilable, idents, ok = ParseAnnotation("//nonnil( a,b )")
+	require.True(t, ok)
+	require.False(t, nilable)
+	require.Equal(t, []string{"a", "b"}, idents)
+
+	for _, text := range []string{"// foo returns nilable(x)", "// nilable(x) because of y", "// nilable()"} {
+		_, _, ok := ParseAnnotation(text)
+		require.False(t, ok, text)
+	}
+}
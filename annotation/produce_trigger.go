@@ -798,6 +798,128 @@ func (f FuncReturnPrestring) String() string {
 	return sb.String()
 }
 
+// FuncValueReturn is used when a value is determined to flow from the result of a call to a
+// function value, whose possible targets are resolved (see functionvalues.Analyzer). The results
+// of the targets flow into the FuncValueRetAnnotationKey of the call.
+type FuncValueReturn struct {
+	*TriggerIfNilable
+}
+
+// equals returns true if the passed ProducingAnnotationTrigger is equal to this one
+func (f *FuncValueReturn) equals(other ProducingAnnotationTrigger) bool {
+	if other, ok := other.(*FuncValueReturn); ok {
+		return f.TriggerIfNilable.equals(other.TriggerIfNilable)
+	}
+	return false
+}
+
+// Prestring returns this FuncValueReturn as a Prestring
+func (f *FuncValueReturn) Prestring() Prestring {
+	key := f.Ann.(*FuncValueRetAnnotationKey)
+	return FuncValueReturnPrestring{key.RetNum, key.FuncValue.Name()}
+}
+
+// FuncValueReturnPrestring is a Prestring storing the needed information to compactly encode a FuncValueReturn
+type FuncValueReturnPrestring struct {
+	RetNum        int
+	FuncValueName string
+}
+
+func (f FuncValueReturnPrestring) String() string {
+	return fmt.Sprintf("result %d of function value `%s()`", f.RetNum, f.FuncValueName)
+}
+
+// ClosureVarReturn is used when a value is determined to flow from a captured variable assigned by
+// a function literal, after a call that runs the literal synchronously (see
+// ClosureVarRetAnnotationKey).
+type ClosureVarReturn struct {
+	*TriggerIfNilable
+}
+
+// equals returns true if the passed ProducingAnnotationTrigger is equal to this one
+func (c *ClosureVarReturn) equals(other ProducingAnnotationTrigger) bool {
+	if other, ok := other.(*ClosureVarReturn); ok {
+		return c.TriggerIfNilable.equals(other.TriggerIfNilable)
+	}
+	return false
+}
+
+// Prestring returns this ClosureVarReturn as a Prestring
+func (c *ClosureVarReturn) Prestring() Prestring {
+	key := c.Ann.(*ClosureVarRetAnnotationKey)
+	return ClosureVarReturnPrestring{key.Var.Name()}
+}
+
+// ClosureVarReturnPrestring is a Prestring storing the needed information to compactly encode a ClosureVarReturn
+type ClosureVarReturnPrestring struct {
+	VarName string
+}
+
+func (c ClosureVarReturnPrestring) String() string {
+	return fmt.Sprintf("captured variable `%s` updated by function literal", c.VarName)
+}
+
+// UnresolvedFuncValueReturn is used when a value is determined to flow from the result of a call
+// to a function value, whose targets cannot be fully resolved (e.g., the function value is passed
+// from another package or read from a map). Such results are trusted to be nonnil, as for the
+// calls to unknown functions, to avoid false positives for every callback API.
+type UnresolvedFuncValueReturn struct {
+	*ProduceTriggerNever
+
+	RetNum        int
+	FuncValueName string
+}
+
+// equals returns true if the passed ProducingAnnotationTrigger is equal to this one
+func (u *UnresolvedFuncValueReturn) equals(other ProducingAnnotationTrigger) bool {
+	if other, ok := other.(*UnresolvedFuncValueReturn); ok {
+		return u.ProduceTriggerNever.equals(other.ProduceTriggerNever) &&
+			u.RetNum == other.RetNum && u.FuncValueName == other.FuncValueName
+	}
+	return false
+}
+
+// Prestring returns this UnresolvedFuncValueReturn as a Prestring
+func (u *UnresolvedFuncValueReturn) Prestring() Prestring {
+	return UnresolvedFuncValueReturnPrestring{u.RetNum, u.FuncValueName}
+}
+
+// UnresolvedFuncValueGuardedReturn is used when a value is determined to flow from a non-error
+// result of a call to an error-returning function value, whose targets cannot be fully resolved.
+// Following the error contract, such results are nonnil if the error result is checked, and
+// nilable otherwise.
+type UnresolvedFuncValueGuardedReturn struct {
+	*ProduceTriggerNever
+
+	RetNum        int
+	FuncValueName string
+}
+
+// equals returns true if the passed ProducingAnnotationTrigger is equal to this one
+func (u *UnresolvedFuncValueGuardedReturn) equals(other ProducingAnnotationTrigger) bool {
+	if other, ok := other.(*UnresolvedFuncValueGuardedReturn); ok {
+		return u.ProduceTriggerNever.equals(other.ProduceTriggerNever) &&
+			u.RetNum == other.RetNum && u.FuncValueName == other.FuncValueName
+	}
+	return false
+}
+
+// Prestring returns this UnresolvedFuncValueGuardedReturn as a Prestring
+func (u *UnresolvedFuncValueGuardedReturn) Prestring() Prestring {
+	return UnresolvedFuncValueReturnPrestring{u.RetNum, u.FuncValueName}
+}
+
+// UnresolvedFuncValueReturnPrestring is a Prestring storing the needed information to compactly encode a
+// UnresolvedFuncValueReturn or UnresolvedFuncValueGuardedReturn
+type UnresolvedFuncValueReturnPrestring struct {
+	RetNum        int
+	FuncValueName string
+}
+
+func (u UnresolvedFuncValueReturnPrestring) String() string {
+	return fmt.Sprintf("result %d of function value `%s()` with unresolved targets", u.RetNum, u.FuncValueName)
+}
+
 // MethodReturn is used when a value is determined to flow from the return of a method
 type MethodReturn struct {
 	*TriggerIfNilable
@@ -1072,6 +1194,104 @@ func (c ChanRecvPrestring) String() string {
 	return fmt.Sprintf("received from a channel of type `%s`", c.TypeName)
 }
 
+// TypeAssertResult is when a value is determined to flow from a type assertion in the `v, ok := x.(T)`
+// form, where `T` is a nilable type. The value is nonnil only if `ok` is checked, and the zero value
+// of `T` (i.e., nil) otherwise.
+type TypeAssertResult struct {
+	*ProduceTriggerNever
+
+	TypeName string
+}
+
+// equals returns true if the passed ProducingAnnotationTrigger is equal to this one
+func (t *TypeAssertResult) equals(other ProducingAnnotationTrigger) bool {
+	if other, ok := other.(*TypeAssertResult); ok {
+		return t.ProduceTriggerNever.equals(other.ProduceTriggerNever) && t.TypeName == other.TypeName
+	}
+	return false
+}
+
+// Prestring returns this TypeAssertResult as a Prestring
+func (t *TypeAssertResult) Prestring() Prestring {
+	return TypeAssertResultPrestring{t.TypeName}
+}
+
+// TypeAssertResultPrestring is a Prestring storing the needed information to compactly encode a TypeAssertResult
+type TypeAssertResultPrestring struct {
+	TypeName string
+}
+
+func (t TypeAssertResultPrestring) String() string {
+	return fmt.Sprintf("result of type assertion to `%s`", t.TypeName)
+}
+
+// ClosedChanRecv is when a value is determined to flow from a receive on a channel that may have
+// been closed in the same function, which yields the zero value of the element type (i.e., nil).
+// Note that receives in the `v, ok := <-ch` form and `for range` loops are not affected.
+type ClosedChanRecv struct {
+	*ProduceTriggerTautology
+
+	ChanName  string
+	CloseLine int
+}
+
+// equals returns true if the passed ProducingAnnotationTrigger is equal to this one
+func (c *ClosedChanRecv) equals(other ProducingAnnotationTrigger) bool {
+	if other, ok := other.(*ClosedChanRecv); ok {
+		return c.ProduceTriggerTautology.equals(other.ProduceTriggerTautology) &&
+			c.ChanName == other.ChanName && c.CloseLine == other.CloseLine
+	}
+	return false
+}
+
+// Prestring returns this ClosedChanRecv as a Prestring
+func (c *ClosedChanRecv) Prestring() Prestring {
+	return ClosedChanRecvPrestring{c.ChanName, c.CloseLine}
+}
+
+// ClosedChanRecvPrestring is a Prestring storing the needed information to compactly encode a ClosedChanRecv
+type ClosedChanRecvPrestring struct {
+	ChanName  string
+	CloseLine int
+}
+
+func (c ClosedChanRecvPrestring) String() string {
+	return fmt.Sprintf("received from channel `%s` closed at line %d", c.ChanName, c.CloseLine)
+}
+
+// ChanClose is used when a channel is closed, and is always paired with a ClosedChanSend for a
+// send on the channel afterward in the same function.
+type ChanClose struct {
+	*ProduceTriggerTautology
+
+	ChanName  string
+	CloseLine int
+}
+
+// equals returns true if the passed ProducingAnnotationTrigger is equal to this one
+func (c *ChanClose) equals(other ProducingAnnotationTrigger) bool {
+	if other, ok := other.(*ChanClose); ok {
+		return c.ProduceTriggerTautology.equals(other.ProduceTriggerTautology) &&
+			c.ChanName == other.ChanName && c.CloseLine == other.CloseLine
+	}
+	return false
+}
+
+// Prestring returns this ChanClose as a Prestring
+func (c *ChanClose) Prestring() Prestring {
+	return ChanClosePrestring{c.ChanName, c.CloseLine}
+}
+
+// ChanClosePrestring is a Prestring storing the needed information to compactly encode a ChanClose
+type ChanClosePrestring struct {
+	ChanName  string
+	CloseLine int
+}
+
+func (c ChanClosePrestring) String() string {
+	return fmt.Sprintf("channel `%s` closed at line %d", c.ChanName, c.CloseLine)
+}
+
 // FuncParamDeep is used when a value is determined to flow deeply from a function parameter
 type FuncParamDeep struct {
 	*TriggerIfDeepNilable
@@ -60,6 +60,10 @@ func (s *ProducingAnnotationTriggerTestSuite) SetupTest() {
 		&ParamFldRead{TriggerIfNilable: &TriggerIfNilable{Ann: mockedKey}},
 		&FldReturn{TriggerIfNilable: &TriggerIfNilable{Ann: mockedKey}},
 		&FuncReturn{TriggerIfNilable: &TriggerIfNilable{Ann: mockedKey}},
+		&FuncValueReturn{TriggerIfNilable: &TriggerIfNilable{Ann: mockedKey}},
+		&UnresolvedFuncValueReturn{ProduceTriggerNever: &ProduceTriggerNever{}},
+		&UnresolvedFuncValueGuardedReturn{ProduceTriggerNever: &ProduceTriggerNever{}},
+		&ClosureVarReturn{TriggerIfNilable: &TriggerIfNilable{Ann: mockedKey}},
 		&MethodReturn{TriggerIfNilable: &TriggerIfNilable{Ann: mockedKey}},
 		&MethodResultReachesInterface{TriggerIfNilable: &TriggerIfNilable{Ann: mockedKey}},
 		&InterfaceParamReachesImplementation{TriggerIfNilable: &TriggerIfNilable{Ann: mockedKey}},
@@ -69,6 +73,9 @@ func (s *ProducingAnnotationTriggerTestSuite) SetupTest() {
 		&SliceRead{TriggerIfDeepNilable: &TriggerIfDeepNilable{Ann: mockedKey}},
 		&PtrRead{TriggerIfDeepNilable: &TriggerIfDeepNilable{Ann: mockedKey}},
 		&ChanRecv{TriggerIfDeepNilable: &TriggerIfDeepNilable{Ann: mockedKey}},
+		&TypeAssertResult{ProduceTriggerNever: &ProduceTriggerNever{}},
+		&ClosedChanRecv{ProduceTriggerTautology: &ProduceTriggerTautology{}},
+		&ChanClose{ProduceTriggerTautology: &ProduceTriggerTautology{}},
 		&FuncParamDeep{TriggerIfDeepNilable: &TriggerIfDeepNilable{Ann: mockedKey}},
 		&VariadicFuncParamDeep{TriggerIfNilable: &TriggerIfNilable{Ann: mockedKey}},
 		&FuncReturnDeep{TriggerIfDeepNilable: &TriggerIfDeepNilable{Ann: mockedKey}},
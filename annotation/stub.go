@@ -0,0 +1,319 @@
+//  Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package annotation
+
+import (
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"strconv"
+
+	"go.uber.org/nilaway/config"
+)
+
+// InvalidStub is a declaration in an annotation stub file (see config.AnnotationStubsFlag) that does
+// not match any object of the stubbed package (e.g., due to a typo or a version mismatch), and is
+// hence ignored by the analysis.
+type InvalidStub struct {
+	// Pos is the position of the import of the stubbed package in the analyzed package, where the
+	// declaration is reported since the stub files are not part of the analyzed sources.
+	Pos token.Pos
+	// Reason describes the declaration, including its position in the stub file, and why it does
+	// not match.
+	Reason string
+}
+
+// stubMismatch is a declaration in an annotation stub file that does not match any object of the
+// stubbed package, see InvalidStub.
+type stubMismatch struct {
+	pkgPath string
+	reason  string
+}
+
+// addStubAnnotations reads the annotations of the user-provided stub files (see
+// config.AnnotationStubsFlag) into this map. Stubs only apply to the (transitively) imported
+// packages that are out of scope for analysis, such that they neither conflict with the
+// annotations nor with the inferred nilabilities of analyzed packages. Stub declarations that do
+// not match any object in the stubbed package (e.g., due to a typo or a version mismatch) are
+// returned, while the other declarations still apply.
+func (m *ObservedMap) addStubAnnotations(pkg *types.Package, conf *config.Config) []stubMismatch {
+	if len(conf.AnnotationStubs) == 0 {
+		return nil
+	}
+
+	outOfScope := make(map[string]*types.Package)
+	var collect func(p *types.Package)
+	collect = func(p *types.Package) {
+		for _, imported := range p.Imports() {
+			if _, ok := outOfScope[imported.Path()]; ok {
+				continue
+			}
+			outOfScope[imported.Path()] = imported
+			collect(imported)
+		}
+	}
+	collect(pkg)
+
+	var mismatches []stubMismatch
+	for _, stub := range conf.AnnotationStubs {
+		p, ok := outOfScope[stub.PkgPath]
+		if !ok || conf.IsPkgInScope(p) {
+			continue
+		}
+		addErr := func(pos token.Pos, err error) {
+			if err != nil {
+				mismatches = append(mismatches, stubMismatch{
+					pkgPath: stub.PkgPath,
+					reason:  fmt.Sprintf("%s: %v", stub.Fset.Position(pos), err),
+				})
+			}
+		}
+		for _, decl := range stub.File.Decls {
+			switch decl := decl.(type) {
+			case *ast.FuncDecl:
+				addErr(decl.Pos(), m.addStubFunc(p, decl))
+			case *ast.GenDecl:
+				for _, spec := range decl.Specs {
+					// As in the source code, the annotations of a single declaration are written
+					// in the doc string of the keyword, and the ones of grouped declarations in
+					// the doc strings of the specs.
+					doc := decl.Doc
+					if len(decl.Specs) > 1 {
+						doc = specDoc(spec)
+					}
+					addErr(spec.Pos(), m.addStubSpec(p, spec, nilabilityFromCommentGroup(doc)))
+				}
+			}
+		}
+	}
+	return mismatches
+}
+
+// invalidStubs positions the mismatched stub declarations at the imports of the stubbed packages in
+// the given files. The mismatches of the packages that are only imported transitively are dropped,
+// since they are reported on the packages importing them directly.
+func invalidStubs(mismatches []stubMismatch, files []*ast.File) []InvalidStub {
+	if len(mismatches) == 0 {
+		return nil
+	}
+
+	importPos := make(map[string]token.Pos)
+	for _, file := range files {
+		for _, spec := range file.Imports {
+			path, err := strconv.Unquote(spec.Path.Value)
+			if err != nil {
+				continue
+			}
+			if _, ok := importPos[path]; !ok {
+				importPos[path] = spec.Pos()
+			}
+		}
+	}
+
+	var invalid []InvalidStub
+	for _, s := range mismatches {
+		if pos, ok := importPos[s.pkgPath]; ok {
+			invalid = append(invalid, InvalidStub{Pos: pos, Reason: s.reason})
+		}
+	}
+	return invalid
+}
+
+// addStubFunc reads the annotations of a function or method declared in a stub file, or returns an
+// error if the declaration does not match any function or method of the package.
+func (m *ObservedMap) addStubFunc(pkg *types.Package, decl *ast.FuncDecl) error {
+	var funcObj *types.Func
+	if decl.Recv == nil {
+		funcObj, _ = pkg.Scope().Lookup(decl.Name.Name).(*types.Func)
+	} else if tn, ok := pkg.Scope().Lookup(config.StubRecvTypeName(decl.Recv)).(*types.TypeName); ok {
+		if named, ok := types.Unalias(tn.Type()).(*types.Named); ok {
+			for i := range named.NumMethods() {
+				if method := named.Method(i); method.Name() == decl.Name.Name {
+					funcObj = method
+					break
+				}
+			}
+		}
+	}
+	name := fmt.Sprintf("function %q", decl.Name.Name)
+	if decl.Recv != nil {
+		name = fmt.Sprintf("method %q of type %q", decl.Name.Name, config.StubRecvTypeName(decl.Recv))
+	}
+	if funcObj == nil {
+		return fmt.Errorf("%s not found in package %q", name, pkg.Path())
+	}
+
+	sig := funcObj.Type().(*types.Signature)
+	set := nilabilityFromCommentGroup(decl.Doc)
+	params, ok := stubVals(set, decl.Type.Params, sig.Params(), sig.Variadic(), paramStr)
+	if !ok {
+		return fmt.Errorf("parameters of %s do not match the ones in package %q", name, pkg.Path())
+	}
+	results, ok := stubVals(set, decl.Type.Results, sig.Results(), false, resultStr)
+	if !ok {
+		return fmt.Errorf("results of %s do not match the ones in package %q", name, pkg.Path())
+	}
+	m.funcParamAnnMap[funcObj] = params
+	m.funcRetAnnMap[funcObj] = results
+	if recv := sig.Recv(); recv != nil {
+		name := ""
+		if names := decl.Recv.List[0].Names; len(names) == 1 {
+			name = names[0].Name
+		}
+		m.funcRecvAnnMap[funcObj] = set.checkNilability(name, recv.Type())
+	}
+	return nil
+}
+
+// addStubSpec reads the annotations of a global variable or a type declared in a stub file, or
+// returns an error if (a part of) the declaration does not match any object of the package.
+func (m *ObservedMap) addStubSpec(pkg *types.Package, spec ast.Spec, set nilabilitySet) error {
+	switch spec := spec.(type) {
+	case *ast.ValueSpec:
+		var errs []error
+		for _, name := range spec.Names {
+			v, ok := pkg.Scope().Lookup(name.Name).(*types.Var)
+			if !ok {
+				errs = append(errs, fmt.Errorf("variable %q not found in package %q", name.Name, pkg.Path()))
+				continue
+			}
+			m.globalVarsAnnMap[v] = set.checkNilability(name.Name, v.Type())
+		}
+		return errors.Join(errs...)
+	case *ast.TypeSpec:
+		tn, ok := pkg.Scope().Lookup(spec.Name.Name).(*types.TypeName)
+		if !ok || tn.IsAlias() {
+			return fmt.Errorf("type %q not found in package %q", spec.Name.Name, pkg.Path())
+		}
+		switch typeVal := spec.Type.(type) {
+		case *ast.StructType:
+			st, ok := tn.Type().Underlying().(*types.Struct)
+			if !ok {
+				return fmt.Errorf("type %q is not a struct in package %q", spec.Name.Name, pkg.Path())
+			}
+			var errs []error
+			for _, field := range typeVal.Fields.List {
+				for _, name := range field.Names {
+					var fld *types.Var
+					for i := range st.NumFields() {
+						if st.Field(i).Name() == name.Name {
+							fld = st.Field(i)
+							break
+						}
+					}
+					if fld == nil {
+						errs = append(errs, fmt.Errorf("field %q of type %q not found in package %q", name.Name, spec.Name.Name, pkg.Path()))
+						continue
+					}
+					m.fieldAnnMap[fld] = set.checkNilability(name.Name, fld.Type())
+				}
+			}
+			return errors.Join(errs...)
+		case *ast.InterfaceType:
+			it, ok := tn.Type().Underlying().(*types.Interface)
+			if !ok {
+				return fmt.Errorf("type %q is not an interface in package %q", spec.Name.Name, pkg.Path())
+			}
+			var errs []error
+			for _, method := range typeVal.Methods.List {
+				funcType, ok := method.Type.(*ast.FuncType)
+				if !ok || len(method.Names) != 1 {
+					continue
+				}
+				name := method.Names[0].Name
+				var funcObj *types.Func
+				for i := range it.NumExplicitMethods() {
+					if it.ExplicitMethod(i).Name() == name {
+						funcObj = it.ExplicitMethod(i)
+						break
+					}
+				}
+				if funcObj == nil {
+					errs = append(errs, fmt.Errorf("method %q of interface %q not found in package %q", name, spec.Name.Name, pkg.Path()))
+					continue
+				}
+				sig := funcObj.Type().(*types.Signature)
+				methodSet := nilabilityFromCommentGroup(method.Doc)
+				params, ok := stubVals(methodSet, funcType.Params, sig.Params(), sig.Variadic(), paramStr)
+				if !ok {
+					errs = append(errs, fmt.Errorf("parameters of method %q of interface %q do not match the ones in package %q", name, spec.Name.Name, pkg.Path()))
+					continue
+				}
+				results, ok := stubVals(methodSet, funcType.Results, sig.Results(), false, resultStr)
+				if !ok {
+					errs = append(errs, fmt.Errorf("results of method %q of interface %q do not match the ones in package %q", name, spec.Name.Name, pkg.Path()))
+					continue
+				}
+				m.funcParamAnnMap[funcObj] = params
+				m.funcRetAnnMap[funcObj] = results
+			}
+			return errors.Join(errs...)
+		case *ast.StarExpr, *ast.MapType, *ast.ArrayType:
+			m.deepTypeAnnMap[tn] = set.checkNilability(spec.Name.Name, tn.Type().Underlying())
+		}
+	}
+	return nil
+}
+
+// stubVals returns the annotations of the parameters (or results) of a function declared in a
+// stub file, looking them up in the docstring by the names used in the stub (or by their
+// positions for unnamed ones) and using the types of the actual function for the defaults. It
+// returns false if the stub declares a different number of them than the actual function.
+func stubVals(set nilabilitySet, fieldList *ast.FieldList, tuple *types.Tuple, variadic bool, posStr func(int) string) ([]Val, bool) {
+	var names []string
+	if fieldList != nil {
+		for _, field := range fieldList.List {
+			if len(field.Names) == 0 {
+				names = append(names, posStr(len(names)))
+				continue
+			}
+			for _, name := range field.Names {
+				names = append(names, name.Name)
+			}
+		}
+	}
+	if len(names) != tuple.Len() {
+		return nil, false
+	}
+
+	vals := make([]Val, 0, len(names))
+	for i, name := range names {
+		t := tuple.At(i).Type()
+		if variadic && i == tuple.Len()-1 {
+			// as for the source code, variadic arguments are treated as having type `T` rather
+			// than `[]T`
+			if s, ok := t.(*types.Slice); ok {
+				t = s.Elem()
+			}
+		}
+		vals = append(vals, set.checkNilability(name, t))
+	}
+	return vals, true
+}
+
+// specDoc returns the doc string of a spec in a grouped declaration.
+func specDoc(spec ast.Spec) *ast.CommentGroup {
+	switch spec := spec.(type) {
+	case *ast.ValueSpec:
+		return spec.Doc
+	case *ast.TypeSpec:
+		return spec.Doc
+	case *ast.ImportSpec:
+		return spec.Doc
+	}
+	return nil
+}
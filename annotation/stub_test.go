@@ -0,0 +1,103 @@
+//  Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package annotation
+
+import (
+	"go/token"
+	"go/types"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/nilaway/config"
+)
+
+func TestAddStubAnnotations(t *testing.T) {
+	t.Parallel()
+
+	// Build the stubbed package `example.com/foo` and the analyzed package importing it.
+	foo := types.NewPackage("example.com/foo", "foo")
+	intPtr := types.NewPointer(types.Typ[types.Int])
+	find := types.NewFunc(token.NoPos, foo, "Find", types.NewSignatureType(nil, nil, nil,
+		types.NewTuple(types.NewParam(token.NoPos, foo, "name", types.Typ[types.String])),
+		types.NewTuple(types.NewParam(token.NoPos, foo, "", intPtr)),
+		false,
+	))
+	foo.Scope().Insert(find)
+	parent := types.NewField(token.NoPos, foo, "Parent", intPtr, false)
+	item := types.NewTypeName(token.NoPos, foo, "Item", nil)
+	types.NewNamed(item, types.NewStruct([]*types.Var{parent}, nil), nil)
+	foo.Scope().Insert(item)
+	bar := types.NewPackage("example.com/bar", "bar")
+	bar.SetImports([]*types.Package{foo})
+
+	path := filepath.Join(t.TempDir(), "foo.nilaway")
+	content := `//nilaway:stub example.com/foo
+package foo
+
+// nilable(result 0)
+func Find(name string) *int
+
+// nilable(result 0)
+func Fnd(name string) *int
+
+// nilable(result 0)
+func (i *Item) Lookup() *int
+
+// nilable(Parent, Child)
+type Item struct {
+	Parent *int
+	Child  *int
+}
+
+// nilable(Default)
+var Default *int
+
+// nilable(result 0)
+func Find() *int
+`
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	stubs, err := config.LoadAnnotationStubs([]string{path})
+	require.NoError(t, err)
+
+	m := &ObservedMap{
+		fieldAnnMap:      make(map[*types.Var]Val),
+		funcParamAnnMap:  make(map[*types.Func][]Val),
+		funcRetAnnMap:    make(map[*types.Func][]Val),
+		funcRecvAnnMap:   make(map[*types.Func]Val),
+		deepTypeAnnMap:   make(map[*types.TypeName]Val),
+		globalVarsAnnMap: make(map[*types.Var]Val),
+	}
+	mismatches := m.addStubAnnotations(bar, &config.Config{AnnotationStubs: stubs})
+
+	// The declarations that do not match any object are returned with their positions.
+	var reasons []string
+	for _, s := range mismatches {
+		require.Equal(t, "example.com/foo", s.pkgPath)
+		reasons = append(reasons, s.reason)
+	}
+	require.Len(t, reasons, 5)
+	require.Contains(t, reasons[0], path+`:8:1: function "Fnd" not found in package "example.com/foo"`)
+	require.Contains(t, reasons[1], path+`:11:1: method "Lookup" of type "Item" not found`)
+	require.Contains(t, reasons[2], path+`:14:6: field "Child" of type "Item" not found`)
+	require.Contains(t, reasons[3], path+`:20:5: variable "Default" not found`)
+	require.Contains(t, reasons[4], path+`:23:1: parameters of function "Find" do not match`)
+
+	// The other declarations still apply.
+	require.Len(t, m.funcRetAnnMap[find], 1)
+	require.True(t, m.funcRetAnnMap[find][0].IsNilable)
+	require.True(t, m.fieldAnnMap[parent].IsNilable)
+}
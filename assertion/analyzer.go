@@ -52,7 +52,7 @@ func run(p *analysis.Pass) ([]annotation.FullTrigger, error) {
 	}
 
 	// Collect and merge the results from sub-analyzers.
-	r1 := pass.ResultOf[function.Analyzer].(*analysishelper.Result[[]annotation.FullTrigger])
+	r1 := pass.ResultOf[function.Analyzer].(*analysishelper.Result[function.Result])
 	r2 := pass.ResultOf[affiliation.Analyzer].(*analysishelper.Result[[]annotation.FullTrigger])
 	r3 := pass.ResultOf[global.Analyzer].(*analysishelper.Result[[]annotation.FullTrigger])
 	if err := errors.Join(r1.Err, r2.Err, r3.Err); err != nil {
@@ -60,8 +60,8 @@ func run(p *analysis.Pass) ([]annotation.FullTrigger, error) {
 	}
 
 	// Merge full triggers.
-	triggers := make([]annotation.FullTrigger, 0, len(r1.Res)+len(r2.Res)+len(r3.Res))
-	for _, t := range [...][]annotation.FullTrigger{r1.Res, r2.Res, r3.Res} {
+	triggers := make([]annotation.FullTrigger, 0, len(r1.Res.Triggers)+len(r2.Res)+len(r3.Res))
+	for _, t := range [...][]annotation.FullTrigger{r1.Res.Triggers, r2.Res, r3.Res} {
 		triggers = append(triggers, t...)
 	}
 
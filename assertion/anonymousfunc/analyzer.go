@@ -52,6 +52,16 @@ type FuncLitInfo struct {
 	// ClosureVars stores a slice of assigned / accessed variables from closure within each
 	// function literal in the order of their appearances.
 	ClosureVars []*VarInfo
+	// AssignedVars stores the subset of ClosureVars that are assigned within the function literal
+	// (or its nested function literals). Their values on return of the function literal flow back
+	// to the enclosing function at each of the SyncCalls and MaybeSyncCalls.
+	AssignedVars []*VarInfo
+	// SyncCalls stores the calls that always run the function literal synchronously, i.e., before
+	// the call returns (e.g., `func() {...}()` or `run(func() {...})` for `func run(f func()) { f() }`).
+	SyncCalls []*ast.CallExpr
+	// MaybeSyncCalls stores the calls that may run the function literal synchronously, or not at
+	// all (e.g., `once.Do(func() {...})`).
+	MaybeSyncCalls []*ast.CallExpr
 }
 
 // VarInfo keeps the information about a variable (*ast.Ident) and its associated object type
@@ -77,9 +87,13 @@ func run(p *analysis.Pass) (map[*ast.FuncLit]*FuncLitInfo, error) {
 	}
 
 	funcLitMap := make(map[*ast.FuncLit]*FuncLitInfo)
+	if !conf.ExperimentalAnonymousFuncEnable {
+		return funcLitMap, nil
+	}
 
+	syncParams := collectSyncParams(pass)
 	for _, file := range pass.Files {
-		if !conf.IsFileInScope(file) || !conf.ExperimentalAnonymousFuncEnable {
+		if !conf.IsFileInScope(file) {
 			continue
 		}
 
@@ -101,8 +115,11 @@ func run(p *analysis.Pass) (map[*ast.FuncLit]*FuncLitInfo, error) {
 				FakeFuncDecl: fakeDecl,
 				FakeFuncObj:  fakeType,
 				ClosureVars:  vars,
+				AssignedVars: collectAssignedVars(pass, funcLit, vars),
 			}
 		}
+
+		collectSyncCalls(pass, file, funcLitMap, syncParams)
 	}
 
 	return funcLitMap, nil
@@ -18,6 +18,7 @@ import (
 	"go/ast"
 	"go/token"
 	"log"
+	"strconv"
 	"strings"
 	"testing"
 
@@ -32,6 +33,11 @@ import (
 // _wantClosurePrefix is a prefix that we use in the test file to specify the expected collected closure from the analyzer.
 const _wantClosurePrefix = "expect_closure:"
 
+// _wantSyncPrefix is a prefix that we use in the test file to specify the expected numbers of
+// synchronous calls and maybe synchronous calls, and the expected assigned closure variables from
+// the analyzer.
+const _wantSyncPrefix = "expect_sync:"
+
 func TestAnalyzer(t *testing.T) {
 	t.Parallel()
 
@@ -130,6 +136,53 @@ func TestClosureCollection(t *testing.T) {
 	}
 }
 
+func TestSyncCalls(t *testing.T) {
+	t.Parallel()
+
+	testdata := analysistest.TestData()
+
+	r := analysistest.Run(t, testdata, Analyzer, "go.uber.org/anonymousfunc/synccall")
+	require.Equal(t, 1, len(r))
+	require.NotNil(t, r[0])
+
+	pass, result := r[0].Pass, r[0].Result
+	require.IsType(t, &analysishelper.Result[map[*ast.FuncLit]*FuncLitInfo]{}, result)
+	funcLitMap := result.(*analysishelper.Result[map[*ast.FuncLit]*FuncLitInfo]).Res
+
+	expectedValues := nilawaytest.FindExpectedValues(pass, _wantSyncPrefix)
+	require.Equal(t, len(expectedValues), len(funcLitMap))
+
+	for node, expected := range expectedValues {
+		funcLit, ok := node.(*ast.FuncLit)
+		require.True(t, ok)
+		info, ok := funcLitMap[funcLit]
+		require.True(t, ok)
+
+		pos := pass.Fset.Position(funcLit.Pos())
+		require.GreaterOrEqual(t, len(expected), 2, "missing expected values at %s:%d:%d", pos.Filename, pos.Line, pos.Column)
+		require.Equal(t, expected[0], strconv.Itoa(len(info.SyncCalls)),
+			"sync calls mismatch at %s:%d:%d", pos.Filename, pos.Line, pos.Column,
+		)
+		require.Equal(t, expected[1], strconv.Itoa(len(info.MaybeSyncCalls)),
+			"maybe sync calls mismatch at %s:%d:%d", pos.Filename, pos.Line, pos.Column,
+		)
+
+		// We intentionally omit the default length for `assignedVars` to avoid comparing nil
+		// slices against empty slices.
+		var assignedVars []string
+		for _, v := range info.AssignedVars {
+			assignedVars = append(assignedVars, v.Ident.Name)
+		}
+		var expectedVars []string
+		if len(expected) > 2 {
+			expectedVars = expected[2:]
+		}
+		require.Equal(t, expectedVars, assignedVars,
+			"assigned vars mismatch at %s:%d:%d", pos.Filename, pos.Line, pos.Column,
+		)
+	}
+}
+
 func TestMain(m *testing.M) {
 	// Enable anonymous function flag for tests. It is OK to not unset this flag since Go builds
 	// tests for each package into separate binaries and execute them in parallel [1]. So the
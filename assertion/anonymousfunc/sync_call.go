@@ -0,0 +1,275 @@
+//  Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package anonymousfunc
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"go.uber.org/nilaway/hook"
+	"go.uber.org/nilaway/util/analysishelper"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+// FuncLitFromAssignment returns the function literal bound to the given ident if the declaration
+// of the ident is an assignment statement whose corresponding RHS is a function literal (e.g.,
+// `f := func() {...}`). Otherwise, it returns nil.
+func FuncLitFromAssignment(ident *ast.Ident) *ast.FuncLit {
+	if ident == nil || ident.Obj == nil || ident.Obj.Decl == nil {
+		return nil
+	}
+
+	if assign, ok := ident.Obj.Decl.(*ast.AssignStmt); ok {
+		// TODO get the correct ident for many to one assignments
+		if len(assign.Lhs) != len(assign.Rhs) {
+			return nil
+		}
+
+		for i := range assign.Lhs {
+			if assign.Lhs[i].(*ast.Ident).Obj != ident.Obj {
+				continue
+			}
+			if rhs, ok := assign.Rhs[i].(*ast.FuncLit); ok {
+				return rhs
+			}
+		}
+	}
+
+	return nil
+}
+
+// FuncLitOf returns the function literal the given expression evaluates to, i.e., the expression
+// itself if it is a function literal, or the literal bound to it if it is an ident (see
+// FuncLitFromAssignment). Otherwise, it returns nil.
+func FuncLitOf(expr ast.Expr) *ast.FuncLit {
+	switch expr := ast.Unparen(expr).(type) {
+	case *ast.FuncLit:
+		return expr
+	case *ast.Ident:
+		return FuncLitFromAssignment(expr)
+	}
+	return nil
+}
+
+// collectAssignedVars returns the closure variables of the given function literal that are
+// assigned anywhere in its body, including the bodies of nested function literals, in the order
+// of closureVars.
+func collectAssignedVars(pass *analysishelper.EnhancedPass, funcLit *ast.FuncLit, closureVars []*VarInfo) []*VarInfo {
+	assigned := make(map[types.Object]bool)
+	markAssigned := func(lhs ast.Expr) {
+		// Declarations (`:=`) always define new variables inside the function literal, so we only
+		// look at the uses here.
+		if ident, ok := ast.Unparen(lhs).(*ast.Ident); ok {
+			if obj := pass.TypesInfo.Uses[ident]; obj != nil {
+				assigned[obj] = true
+			}
+		}
+	}
+	ast.Inspect(funcLit.Body, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.AssignStmt:
+			if n.Tok != token.DEFINE {
+				for _, lhs := range n.Lhs {
+					markAssigned(lhs)
+				}
+			}
+		case *ast.RangeStmt:
+			if n.Tok == token.ASSIGN {
+				markAssigned(n.Key)
+				if n.Value != nil {
+					markAssigned(n.Value)
+				}
+			}
+		}
+		return true
+	})
+
+	var vars []*VarInfo
+	for _, v := range closureVars {
+		if assigned[v.Obj] {
+			vars = append(vars, v)
+		}
+	}
+	return vars
+}
+
+// collectSyncParams returns, for each function declared in the package, the indices of its
+// parameters of function type that it calls directly (i.e., not in a `go` or `defer` statement,
+// nor in a nested function literal). The function literals passed to such parameters are hence
+// run synchronously by the function (e.g., `func run(f func()) { f() }`). The value for each index
+// is whether the parameter is always called, i.e., by a top-level simple statement of the body
+// (not in the short-circuited operand of `&&` or `||`) that no `return` statement precedes.
+// Otherwise, the function may return without calling the parameter, e.g.,
+// `func maybeRun(f func(), cond bool) { if cond { f() } }`.
+func collectSyncParams(pass *analysishelper.EnhancedPass) map[*types.Func]map[int]bool {
+	syncParams := make(map[*types.Func]map[int]bool)
+	for _, file := range pass.Files {
+		for _, decl := range file.Decls {
+			funcDecl, ok := decl.(*ast.FuncDecl)
+			if !ok || funcDecl.Body == nil {
+				continue
+			}
+			funcObj, ok := pass.TypesInfo.Defs[funcDecl.Name].(*types.Func)
+			if !ok {
+				continue
+			}
+			params := funcObj.Type().(*types.Signature).Params()
+			paramIndices := make(map[types.Object]int, params.Len())
+			for i := 0; i < params.Len(); i++ {
+				paramIndices[params.At(i)] = i
+			}
+
+			addCall := func(call *ast.CallExpr, always bool) {
+				ident, ok := ast.Unparen(call.Fun).(*ast.Ident)
+				if !ok {
+					return
+				}
+				if i, ok := paramIndices[pass.TypesInfo.Uses[ident]]; ok {
+					if syncParams[funcObj] == nil {
+						syncParams[funcObj] = make(map[int]bool)
+					}
+					syncParams[funcObj][i] = syncParams[funcObj][i] || always
+				}
+			}
+
+			returned := false
+			for _, stmt := range funcDecl.Body.List {
+				inspectSyncCalls(stmt, !returned && isSimpleStmt(stmt), addCall)
+				returned = returned || hasReturn(stmt)
+			}
+		}
+	}
+	return syncParams
+}
+
+// inspectSyncCalls calls addCall for each call expression in the given node that is not in a `go`
+// or `defer` statement, nor in a nested function literal. The calls are always run if the node is
+// (i.e., always is true), unless they are in the short-circuited operand of `&&` or `||`.
+func inspectSyncCalls(node ast.Node, always bool, addCall func(call *ast.CallExpr, always bool)) {
+	ast.Inspect(node, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.FuncLit, *ast.GoStmt, *ast.DeferStmt:
+			return false
+		case *ast.BinaryExpr:
+			if n.Op == token.LAND || n.Op == token.LOR {
+				inspectSyncCalls(n.X, always, addCall)
+				inspectSyncCalls(n.Y, false, addCall)
+				return false
+			}
+		case *ast.CallExpr:
+			addCall(n, always)
+		}
+		return true
+	})
+}
+
+// isSimpleStmt returns whether the given statement runs all its sub-expressions unconditionally,
+// e.g., an expression statement or an assignment, as opposed to an `if` or `for` statement.
+func isSimpleStmt(stmt ast.Stmt) bool {
+	switch stmt.(type) {
+	case *ast.ExprStmt, *ast.AssignStmt, *ast.DeclStmt, *ast.ReturnStmt, *ast.IncDecStmt, *ast.SendStmt:
+		return true
+	}
+	return false
+}
+
+// hasReturn returns whether the given statement contains a `return` statement of the enclosing
+// function, i.e., not in a nested function literal.
+func hasReturn(stmt ast.Stmt) bool {
+	found := false
+	ast.Inspect(stmt, func(n ast.Node) bool {
+		switch n.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.ReturnStmt:
+			found = true
+		}
+		return !found
+	})
+	return found
+}
+
+// collectSyncCalls finds the calls in the given file that run a function literal synchronously,
+// and appends them to the SyncCalls (or MaybeSyncCalls, if the callee may not run the literal) of
+// the corresponding function literals. These are
+// (1) direct invocations of the literal, e.g., `func() {...}()` or `f()` for `f := func() {...}`;
+// (2) calls passing the literal as an argument to a user-configured synchronous caller (see
+// hook.IsSyncCall), or a well-known one that may not run it (see hook.IsMaybeSyncCall), e.g.,
+// `once.Do(func() {...})`;
+// (3) calls passing the literal as an argument to a parameter that the callee calls directly (see
+// collectSyncParams).
+// Calls in `go` or `defer` statements are excluded since they do not run before the statement
+// completes.
+func collectSyncCalls(
+	pass *analysishelper.EnhancedPass,
+	file *ast.File,
+	funcLitMap map[*ast.FuncLit]*FuncLitInfo,
+	syncParams map[*types.Func]map[int]bool,
+) {
+	// Collect the calls in `go` and `defer` statements first, so that we can skip them below.
+	asyncCalls := make(map[*ast.CallExpr]bool)
+	ast.Inspect(file, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.GoStmt:
+			asyncCalls[n.Call] = true
+		case *ast.DeferStmt:
+			asyncCalls[n.Call] = true
+		}
+		return true
+	})
+
+	addSyncCall := func(expr ast.Expr, call *ast.CallExpr, always bool) {
+		info, ok := funcLitMap[FuncLitOf(expr)]
+		if !ok {
+			return
+		}
+		if always {
+			info.SyncCalls = append(info.SyncCalls, call)
+		} else {
+			info.MaybeSyncCalls = append(info.MaybeSyncCalls, call)
+		}
+	}
+
+	ast.Inspect(file, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok || asyncCalls[call] {
+			return true
+		}
+
+		// (1) direct invocations.
+		addSyncCall(call.Fun, call, true)
+
+		// (2) user-configured or well-known synchronous callers.
+		isSync := hook.IsSyncCall(pass, call)
+		if isSync || hook.IsMaybeSyncCall(pass, call) {
+			for _, arg := range call.Args {
+				addSyncCall(arg, call, isSync)
+			}
+			return true
+		}
+
+		// (3) in-package functions calling the parameter directly.
+		if callee := typeutil.StaticCallee(pass.TypesInfo, call); callee != nil {
+			params := syncParams[callee.Origin()]
+			for i, arg := range call.Args {
+				if always, ok := params[i]; ok {
+					addSyncCall(arg, call, always)
+				}
+			}
+		}
+		return true
+	})
+}
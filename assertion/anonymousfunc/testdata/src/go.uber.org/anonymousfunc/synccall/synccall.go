@@ -0,0 +1,124 @@
+//  Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package synccall
+
+import (
+	"sort"
+	"sync"
+)
+
+// For testing purposes, we add a comment at the same line of each anonymous function declaration,
+// listing the expected number of calls that always run the function literal synchronously and
+// the expected number of calls that may run it, followed by the expected closure variables
+// assigned in the function literal.
+
+var _once sync.Once
+
+func direct() {
+	var x, y *int
+	func() { // expect_sync: 1 0 x
+		x = new(int)
+		print(y)
+	}()
+
+	f := func() { // expect_sync: 2 0 y
+		y = x
+	}
+	f()
+	f()
+}
+
+func nested() {
+	var x *int
+	func() { // expect_sync: 1 0 x
+		g := func() { // expect_sync: 0 0 x
+			x = new(int)
+		}
+		go g()
+	}()
+	print(x)
+}
+
+func callers(s []int) {
+	var x *int
+	_once.Do(func() { // expect_sync: 0 1 x
+		x = new(int)
+	})
+	sort.Slice(s, func(i, j int) bool { // expect_sync: 0 1
+		return s[i] < s[j]
+	})
+	run(func() { // expect_sync: 1 0 x
+		for _, x = range []*int{} {
+		}
+	})
+	store(func() { // expect_sync: 0 0 x
+		x = nil
+	})
+	maybeRun(func() { // expect_sync: 0 1 x
+		x = new(int)
+	}, true)
+	runAfter(func() { // expect_sync: 0 1 x
+		x = new(int)
+	}, true)
+	print(x)
+}
+
+func async() {
+	var x *int
+	go func() { // expect_sync: 0 0 x
+		x = new(int)
+	}()
+	defer _once.Do(func() { // expect_sync: 0 0 x
+		x = new(int)
+	})
+	go run(func() { // expect_sync: 0 0 x
+		x = new(int)
+	})
+	print(x)
+}
+
+func defines() {
+	var x *int
+	func() { // expect_sync: 1 0
+		x := new(int)
+		print(x)
+	}()
+	print(x)
+}
+
+func run(f func()) {
+	f()
+}
+
+func maybeRun(f func(), cond bool) {
+	if cond {
+		f()
+	}
+}
+
+func runAfter(f func(), cond bool) {
+	if !cond {
+		return
+	}
+	f()
+}
+
+var _stored func()
+
+func store(f func()) {
+	_stored = f
+	go f()
+	defer f()
+}
@@ -16,13 +16,16 @@
 package function
 
 import (
+	"cmp"
 	"context"
 	"errors"
 	"fmt"
 	"go/ast"
+	"go/token"
 	"go/types"
 	"reflect"
 	"runtime/debug"
+	"slices"
 	"strings"
 	"sync"
 
@@ -30,8 +33,12 @@ import (
 	"go.uber.org/nilaway/assertion/anonymousfunc"
 	"go.uber.org/nilaway/assertion/function/assertiontree"
 	"go.uber.org/nilaway/assertion/function/functioncontracts"
+	"go.uber.org/nilaway/assertion/function/functionvalues"
+	"go.uber.org/nilaway/assertion/function/preprocess"
 	"go.uber.org/nilaway/assertion/structfield"
 	"go.uber.org/nilaway/config"
+	"go.uber.org/nilaway/guard"
+	"go.uber.org/nilaway/inference"
 	"go.uber.org/nilaway/util/analysishelper"
 	"go.uber.org/nilaway/util/asthelper"
 	"golang.org/x/tools/go/analysis"
@@ -49,20 +56,38 @@ var Analyzer = &analysis.Analyzer{
 	Name:       "nilaway_function_analyzer",
 	Doc:        _doc,
 	Run:        analysishelper.WrapRun(run),
-	ResultType: reflect.TypeOf((*analysishelper.Result[[]annotation.FullTrigger])(nil)),
+	ResultType: reflect.TypeOf((*analysishelper.Result[Result])(nil)),
 	Requires: []*analysis.Analyzer{
 		config.Analyzer,
 		ctrlflow.Analyzer,
 		structfield.Analyzer,
 		anonymousfunc.Analyzer,
 		functioncontracts.Analyzer,
+		functionvalues.Analyzer,
 	},
 }
 
-// This limit is in place to prevent the expensive assertions analyzer from being run on
-// overly-sized functions. The limit is based on the number of CFG blocks, which provides
-// a better measure of function complexity than token/byte count.
-const _maxFuncSizeInCFGBlocks = 500
+// Result is the result of the function analyzer.
+type Result struct {
+	// Triggers are the full triggers generated from the analyzed functions, in the order of their
+	// declarations.
+	Triggers []annotation.FullTrigger
+	// Skipped lists the functions that were not analyzed, e.g., because they are too large or the
+	// analysis of them failed. They do not contribute any triggers, such that their parameters and
+	// results are left to the defaults (or the annotations) rather than failing the analysis of
+	// the entire package.
+	Skipped []SkippedFunc
+}
+
+// SkippedFunc is a function skipped by the analysis.
+type SkippedFunc struct {
+	// Pos is the position of the function declaration (or literal).
+	Pos token.Pos
+	// Name is the name of the function (or the fake name of the function literal).
+	Name string
+	// Reason is the reason why the function was skipped.
+	Reason error
+}
 
 // functionResult is the struct that stores the results for analyzing a function declaration.
 type functionResult struct {
@@ -70,6 +95,9 @@ type functionResult struct {
 	triggers []annotation.FullTrigger
 	// err stores any error occurred during the analysis.
 	err error
+	// skipReason is the reason why the function is only partially analyzed (e.g., summarized by
+	// its return statements), if any. Unlike err, the triggers are still used.
+	skipReason error
 	// index is the index of the function declaration in the package. This is particularly
 	// important since currently we have hidden coupling in NilAway that requires the generated
 	// triggers be placed in order of their declarations. Here, the index will ensure that we can
@@ -81,18 +109,19 @@ type functionResult struct {
 	funcDecl *ast.FuncDecl
 }
 
-func run(p *analysis.Pass) ([]annotation.FullTrigger, error) {
-	var err error
+func run(p *analysis.Pass) (Result, error) {
+	var skipped []SkippedFunc
 	pass := analysishelper.NewEnhancedPass(p)
 	conf := pass.ResultOf[config.Analyzer].(*config.Config)
 	if !conf.IsPkgInScope(pass.Pkg) {
-		return nil, nil
+		return Result{}, nil
 	}
 
 	// Construct experimental features. By default, enable all features on NilAway itself.
 	functionConfig := assertiontree.FunctionConfig{
 		EnableStructInitCheck: conf.ExperimentalStructInitEnable,
 		EnableAnonymousFunc:   conf.ExperimentalAnonymousFuncEnable,
+		StableRoundLimit:      conf.StableRoundLimit,
 	}
 	if strings.HasPrefix(pass.Pkg.Path(), config.NilAwayPkgPathPrefix) { //nolint:revive
 		// TODO: enable struct initialization flag (tracked in Issue #23).
@@ -101,15 +130,20 @@ func run(p *analysis.Pass) ([]annotation.FullTrigger, error) {
 		functionConfig.EnableStructInitCheck = conf.ExperimentalStructInitEnable
 		functionConfig.EnableAnonymousFunc = conf.ExperimentalAnonymousFuncEnable
 	}
+	// Field assignments are checked against the field annotations in no-infer mode, hence fields are tracked per access
+	// path only with inference. The struct initialization check has its own handling of fields.
+	functionConfig.EnableObjectSensitiveFields = inference.DetermineMode(pass) == inference.FullInfer &&
+		!functionConfig.EnableStructInitCheck
 
 	ctrlflowResult := pass.ResultOf[ctrlflow.Analyzer].(*ctrlflow.CFGs)
 	anonymousFuncResult := pass.ResultOf[anonymousfunc.Analyzer].(*analysishelper.Result[map[*ast.FuncLit]*anonymousfunc.FuncLitInfo])
-	contractsResult := pass.ResultOf[functioncontracts.Analyzer].(*analysishelper.Result[functioncontracts.Map])
-	if err := errors.Join(anonymousFuncResult.Err, contractsResult.Err); err != nil {
-		return nil, err
+	contractsResult := pass.ResultOf[functioncontracts.Analyzer].(*analysishelper.Result[functioncontracts.Result])
+	funcValuesResult := pass.ResultOf[functionvalues.Analyzer].(*analysishelper.Result[functionvalues.Map])
+	if err := errors.Join(anonymousFuncResult.Err, contractsResult.Err, funcValuesResult.Err); err != nil {
+		return Result{}, err
 	}
 
-	funcLitMap, funcContracts := anonymousFuncResult.Res, contractsResult.Res
+	funcLitMap, funcContracts, funcValues := anonymousFuncResult.Res, contractsResult.Res.Contracts, funcValuesResult.Res
 
 	// Create a fake ident map for the fake func decl nodes to be shared for all function contexts.
 	pkgFakeIdentMap := make(map[*ast.Ident]types.Object)
@@ -143,10 +177,29 @@ func run(p *analysis.Pass) ([]annotation.FullTrigger, error) {
 			// We need a stable order of triggers for inference. However, the
 			// fake func decl nodes generated from the anonymous function analyzer are stored in
 			// a map. Hence, here we traverse the file and append the fake func decl nodes in
-			// depth-first order.
+			// depth-first order. The deferred function literals spliced into the enclosing
+			// functions (see preprocess.DeferredFuncLits) and the inlined templ component function
+			// literals are already analyzed there, so we skip them.
+			spliced := make(map[*ast.FuncLit]bool)
+			preprocessor := preprocess.New(pass, funcContracts)
 			ast.Inspect(file, func(node ast.Node) bool {
-				if f, ok := node.(*ast.FuncLit); ok {
-					funcs = append(funcs, f)
+				switch n := node.(type) {
+				case *ast.FuncDecl:
+					if f := preprocessor.InlinedTemplComponentFuncLit(n); f != nil {
+						spliced[f] = true
+					}
+					if n.Body != nil {
+						for _, f := range preprocess.DeferredFuncLits(n.Body) {
+							spliced[f] = true
+						}
+					}
+				case *ast.FuncLit:
+					if !spliced[n] {
+						funcs = append(funcs, n)
+					}
+					for _, f := range preprocess.DeferredFuncLits(n.Body) {
+						spliced[f] = true
+					}
 				}
 				return true
 			})
@@ -186,21 +239,25 @@ func run(p *analysis.Pass) ([]annotation.FullTrigger, error) {
 			if graph == nil {
 				continue
 			}
-			// Skip if the function is too large based on CFG complexity.
-			// Use CFG block count as a more accurate measure of function complexity
-			// than token/byte count, which can be misleading due to comments and formatting.
-			if len(graph.Blocks) > _maxFuncSizeInCFGBlocks {
-				err = errors.Join(err, fmt.Errorf("skipping function `%s()` at %s: function too large (%d CFG blocks, exceeds limit of %d blocks)",
-					funcDecl.Name.Name, pass.Fset.Position(funcDecl.Pos()), len(graph.Blocks), _maxFuncSizeInCFGBlocks))
+
+			funcContext := assertiontree.NewFunctionContext(
+				pass, funcDecl, funcLit, functionConfig, funcLitMap, pkgFakeIdentMap, funcContracts, funcValues)
+			idx := funcIndex
+			funcIndex++
+
+			// This limit is in place to prevent the expensive backpropagation from being run on
+			// overly-sized functions. Use CFG block count as a more accurate measure of function
+			// complexity than token/byte count, which can be misleading due to comments and
+			// formatting. The function is still summarized by its return statements, such that its
+			// callers get the nilability of its results.
+			if limit := conf.MaxFuncSizeInCFGBlocks; limit > 0 && len(graph.Blocks) > limit {
+				reason := fmt.Errorf("function too large (%d CFG blocks, exceeds limit of %d blocks)", len(graph.Blocks), limit)
+				wg.Go(func() { summarizeFunc(funcDecl, funcContext, reason, idx, funcChan) })
 				continue
 			}
 
 			// Now, analyze the function declarations concurrently.
-			funcContext := assertiontree.NewFunctionContext(
-				pass, funcDecl, funcLit, functionConfig, funcLitMap, pkgFakeIdentMap, funcContracts)
-			idx := funcIndex
 			wg.Go(func() { analyzeFunc(ctx, pass, funcDecl, funcContext, graph, idx, funcChan) })
-			funcIndex++
 		}
 	}
 
@@ -221,8 +278,13 @@ func run(p *analysis.Pass) ([]annotation.FullTrigger, error) {
 	funcResults := map[*types.Func]*functionResult{}
 	for r := range funcChan {
 		if r.err != nil {
-			err = errors.Join(err, r.err)
+			// Isolate the failure to the function, such that the triggers of the other functions
+			// are still matched.
+			skipped = append(skipped, SkippedFunc{Pos: r.funcDecl.Pos(), Name: r.funcDecl.Name.Name, Reason: r.err})
 		} else {
+			if r.skipReason != nil {
+				skipped = append(skipped, SkippedFunc{Pos: r.funcDecl.Pos(), Name: r.funcDecl.Name.Name, Reason: r.skipReason})
+			}
 			funcTriggers[r.index] = r.triggers
 			triggerCount += len(r.triggers)
 
@@ -247,7 +309,10 @@ func run(p *analysis.Pass) ([]annotation.FullTrigger, error) {
 		triggers = append(triggers, s...)
 	}
 
-	return triggers, err
+	// The results are received in the order of completion, so we sort the skipped functions for
+	// deterministic reporting.
+	slices.SortFunc(skipped, func(a, b SkippedFunc) int { return cmp.Compare(a.Pos, b.Pos) })
+	return Result{Triggers: triggers, Skipped: skipped}, nil
 }
 
 // duplicateFullTriggersFromContractedFunctionsToCallers duplicates all the full triggers that have
@@ -256,7 +321,10 @@ func run(p *analysis.Pass) ([]annotation.FullTrigger, error) {
 // producers/consumers for argument pass or result return at every call site of the contracted
 // function. In order to connect such producers/consumers back to the contracted functions, we
 // create new full triggers that duplicates the original full triggers of the contracted functions
-// but uses the new producers/consumers instead.
+// but uses the new producers/consumers instead. The contracts determine whether the duplicated
+// full triggers involving the results are controlled by the argument sites (see
+// returnControllers), and may also add new full triggers between the argument and result sites
+// (see contractFullTriggers).
 func duplicateFullTriggersFromContractedFunctionsToCallers(
 	pass *analysishelper.EnhancedPass,
 	funcContracts functioncontracts.Map,
@@ -294,6 +362,19 @@ func duplicateFullTriggersFromContractedFunctionsToCallers(
 	dupTriggers := map[*types.Func][]annotation.FullTrigger{}
 	for ctrtFunc, calls := range callsByCtrtFunc {
 		r := funcResults[ctrtFunc]
+		var flows *returnFlows
+		if r != nil {
+			flows = newReturnFlows(r.triggers)
+		}
+		for caller, callExprs := range calls {
+			for _, callExpr := range callExprs {
+				// The full triggers stated by the contracts themselves do not depend on the body of
+				// the contracted function, so they are added for the upstream functions as well.
+				dupTriggers[caller] = append(dupTriggers[caller],
+					contractFullTriggers(pass, ctrtFunc, funcContracts[ctrtFunc], callExpr, flows)...)
+			}
+		}
+
 		if r == nil {
 			// The contracted function is imported from upstream, and the local package analysis
 			// does not involve it.
@@ -312,11 +393,9 @@ func duplicateFullTriggersFromContractedFunctionsToCallers(
 			// Duplicate the full trigger in every caller
 			for caller, callExprs := range calls {
 				for _, callExpr := range callExprs {
-					dupTrigger := duplicateFullTrigger(trigger, ctrtFunc, callExpr, pass,
-						isParamProducer, isReturnConsumer)
-
-					// Store the duplicated full trigger
-					dupTriggers[caller] = append(dupTriggers[caller], dupTrigger)
+					dupTriggers[caller] = append(dupTriggers[caller],
+						duplicateFullTrigger(trigger, ctrtFunc, funcContracts[ctrtFunc], callExpr, pass,
+							isParamProducer, isReturnConsumer)...)
 				}
 			}
 		}
@@ -334,21 +413,21 @@ func duplicateFullTriggersFromContractedFunctionsToCallers(
 	}
 }
 
-// duplicateFullTrigger creates a (possibly controlled) full trigger from the given full trigger
-// with FuncParam producer or UseAsReturn consumer or both.
+// duplicateFullTrigger creates (possibly controlled) full triggers from the given full trigger
+// with FuncParam producer or UseAsReturn consumer or both. For a UseAsReturn consumer, one full
+// trigger is created for every controller of the result (see returnControllers), such that the
+// flow is activated if any of the controllers is nilable; no full trigger is created if the
+// contracts guarantee the result to be nonnil regardless of the arguments.
 // Precondition: isParamProducer or isReturnConsumer is true; also they can be both true.
 func duplicateFullTrigger(
 	trigger annotation.FullTrigger,
 	callee *types.Func,
+	contracts functioncontracts.Contracts,
 	callExpr *ast.CallExpr,
 	pass *analysishelper.EnhancedPass,
 	isParamProducer bool,
 	isReturnConsumer bool,
-) annotation.FullTrigger {
-	// TODO: what if we have more than one parameter, planned in future revisions
-	argExpr := callExpr.Args[0]
-	argLoc := pass.PosToLocation(argExpr.Pos())
-
+) []annotation.FullTrigger {
 	// Create the duplicated full trigger
 	// TODO: we just copy the pointer for producer and consumer because I don't see a problem when
 	//  two full triggers share a producer or consumer. We do deep duplication for the param or
@@ -366,17 +445,233 @@ func duplicateFullTrigger(
 		CreatedFromDuplication: true,
 	}
 	if isParamProducer {
+		key, ok := trigger.Producer.Annotation.(*annotation.FuncParam).Ann.(*annotation.ParamAnnotationKey)
+		if !ok {
+			return nil
+		}
+		argLoc, ok := argLocation(pass, callExpr, key.ParamNum)
+		if !ok {
+			// The argument is not passed explicitly at this call site (e.g., an empty variadic
+			// argument), so there is no argument site to connect to.
+			return nil
+		}
 		dupTrigger.Producer = annotation.DuplicateParamProducer(trigger.Producer, argLoc)
 	}
-	if isReturnConsumer {
-		retLoc := pass.PosToLocation(callExpr.Pos())
-		dupTrigger.Consumer = annotation.DuplicateReturnConsumer(trigger.Consumer, retLoc)
-		// Set up the site that controls the controlled full trigger to be created
-		c := annotation.NewCallSiteParamKey(callee, 0, argLoc)
-		dupTrigger.Controller = c
+	if !isReturnConsumer {
+		return []annotation.FullTrigger{dupTrigger}
+	}
+
+	retKey, ok := trigger.Consumer.Annotation.(*annotation.UseAsReturn).Ann.(*annotation.RetAnnotationKey)
+	if !ok {
+		return nil
+	}
+	retLoc := pass.PosToLocation(callExpr.Pos())
+	dupTrigger.Consumer = annotation.DuplicateReturnConsumer(trigger.Consumer, retLoc)
+	controllers, guaranteed := returnControllers(pass, callee, contracts, callExpr, retKey.RetNum)
+	if guaranteed {
+		return nil
+	}
+	if len(controllers) == 0 {
+		return []annotation.FullTrigger{dupTrigger}
+	}
+	// Set up the sites that control the controlled full triggers to be created
+	dupTriggers := make([]annotation.FullTrigger, len(controllers))
+	for i, c := range controllers {
+		dupTriggers[i] = dupTrigger
+		dupTriggers[i].Controller = c
+	}
+	return dupTriggers
+}
+
+// returnControllers returns the argument sites at the given call site that control the flows into
+// the given result of the contracted function, based on the contracts with a `nonnil` output for
+// the result: if all the arguments with `nonnil` inputs are nonnil, the result is nonnil, so the
+// flows into the result are activated only if any of such arguments is nilable. If a contract
+// guarantees the result to be nonnil regardless of the arguments (e.g., `contract(_ -> nonnil)`),
+// guaranteed is true. If no contract applies, no controllers are returned and the flows are
+// always active.
+//
+// For error-returning functions, a contract with a `nonnil` output for the error result describes
+// the error case (where the other results are not used), so it is ignored here.
+//
+// The contracts with `nil` inputs cannot be expressed by the controllers (which are activated by
+// nilable arguments), so they are ignored as well. Similarly, if multiple contracts apply, only
+// the first one is used, which is conservative since it activates the flows more often.
+//
+// nilable(controllers)
+func returnControllers(
+	pass *analysishelper.EnhancedPass,
+	callee *types.Func,
+	contracts functioncontracts.Contracts,
+	callExpr *ast.CallExpr,
+	retNum int,
+) (controllers []*annotation.CallSiteParamAnnotationKey, guaranteed bool) {
+	var first []*annotation.CallSiteParamAnnotationKey
+	for _, ctr := range contracts {
+		if !contractApplies(callee, ctr) || ctr.Outs[retNum] != functioncontracts.NonNil {
+			continue
+		}
+
+		var keys []*annotation.CallSiteParamAnnotationKey
+		usable := true
+		for i, in := range ctr.Ins {
+			switch in {
+			case functioncontracts.NonNil:
+				argLoc, ok := argLocation(pass, callExpr, i)
+				if !ok {
+					usable = false
+					continue
+				}
+				keys = append(keys, annotation.NewCallSiteParamKey(callee, i, argLoc))
+			case functioncontracts.Nil:
+				usable = false
+			}
+		}
+		if !usable {
+			continue
+		}
+		if len(keys) == 0 {
+			return nil, true
+		}
+		if first == nil {
+			first = keys
+		}
 	}
+	return first, false
+}
 
-	return dupTrigger
+// returnFlows summarizes the full triggers of a function body that flow into its results, which
+// are duplicated at every call site of the function.
+type returnFlows struct {
+	// params stores the pairs of a parameter and a result where the parameter is directly returned
+	// as the result.
+	params map[[2]int]bool
+	// results stores the results that have any full trigger flowing into them.
+	results map[int]bool
+}
+
+// newReturnFlows creates a returnFlows from the given full triggers of a function body.
+func newReturnFlows(triggers []annotation.FullTrigger) *returnFlows {
+	flows := &returnFlows{params: make(map[[2]int]bool), results: make(map[int]bool)}
+	for _, trigger := range triggers {
+		ret, ok := trigger.Consumer.Annotation.(*annotation.UseAsReturn)
+		if !ok {
+			continue
+		}
+		retKey, ok := ret.Ann.(*annotation.RetAnnotationKey)
+		if !ok {
+			continue
+		}
+		flows.results[retKey.RetNum] = true
+		if param, ok := trigger.Producer.Annotation.(*annotation.FuncParam); ok {
+			if paramKey, ok := param.Ann.(*annotation.ParamAnnotationKey); ok {
+				flows.params[[2]int{paramKey.ParamNum, retKey.RetNum}] = true
+			}
+		}
+	}
+	return flows
+}
+
+// contractFullTriggers returns the full triggers stated by the contracts with `nil` outputs at the
+// given call site: if the arguments with `nil` inputs are nil, the results with `nil` outputs are
+// nil. Since the full triggers cannot express a conjunction of conditions, a full trigger from
+// every argument with a `nil` input to every result with a `nil` output is created, which is
+// conservative. The contracts without `nil` inputs cannot be expressed and are ignored.
+//
+// The flows (nil for upstream functions) summarize the full triggers of the callee body flowing
+// into its results, which are duplicated at the call site. A full trigger from an argument to a
+// result is skipped if the flow is already covered by them, i.e., the argument is directly
+// returned as the result, or the result has duplicated full triggers that are all controlled by
+// the argument (see returnControllers) and hence activated whenever the argument is nilable.
+// Otherwise, the same nil flow would be reported twice at the call site.
+func contractFullTriggers(
+	pass *analysishelper.EnhancedPass,
+	callee *types.Func,
+	contracts functioncontracts.Contracts,
+	callExpr *ast.CallExpr,
+	flows *returnFlows,
+) []annotation.FullTrigger {
+	covered := func(paramNum, retNum int) bool {
+		if flows == nil {
+			return false
+		}
+		if flows.params[[2]int{paramNum, retNum}] {
+			return true
+		}
+		if !flows.results[retNum] {
+			return false
+		}
+		controllers, _ := returnControllers(pass, callee, contracts, callExpr, retNum)
+		return slices.ContainsFunc(controllers, func(c *annotation.CallSiteParamAnnotationKey) bool {
+			return c.ParamNum == paramNum
+		})
+	}
+
+	var triggers []annotation.FullTrigger
+	retLoc := pass.PosToLocation(callExpr.Pos())
+	for _, ctr := range contracts {
+		if !contractApplies(callee, ctr) {
+			continue
+		}
+		for retNum, out := range ctr.Outs {
+			if out != functioncontracts.Nil || retNum == functioncontracts.GuardResultIndex(callee.Signature()) {
+				continue
+			}
+			for i, in := range ctr.Ins {
+				if in != functioncontracts.Nil || covered(i, retNum) {
+					continue
+				}
+				argLoc, ok := argLocation(pass, callExpr, i)
+				if !ok {
+					continue
+				}
+				triggers = append(triggers, annotation.FullTrigger{
+					Producer: &annotation.ProduceTrigger{
+						Annotation: &annotation.FuncParam{
+							TriggerIfNilable: &annotation.TriggerIfNilable{
+								Ann: annotation.NewCallSiteParamKey(callee, i, argLoc)}},
+						Expr: callExpr.Args[i],
+					},
+					Consumer: &annotation.ConsumeTrigger{
+						Annotation: &annotation.UseAsReturn{
+							TriggerIfNonNil: &annotation.TriggerIfNonNil{
+								Ann: annotation.NewCallSiteRetKey(callee, retNum, retLoc)}},
+						Expr:   callExpr,
+						Guards: guard.NoGuards(),
+					},
+					CreatedFromDuplication: true,
+				})
+			}
+		}
+	}
+	return triggers
+}
+
+// contractApplies returns false if the contract describes the failure case of an error-returning
+// or ok-returning function (i.e., it has a `nonnil` output for the error result or a `false` output
+// for the ok result), where the other results are not used and hence are irrelevant to the
+// nilability analysis.
+func contractApplies(callee *types.Func, ctr functioncontracts.Contract) bool {
+	g := functioncontracts.GuardResultIndex(callee.Signature())
+	if g < 0 {
+		return true
+	}
+	return ctr.Outs[g] != functioncontracts.NonNil && ctr.Outs[g] != functioncontracts.False
+}
+
+// argLocation returns the location of the argument for the given parameter at the call site, and
+// false if the argument is not passed explicitly (e.g., an empty variadic argument, or the
+// arguments are given by a multi-value call expression).
+func argLocation(pass *analysishelper.EnhancedPass, callExpr *ast.CallExpr, paramNum int) (token.Position, bool) {
+	if paramNum >= len(callExpr.Args) {
+		return token.Position{}, false
+	}
+	if len(callExpr.Args) == 1 {
+		if tuple, ok := pass.TypesInfo.TypeOf(callExpr.Args[0]).(*types.Tuple); ok && tuple.Len() > 1 {
+			return token.Position{}, false
+		}
+	}
+	return pass.PosToLocation(callExpr.Args[paramNum].Pos()), true
 }
 
 // findCallsToContractedFunctions finds all the calls to the contracted functions in the given
@@ -404,11 +699,10 @@ func findCallsToContractedFunctions(
 			return true
 		}
 
-		// TODO: for now we find the functions with only a single contract nonnil -> nonnil. If we
-		//  want to support multiple contracts or contracts with multiple/other values not only we
-		//  should update here, but we should also make changes to other parts of duplicating
-		//  triggers.
-		if !hasOnlyNonNilToNonNilContract(functionContracts, funcObj) {
+		// The arguments and results of all the calls to the contracted functions are given new
+		// sites at the call sites (see RootAssertionNode.HasContract), so all of them need to be
+		// connected to the contracted functions.
+		if len(functionContracts[funcObj]) == 0 {
 			return true
 		}
 		calls[funcObj] = append(calls[funcObj], callExpr)
@@ -417,18 +711,6 @@ func findCallsToContractedFunctions(
 	return calls
 }
 
-// hasOnlyNonNilToNonNilContract returns whether the given function has only one contract that is
-// nonnil->nonnil.
-func hasOnlyNonNilToNonNilContract(funcContracts functioncontracts.Map, funcObj *types.Func) bool {
-	contracts, ok := funcContracts[funcObj]
-	if !ok || len(contracts) != 1 {
-		return false
-	}
-	ctr := contracts[0]
-	return len(ctr.Ins) == 1 && ctr.Ins[0] == functioncontracts.NonNil &&
-		len(ctr.Outs) == 1 && ctr.Outs[0] == functioncontracts.NonNil
-}
-
 // analyzeFunc analyzes a given function declaration and emit generated triggers, or an error if
 // something went wrong during the analysis. It is mainly a wrapper function for
 // assertiontree.BackpropAcrossFunc with synchronization and communication support for concurrency.
@@ -450,15 +732,10 @@ func analyzeFunc(
 		}
 	}()
 
-	// Do the actual backpropagation.
+	// Do the actual backpropagation. Note that the errors are not wrapped with the function
+	// information here, since the function is reported along with the error (see SkippedFunc).
 	funcTriggers, _, _, err := assertiontree.BackpropAcrossFunc(ctx, pass, funcDecl, funcContext, graph)
 
-	// If any error occurs in back-propagating the function, we wrap the error with more information.
-	if err != nil {
-		pos := pass.Fset.Position(funcDecl.Pos())
-		err = fmt.Errorf("analyzing function %s at %s:%d.%d: %w", funcDecl.Name, pos.Filename, pos.Line, pos.Column, err)
-	}
-
 	funcChan <- functionResult{
 		triggers: funcTriggers,
 		err:      err,
@@ -466,3 +743,40 @@ func analyzeFunc(
 		funcDecl: funcDecl,
 	}
 }
+
+// summarizeFunc summarizes a function that is skipped for the given reason (e.g., because it is
+// too large) by its return statements (see assertiontree.SummarizeFuncReturns) and sends the
+// result to funcChan. The reason is sent along with the triggers such that the function is still
+// reported as skipped, or, if the summary fails, along with the failure as the error of the result.
+func summarizeFunc(
+	funcDecl *ast.FuncDecl,
+	funcContext assertiontree.FunctionContext,
+	reason error,
+	index int,
+	funcChan chan functionResult,
+) {
+	// As a last resort, convert the panics into errors and return.
+	defer func() {
+		if r := recover(); r != nil {
+			e := fmt.Errorf("%w, and summarizing its return statements failed: INTERNAL PANIC: %s\n%s",
+				reason, r, string(debug.Stack()))
+			funcChan <- functionResult{err: e, index: index, funcDecl: funcDecl}
+		}
+	}()
+
+	funcTriggers, err := assertiontree.SummarizeFuncReturns(funcContext)
+	if err != nil {
+		funcChan <- functionResult{
+			err:      fmt.Errorf("%w, and summarizing its return statements failed: %w", reason, err),
+			index:    index,
+			funcDecl: funcDecl,
+		}
+		return
+	}
+	funcChan <- functionResult{
+		triggers:   funcTriggers,
+		skipReason: fmt.Errorf("%w, only its return statements are summarized", reason),
+		index:      index,
+		funcDecl:   funcDecl,
+	}
+}
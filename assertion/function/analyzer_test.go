@@ -16,19 +16,21 @@ package function
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"go/ast"
 	"go/types"
+	"slices"
 	"strconv"
 	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/require"
 	"go.uber.org/goleak"
-	"go.uber.org/nilaway/annotation"
 	"go.uber.org/nilaway/assertion/anonymousfunc"
 	"go.uber.org/nilaway/assertion/function/assertiontree"
 	"go.uber.org/nilaway/assertion/function/functioncontracts"
+	"go.uber.org/nilaway/assertion/function/functionvalues"
 	"go.uber.org/nilaway/nilawaytest"
 	"go.uber.org/nilaway/util/analysishelper"
 	"golang.org/x/tools/go/analysis"
@@ -48,7 +50,7 @@ func TestAnalyzer(t *testing.T) {
 	// and convert it to an error via the result struct.
 	r, err := Analyzer.Run(nil /* pass */)
 	require.NoError(t, err)
-	require.ErrorContains(t, r.(*analysishelper.Result[[]annotation.FullTrigger]).Err, "INTERNAL PANIC")
+	require.ErrorContains(t, r.(*analysishelper.Result[Result]).Err, "INTERNAL PANIC")
 }
 
 func TestCancelledContext(t *testing.T) {
@@ -86,8 +88,9 @@ func TestCancelledContext(t *testing.T) {
 	emptyFuncLitMap := make(map[*ast.FuncLit]*anonymousfunc.FuncLitInfo)
 	emptyPkgFakeIdentMap := make(map[*ast.Ident]types.Object)
 	emptyFuncContracts := make(functioncontracts.Map)
+	emptyFuncValues := make(functionvalues.Map)
 	funcContext := assertiontree.NewFunctionContext(pass, funcDecl, nil, /* funcLit */
-		funcConfig, emptyFuncLitMap, emptyPkgFakeIdentMap, emptyFuncContracts)
+		funcConfig, emptyFuncLitMap, emptyPkgFakeIdentMap, emptyFuncContracts, emptyFuncValues)
 	// (3) Set up synchronization and communication for the goroutine we are going to spawn.
 	resultChan := make(chan functionResult)
 	var wg sync.WaitGroup
@@ -145,6 +148,37 @@ func TestAnalyzeFuncPanic(t *testing.T) {
 	require.ErrorContains(t, res.err, "panic")
 }
 
+func TestSummarizeFuncPanic(t *testing.T) {
+	t.Parallel()
+
+	resultChan := make(chan functionResult)
+	var wg sync.WaitGroup
+
+	// Intentionally give bad input data to cause a panic. We should convert the panic to an error
+	// that keeps the reason why the function is summarized, and send it back to the original
+	// channel.
+	wg.Go(func() {
+		summarizeFunc(
+			&ast.FuncDecl{},                  /* funcDecl */
+			assertiontree.FunctionContext{},  /* funcContext */
+			errors.New("function too large"), /* reason */
+			0,                                /* index */
+			resultChan,
+		)
+	})
+	// Fire up another goroutine that waits for the work to be done and closes the result channel.
+	go func() {
+		wg.Wait()
+		close(resultChan)
+	}()
+
+	res := <-resultChan
+	require.Equal(t, res.index, 0)
+	require.Empty(t, res.triggers)
+	require.ErrorContains(t, res.err, "function too large")
+	require.ErrorContains(t, res.err, "panic")
+}
+
 func TestBackpropFixpointConvergence(t *testing.T) {
 	t.Parallel()
 
@@ -174,8 +208,9 @@ func TestBackpropFixpointConvergence(t *testing.T) {
 		emptyFuncLitMap := make(map[*ast.FuncLit]*anonymousfunc.FuncLitInfo)
 		emptyPkgFakeIdentMap := make(map[*ast.Ident]types.Object)
 		emptyFuncContracts := make(functioncontracts.Map)
+		emptyFuncValues := make(functionvalues.Map)
 		funcContext := assertiontree.NewFunctionContext(pass, funcDecl, nil, /* funcLit */
-			funcConfig, emptyFuncLitMap, emptyPkgFakeIdentMap, emptyFuncContracts)
+			funcConfig, emptyFuncLitMap, emptyPkgFakeIdentMap, emptyFuncContracts, emptyFuncValues)
 		ctrlflowResult := pass.ResultOf[ctrlflow.Analyzer].(*ctrlflow.CFGs)
 
 		ctx, cancel := context.WithCancel(t.Context())
@@ -255,29 +290,31 @@ func TestFunctionSizeLimit(t *testing.T) {
 	require.NotNil(t, result, "Analysis result should not be nil")
 
 	// Extract the actual result from the enhanced result wrapper
-	enhancedResult, ok := result.Result.(*analysishelper.Result[[]annotation.FullTrigger])
-	require.True(t, ok, "Result should be of type *analysishelper.Result[[]annotation.FullTrigger]")
+	enhancedResult, ok := result.Result.(*analysishelper.Result[Result])
+	require.True(t, ok, "Result should be of type *analysishelper.Result[Result]")
+	// Skipping functions must not fail the analysis of the package.
+	require.NoError(t, enhancedResult.Err)
+	require.NotEmpty(t, enhancedResult.Res.Triggers, "Triggers of the other functions should be kept")
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
 			t.Parallel()
 
+			idx := slices.IndexFunc(enhancedResult.Res.Skipped, func(f SkippedFunc) bool { return f.Name == tc.functionName })
 			if tc.shouldBeSkipped {
-				// Function should be skipped - verify error contains expected messages
-				require.Error(t, enhancedResult.Err, "Should have error about %s being skipped", tc.functionName)
+				// Function should be skipped - verify the reason contains expected messages
+				require.NotEqual(t, -1, idx, "Should have skipped %s", tc.functionName)
+				skipped := enhancedResult.Res.Skipped[idx]
+				require.True(t, skipped.Pos.IsValid(), "Skipped function %s should be positioned", tc.functionName)
 
-				errorMessage := enhancedResult.Err.Error()
+				errorMessage := skipped.Reason.Error()
 				for _, expectedText := range tc.expectedErrorContains {
 					require.Contains(t, errorMessage, expectedText,
-						"Error should contain '%s' for function %s", expectedText, tc.functionName)
+						"Reason should contain '%s' for function %s", expectedText, tc.functionName)
 				}
 			} else {
-				// Function should be processed - verify no error about this specific function being skipped
-				if enhancedResult.Err != nil {
-					errorMessage := enhancedResult.Err.Error()
-					require.NotContains(t, errorMessage, tc.functionName,
-						"Function %s should not be mentioned in skipped functions error", tc.functionName)
-				}
+				// Function should be processed - verify this specific function is not skipped
+				require.Equal(t, -1, idx, "Function %s should not be skipped", tc.functionName)
 			}
 		})
 	}
@@ -27,7 +27,7 @@ import (
 
 	"go.uber.org/nilaway/annotation"
 	"go.uber.org/nilaway/assertion/function/preprocess"
-	"go.uber.org/nilaway/config"
+	"go.uber.org/nilaway/assertion/global"
 	"go.uber.org/nilaway/guard"
 	"go.uber.org/nilaway/util/analysishelper"
 	"go.uber.org/nilaway/util/asthelper"
@@ -82,6 +82,14 @@ func backpropAcrossNode(rootNode *RootAssertionNode, node ast.Node) error {
 		rootNode.AddComputation(n.X)
 	case *ast.GoStmt:
 		rootNode.AddComputation(n.Call)
+	case *ast.DeferStmt:
+		// The bodies of the unconditionally deferred function literals are spliced at the exits of
+		// the function during preprocessing (see preprocess.DeferredFuncLits), and for the other
+		// deferred calls the function value and the arguments are evaluated right here at the
+		// defer statement, e.g., `defer mu.Unlock()` dereferences `mu`.
+		if !preprocess.IsSplicedDeferStmt(rootNode.FuncDecl().Body, n) {
+			rootNode.AddComputation(n.Call)
+		}
 	case *ast.IncDecStmt:
 		rootNode.AddComputation(n.X)
 
@@ -107,9 +115,8 @@ func backpropAcrossNode(rootNode *RootAssertionNode, node ast.Node) error {
 		}
 	// The following cases are not interesting to our nilness analysis, or are currently
 	// unsupported, so we do nothing for them.
-	case *ast.BasicLit, *ast.Ident, *ast.EmptyStmt, *ast.DeferStmt:
+	case *ast.BasicLit, *ast.Ident, *ast.EmptyStmt:
 		// TODO: figure out what source code generates these cases - it's not obvious
-		// TODO: handle defers
 	default:
 		return fmt.Errorf("unrecognized AST node %T in CFG - add a case for it", n)
 	}
@@ -124,13 +131,31 @@ func backpropAcrossSend(rootNode *RootAssertionNode, node *ast.SendStmt) error {
 	// (1) A send to a nil channel blocks forever;
 	// (2) A send to a closed channel panics.
 	// (1) falls out of scope for NilAway and hence we do not create a consumer here for the
-	// channel variable. For (2), since we do not track the state of the channels, we currently
-	// cannot support it.
-	// TODO: rethink our strategy of handling channels (#192).
+	// channel variable. For (2), we track the channels closed in the same function below.
 	consumer, err := exprAsAssignmentConsumer(rootNode, node, nil)
 	if err != nil {
 		return err
 	}
+
+	// A send on a channel that may have been closed in this function panics, which we report
+	// directly as a full trigger from the `close` call (see closedChanOps).
+	if closeCall, ok := rootNode.functionContext.closedChanOps[node]; ok {
+		rootNode.AddNewTriggers(annotation.FullTrigger{
+			Producer: &annotation.ProduceTrigger{
+				Annotation: &annotation.ChanClose{
+					ProduceTriggerTautology: &annotation.ProduceTriggerTautology{},
+					ChanName:                types.ExprString(node.Chan),
+					CloseLine:               rootNode.Pass().Fset.Position(closeCall.Pos()).Line,
+				},
+				Expr: closeCall.Args[0],
+			},
+			Consumer: &annotation.ConsumeTrigger{
+				Annotation: &annotation.ClosedChanSend{ConsumeTriggerTautology: &annotation.ConsumeTriggerTautology{}},
+				Expr:       node.Chan,
+				Guards:     guard.NoGuards(),
+			},
+		})
+	}
 	if consumer != nil {
 		rootNode.AddConsumption(&annotation.ConsumeTrigger{
 			Annotation: consumer,
@@ -148,13 +173,23 @@ func backpropAcrossSend(rootNode *RootAssertionNode, node *ast.SendStmt) error {
 // backpropAcrossReturn handles backpropagation for return statements. It is designed to be called
 // from backpropAcrossNode as a special handler.
 func backpropAcrossReturn(rootNode *RootAssertionNode, node *ast.ReturnStmt) error {
+	// The synthetic exit after the deferred function literals only marks the end of the function,
+	// the results are consumed at the actual return statements.
+	if preprocess.IsDeferredExit(node) {
+		return nil
+	}
+
 	// we have to handle the case that a multiply-returning function is being returned, and split
 	// the productions appropriate instead of just calling computeAndConsumeResults directly in that case
 
-	if rootNode.functionContext.functionConfig.EnableStructInitCheck {
+	if rootNode.functionContext.functionConfig.tracksParamAndReturnFields() {
 		rootNode.addConsumptionsForFieldsOfParams()
 	}
 
+	if rootNode.functionContext.funcLit != nil {
+		rootNode.addConsumptionsForClosureVars(node)
+	}
+
 	if len(node.Results) == 1 {
 		if call, ok := node.Results[0].(*ast.CallExpr); ok {
 			var fident *ast.Ident
@@ -180,6 +215,11 @@ func backpropAcrossReturn(rootNode *RootAssertionNode, node *ast.ReturnStmt) err
 				if !handleIdent(fun.Sel) {
 					return computeAndConsumeResults(rootNode, node)
 				}
+			case *ast.IndexExpr, *ast.IndexListExpr:
+				// explicitly instantiated generic function (e.g., `return foo[int](x)`)
+				if ident := asthelper.FuncIdentFromInstantiation(fun); ident == nil || !handleIdent(ident) {
+					return computeAndConsumeResults(rootNode, node)
+				}
 			default:
 				// In this case - an anonymous function is called and returned, for now I don't
 				// know what to do here, so we just compute
@@ -203,8 +243,10 @@ func backpropAcrossReturn(rootNode *RootAssertionNode, node *ast.ReturnStmt) err
 						return errors.New("producers variable is nil")
 					}
 					// since we don't individually track the returns of a multiply returning function,
-					// we form full triggers for each return whose type doesn't bar nilness
-					if !typeshelper.TypeBarsNilness(funcObj.Type().(*types.Signature).Results().At(i).Type()) {
+					// we form full triggers for each return whose type doesn't bar nilness. Note
+					// that we check the type of the call rather than the declared result types, so
+					// that instantiated generic results (e.g., `T` instantiated by `int`) are exempt.
+					if !typeshelper.TypeBarsNilness(rootNode.Pass().TypesInfo.TypeOf(call).(*types.Tuple).At(i).Type()) {
 						isErrReturning := typeshelper.FuncIsErrReturning(funcObj.Signature())
 						isOkReturning := typeshelper.FuncIsOkReturning(funcObj.Signature())
 
@@ -285,6 +327,12 @@ func backpropAcrossAssignment(rootNode *RootAssertionNode, lhs, rhs []ast.Expr)
 		// Now that we've back-propagated across the assignment itself, make sure we can compute
 		// all of the lhs and rhs.
 		for _, rhsVal := range rhs {
+			// The type assertion in the "ok" form `v, ok := x.(T)` does not panic for a nil `x`,
+			// so we only compute `x` here.
+			if r, ok := ast.Unparen(rhsVal).(*ast.TypeAssertExpr); ok && len(lhs) == 2 && len(rhs) == 1 {
+				rootNode.AddComputation(r.X)
+				continue
+			}
 			rootNode.AddComputation(rhsVal)
 		}
 		for _, lhsVal := range lhs {
@@ -340,7 +388,7 @@ func backpropAcrossAssignment(rootNode *RootAssertionNode, lhs, rhs []ast.Expr)
 		// currently handle the following cases in NilAway:
 		// 1. Map read: `v, ok := m[k]`
 		// 2. Channel receive: `v, ok := <-ch`
-		// TODO: 3. Type assertion: `v, ok := y.(*type)`
+		// 3. Type assertion: `v, ok := y.(*type)`
 		if len(lhs) == 2 {
 			rootNode.AddGuardMatch(lhs[0], ContinueTracking)
 
@@ -379,24 +427,24 @@ func backpropAcrossAssignment(rootNode *RootAssertionNode, lhs, rhs []ast.Expr)
 			}
 
 			// Type assertion
+			// A failed type assertion yields the zero value of the asserted type, so for nilable
+			// types the value is nonnil only if `ok` is checked. Moreover, a nil `y` fails all type
+			// assertions, so it is nonnil if `ok` is checked as well.
 			if r, ok := rhsNode.(*ast.TypeAssertExpr); ok && r.Type != nil {
-				// TODO: properly handle type assertions' "OK" contract
-				return backpropAcrossOneToOneAssignment(rootNode, lhs[0:1], rhs)
-			}
-
-			// Generic function call
-			// TODO: currently we do not handle generic rich-check-effect function calls, so as a temporary solution,
-			//  we suppress reporting of errors. Note that this helps suppress false positives, but it also means that
-			//  we don't report true positives either. We should fix this in the future when we add support for generics.
-			if c := asthelper.CallExprFromExpr(rhsNode); c != nil {
-				if _, ok := c.Fun.(*ast.IndexExpr); ok {
-					if !asthelper.IsEmptyExpr(lhs[0]) {
-						rootNode.AddProduction(&annotation.ProduceTrigger{
-							Annotation: &annotation.ProduceTriggerNever{},
-							Expr:       lhs[0],
-						})
-					}
+				rootNode.AddGuardMatch(r.X, ProduceAsNonnil)
+				if !isNilableTypeAssertion(rootNode.Pass(), r) {
+					return backpropAcrossOneToOneAssignment(rootNode, lhs[0:1], rhs)
+				}
+				if !asthelper.IsEmptyExpr(lhs[0]) {
+					rootNode.AddProduction(&annotation.ProduceTrigger{
+						Annotation: &annotation.TypeAssertResult{
+							ProduceTriggerNever: &annotation.ProduceTriggerNever{NeedsGuard: true},
+							TypeName:            types.TypeString(rootNode.Pass().TypesInfo.TypeOf(r.Type), types.RelativeTo(rootNode.Pass().Pkg)),
+						},
+						Expr: lhs[0],
+					})
 				}
+				return nil
 			}
 		}
 	}
@@ -454,6 +502,13 @@ func backpropAcrossRange(rootNode *RootAssertionNode, lhs []ast.Expr, rhs ast.Ex
 	}
 
 	rhsType := types.Unalias(rootNode.Pass().TypesInfo.Types[rhs].Type)
+	// For a generic range expression (where rhsType is a *types.TypeParam), the range semantics
+	// is determined by the core type of its type set (e.g., `[]E` for `S ~[]E`).
+	if tp, ok := rhsType.(*types.TypeParam); ok {
+		if core := typeshelper.CoreType(tp); core != nil {
+			rhsType = core
+		}
+	}
 
 	// Go 1.23 introduced [range-over-func] language feature, where the `range` statement can
 	// now take the following types:
@@ -508,13 +563,6 @@ func backpropAcrossRange(rootNode *RootAssertionNode, lhs []ast.Expr, rhs ast.Ex
 			return nil
 		}
 
-		// We could be ranging over a generic slice (where rhsType is a *types.TypeParam) but
-		// we do not handle generics yet. Here we just assume generic slices are all deeply
-		// nonnil - we do not need to do anything here.
-		// TODO: handle that.
-		if _, ok := rhsType.(*types.TypeParam); ok {
-			return nil
-		}
 		return fmt.Errorf("unrecognized type of rhs in range statement: %s", rhsType)
 	default:
 		return fmt.Errorf("unexpected LHS found in assignment to 'range' operator")
@@ -670,7 +718,7 @@ buildShadowMask:
 				if rpath != nil {
 					// Both lhsVal and rhsVal are trackable! we're in case C
 
-					if rootNode.functionContext.functionConfig.EnableStructInitCheck {
+					if rootNode.functionContext.functionConfig.tracksParamAndReturnFields() {
 						// If rhs is a function call that is tracked then we just add field producers before detaching
 						// the assertion nodes
 						_, rproducers := rootNode.ParseExprAsProducer(rhsVal, true)
@@ -712,7 +760,7 @@ buildShadowMask:
 							Expr:       lhsVal,
 						})
 					case 1:
-						if rootNode.functionContext.functionConfig.EnableStructInitCheck {
+						if rootNode.functionContext.functionConfig.tracksParamAndReturnFields() {
 							fieldProducers := rproducers[0].GetFieldProducers()
 							rootNode.addProductionsForAssignmentFields(fieldProducers, lhsVal)
 						}
@@ -802,7 +850,7 @@ func backpropAcrossManyToOneAssignment(rootNode *RootAssertionNode, lhs, rhs []a
 		}
 
 		// Phase 1
-		if rootNode.functionContext.functionConfig.EnableStructInitCheck {
+		if rootNode.functionContext.functionConfig.tracksParamAndReturnFields() {
 			fieldProducers := producers[i].GetFieldProducers()
 			rootNode.addProductionsForAssignmentFields(fieldProducers, lhsVal)
 		}
@@ -907,13 +955,17 @@ func BackpropAcrossFunc(
 ) ([]annotation.FullTrigger, int, int, error) {
 	// We transform the CFG to have it reflect the implicit control flow that happens
 	// inside short-circuiting boolean expressions.
-	preprocessor := preprocess.New(pass)
+	preprocessor := preprocess.New(pass, functionContext.funcContracts)
 	graph = preprocessor.CFG(graph, functionContext.funcDecl)
 
 	// Generate rick check effects.
 	richCheckBlocks, exprNonceMap := genInitialRichCheckEffects(graph, functionContext)
 	richCheckBlocks = propagateRichChecks(graph, richCheckBlocks)
 	blocks, preprocessing := blocksAndPreprocessingFromCFG(pass, graph, richCheckBlocks)
+	functionContext.closedChanOps = closedChanOps(pass, blocks)
+	if functionContext.funcLit == nil {
+		functionContext.unsetGlobals = global.UnsetGlobalsAtEntry(pass, functionContext.funcDecl)
+	}
 
 	// The assertion nodes for each block and an array of bools to indicate whether each block is
 	// updated in this round or not.
@@ -1055,7 +1107,7 @@ func BackpropAcrossFunc(
 			stableRoundCount = 0
 		}
 
-		if stableRoundCount >= config.StableRoundLimit {
+		if stableRoundCount >= functionContext.functionConfig.stableRoundLimit() {
 			break
 		}
 
@@ -1078,5 +1130,6 @@ func BackpropAcrossFunc(
 	if currRootAssertionNode == nil {
 		return nil, roundCount, stableRoundCount, nil
 	}
-	return currRootAssertionNode.triggers, roundCount, stableRoundCount, nil
+	triggers := filterTriggersForRecover(pass, functionContext.funcDecl, currRootAssertionNode.triggers)
+	return triggers, roundCount, stableRoundCount, nil
 }
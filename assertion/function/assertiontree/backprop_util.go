@@ -23,6 +23,7 @@ import (
 	"go/types"
 
 	"go.uber.org/nilaway/annotation"
+	"go.uber.org/nilaway/assertion/function/preprocess"
 	"go.uber.org/nilaway/guard"
 	"go.uber.org/nilaway/hook"
 	"go.uber.org/nilaway/util/analysishelper"
@@ -155,7 +156,7 @@ func computeAndConsumeResults(rootNode *RootAssertionNode, node *ast.ReturnStmt)
 				if !asthelper.IsEmptyExpr(retVariable) {
 					addReturnConsumers(rootNode, node, retVariable, retKey, true /* isNamedReturn */)
 
-					if rootNode.functionContext.functionConfig.EnableStructInitCheck {
+					if rootNode.functionContext.functionConfig.tracksParamAndReturnFields() {
 						rootNode.addConsumptionsForFieldsOfReturns(results[i], i)
 					}
 				} else {
@@ -215,7 +216,7 @@ func computeAndConsumeResults(rootNode *RootAssertionNode, node *ast.ReturnStmt)
 		retKey := annotation.RetKeyFromRetNum(rootNode.ObjectOf(rootNode.FuncNameIdent()).(*types.Func), i)
 		addReturnConsumers(rootNode, node, node.Results[i], retKey, false /* isNamedReturn */)
 
-		if rootNode.functionContext.functionConfig.EnableStructInitCheck {
+		if rootNode.functionContext.functionConfig.tracksParamAndReturnFields() {
 			rootNode.addConsumptionsForFieldsOfReturns(node.Results[i], i)
 		}
 	}
@@ -258,7 +259,7 @@ func isErrorReturnNonnil(rootNode *RootAssertionNode, errRet ast.Expr) bool {
 	}
 	// The error value is the return of a hook-modeled function known to produce a non-nil error.
 	if callExpr, ok := errRet.(*ast.CallExpr); ok {
-		if hook.AssumeReturn(rootNode.Pass(), callExpr) != nil {
+		if prod := hook.AssumeReturn(rootNode.Pass(), callExpr); prod != nil && prod.Annotation.Kind() == annotation.Never {
 			return true
 		}
 	}
@@ -296,7 +297,7 @@ func handleErrorReturns(rootNode *RootAssertionNode, retStmt *ast.ReturnStmt, re
 		}
 
 		// TODO: handle struct init in the context of error return in a better way in a follow up diff
-		if rootNode.functionContext.functionConfig.EnableStructInitCheck {
+		if rootNode.functionContext.functionConfig.tracksParamAndReturnFields() {
 			for i := range results {
 				rootNode.addConsumptionsForFieldsOfReturns(results[i], i)
 			}
@@ -309,7 +310,7 @@ func handleErrorReturns(rootNode *RootAssertionNode, retStmt *ast.ReturnStmt, re
 		createSpecialConsumersForAllReturns(rootNode, nonErrRetExpr, errRetExpr, errRetIndex, retStmt, isNamedReturn)
 
 		// TODO: handle struct init in the context of error return in a better way in a follow up diff
-		if rootNode.functionContext.functionConfig.EnableStructInitCheck {
+		if rootNode.functionContext.functionConfig.tracksParamAndReturnFields() {
 			for i := range results {
 				rootNode.addConsumptionsForFieldsOfReturns(results[i], i)
 			}
@@ -560,7 +561,7 @@ func exprAsAssignmentConsumer(rootNode *RootAssertionNode, expr ast.Node, exprRH
 				}
 			case *ast.CallExpr:
 				// check if this is a call to a function by name
-				if ident := asthelper.FuncIdentFromCallExpr(expr); ident != nil {
+				if ident := asthelper.FuncIdentFromCallExpr(expr); ident != nil && rootNode.isFunc(ident) {
 					obj := rootNode.ObjectOf(ident).(*types.Func)
 					if obj.Type().(*types.Signature).Results().Len() != 1 {
 						return nil, errors.New("multiply returning function treated as assignment consumer")
@@ -642,7 +643,7 @@ func exprAsAssignmentConsumer(rootNode *RootAssertionNode, expr ast.Node, exprRH
 			}
 		}
 
-		if rootNode.functionContext.functionConfig.EnableStructInitCheck {
+		if rootNode.functionContext.functionConfig.tracksParamAndReturnFields() {
 			if head := asthelper.GetSelectorExprHeadIdent(expr); head != nil {
 				if obj, ok := rootNode.ObjectOf(head).(*types.Var); ok {
 					if !annotation.VarIsGlobal(obj) {
@@ -652,6 +653,9 @@ func exprAsAssignmentConsumer(rootNode *RootAssertionNode, expr ast.Node, exprRH
 						// For a global variable g, `g.f = nil` would result in a const nil field assignment trigger.
 						// However, for other type of variables `p.f = nil` would result into an escape trigger only if the
 						// field escapes as per the definition of field escape in our analysis.
+						// Similarly, with object sensitive fields, `p.f = nil` only flows to the later reads of `p.f`
+						// along its access path (and across functions via param and return fields), instead of
+						// affecting the field `f` of every other object.
 						return nil, nil
 					}
 				}
@@ -708,13 +712,13 @@ func blocksAndPreprocessingFromCFG(pass *analysishelper.EnhancedPass, graph *cfg
 		Live:  true,
 	})
 	// add "return" block as a successor for:
-	// - all returning blocks
+	// - all returning blocks (except the ones followed by deferred function literals, see preprocess.DeferredFuncLits)
 	// - while loops (`for <EXPR> {}` or `for {}`)
 	for i := 0; i < numBlocks; i++ {
 		// TODO: storing `blocks[i].Succs` in a local variable should not be needed. But NilAway complaints about slicing
 		//  of the field `blocks[i].Succs` in the if condition. This should be fixed.
 		succ := blocks[i].Succs
-		if blocks[i].Return() != nil || (len(succ) == 1 && succ[0].Index == blocks[i].Index) {
+		if (blocks[i].Return() != nil && len(succ) == 0) || (len(succ) == 1 && succ[0].Index == blocks[i].Index) {
 			blocks[i].Succs = append(blocks[i].Succs, blocks[numBlocks])
 		}
 	}
@@ -898,3 +902,72 @@ func addReturnConsumers(rootNode *RootAssertionNode, node *ast.ReturnStmt, expr
 		})
 	}
 }
+
+// filterTriggersForRecover removes the triggers requiring the parameters (or the receiver) of the
+// function to be nonnil for dereferences, if the function recovers from panics in a deferred
+// function literal (see recoversPanics). The panics caused by such dereferences are recovered, so
+// they do not propagate nonnil requirements to the callers of the function. The dereferences
+// inside the deferred function literals themselves are not recovered and hence kept.
+func filterTriggersForRecover(pass *analysishelper.EnhancedPass, funcDecl *ast.FuncDecl, triggers []annotation.FullTrigger) []annotation.FullTrigger {
+	if funcDecl == nil || funcDecl.Body == nil {
+		return triggers
+	}
+	funcLits := preprocess.DeferredFuncLits(funcDecl.Body)
+	if !recoversPanics(pass, funcLits) {
+		return triggers
+	}
+
+	inDeferredFuncLit := func(expr ast.Expr) bool {
+		for _, funcLit := range funcLits {
+			if funcLit.Pos() <= expr.Pos() && expr.End() <= funcLit.End() {
+				return true
+			}
+		}
+		return false
+	}
+
+	filtered := make([]annotation.FullTrigger, 0, len(triggers))
+	for _, t := range triggers {
+		switch t.Producer.Annotation.(type) {
+		case *annotation.FuncParam, *annotation.MethodRecv:
+			switch t.Consumer.Annotation.(type) {
+			case *annotation.PtrLoad, *annotation.FldAccess, *annotation.MapWrittenTo, *annotation.SliceAccess:
+				if t.Consumer.Expr != nil && !inDeferredFuncLit(t.Consumer.Expr) {
+					continue
+				}
+			}
+		}
+		filtered = append(filtered, t)
+	}
+	return filtered
+}
+
+// recoversPanics returns true if any of the deferred function literals directly calls the builtin
+// `recover`, which stops the panics of the enclosing function.
+func recoversPanics(pass *analysishelper.EnhancedPass, funcLits []*ast.FuncLit) bool {
+	for _, funcLit := range funcLits {
+		found := false
+		ast.Inspect(funcLit.Body, func(node ast.Node) bool {
+			if found {
+				return false
+			}
+			switch n := node.(type) {
+			case *ast.FuncLit:
+				// `recover` only stops panics when called directly by the deferred function.
+				return false
+			case *ast.CallExpr:
+				if ident, ok := ast.Unparen(n.Fun).(*ast.Ident); ok {
+					if b, ok := pass.TypesInfo.Uses[ident].(*types.Builtin); ok && b.Name() == "recover" {
+						found = true
+						return false
+					}
+				}
+			}
+			return true
+		})
+		if found {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,126 @@
+//  Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package assertiontree
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"go.uber.org/nilaway/util/analysishelper"
+	"golang.org/x/tools/go/cfg"
+)
+
+// closedChanOps computes the channel operations, i.e., the receives `<-ch` (*ast.UnaryExpr) and the
+// sends `ch <- v` (*ast.SendStmt), that may happen after the channel is closed by a `close(ch)`
+// call in the same function, mapped to such a call. This is a forward may-analysis over the CFG,
+// where a channel is closed after a `close(ch)` call until it is reassigned.
+//
+// Only the channels stored in local variables or parameters are tracked, and the operations in
+// (non-spliced) function literals are ignored.
+// TODO: track channels stored in struct fields as well (#192).
+func closedChanOps(pass *analysishelper.EnhancedPass, blocks []*cfg.Block) map[ast.Node]*ast.CallExpr {
+	// closedChans maps the channel variables that may be closed to one of the `close` calls.
+	type closedChans map[*types.Var]*ast.CallExpr
+
+	chanVar := func(expr ast.Expr) *types.Var {
+		ident, ok := ast.Unparen(expr).(*ast.Ident)
+		if !ok {
+			return nil
+		}
+		v, _ := pass.TypesInfo.ObjectOf(ident).(*types.Var)
+		return v
+	}
+
+	ops := make(map[ast.Node]*ast.CallExpr)
+	// transfer applies the effects of the node to the closed channels in place, and records the
+	// channel operations on the closed channels in `ops`.
+	transfer := func(node ast.Node, closed closedChans) {
+		ast.Inspect(node, func(n ast.Node) bool {
+			switch n := n.(type) {
+			case *ast.FuncLit:
+				return false
+			case *ast.UnaryExpr:
+				if n.Op == token.ARROW {
+					if call, ok := closed[chanVar(n.X)]; ok {
+						ops[n] = call
+					}
+				}
+			case *ast.SendStmt:
+				if call, ok := closed[chanVar(n.Chan)]; ok {
+					ops[n] = call
+				}
+			}
+			return true
+		})
+
+		// Closing and reassigning the channels happen after the evaluation of the operands.
+		switch n := node.(type) {
+		case *ast.ExprStmt:
+			call, ok := ast.Unparen(n.X).(*ast.CallExpr)
+			if !ok || len(call.Args) != 1 {
+				return
+			}
+			if ident, ok := ast.Unparen(call.Fun).(*ast.Ident); ok {
+				if b, ok := pass.TypesInfo.Uses[ident].(*types.Builtin); ok && b.Name() == "close" {
+					if v := chanVar(call.Args[0]); v != nil {
+						closed[v] = call
+					}
+				}
+			}
+		case *ast.AssignStmt:
+			for _, lhs := range n.Lhs {
+				delete(closed, chanVar(lhs))
+			}
+		case *ast.ValueSpec:
+			for _, name := range n.Names {
+				delete(closed, chanVar(name))
+			}
+		}
+	}
+
+	// Run the analysis to a fixed point, where the closed channels at the entry of each block are
+	// the union of the ones at the exits of its predecessors.
+	entries := make([]closedChans, len(blocks))
+	entries[0] = make(closedChans)
+	for changed := true; changed; {
+		changed = false
+		for i, block := range blocks {
+			if entries[i] == nil || !block.Live {
+				continue
+			}
+			closed := make(closedChans, len(entries[i]))
+			for v, call := range entries[i] {
+				closed[v] = call
+			}
+			for _, node := range block.Nodes {
+				transfer(node, closed)
+			}
+			for _, succ := range block.Succs {
+				if entries[succ.Index] == nil {
+					entries[succ.Index] = make(closedChans)
+					changed = true
+				}
+				for v, call := range closed {
+					if _, ok := entries[succ.Index][v]; !ok {
+						entries[succ.Index][v] = call
+						changed = true
+					}
+				}
+			}
+		}
+	}
+	return ops
+}
@@ -0,0 +1,123 @@
+//  Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package assertiontree
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"slices"
+
+	"go.uber.org/nilaway/annotation"
+	"go.uber.org/nilaway/assertion/anonymousfunc"
+	"go.uber.org/nilaway/guard"
+)
+
+// closureVarSite is the key of the fake identifiers created for captured variables.
+type closureVarSite struct {
+	obj *types.Var
+	pos token.Pos
+}
+
+// closureVarIdent returns a fake identifier for the captured variable v at the given position,
+// so that the triggers created for it are reported at that position rather than at its first
+// appearance in the function literal.
+func (r *RootAssertionNode) closureVarIdent(v *anonymousfunc.VarInfo, pos token.Pos) *ast.Ident {
+	site := closureVarSite{obj: v.Obj, pos: pos}
+	if ident, ok := r.functionContext.closureVarIdents[site]; ok {
+		return ident
+	}
+	ident := &ast.Ident{NamePos: pos, Name: v.Obj.Name()}
+	r.functionContext.AddFakeIdent(ident, v.Obj)
+	r.functionContext.closureVarIdents[site] = ident
+	return ident
+}
+
+// addConsumptionsForClosureVars is called at the returns of a function literal, and consumes the
+// values of the captured variables the function literal assigns with their
+// ClosureVarRetAnnotationKey, which flow back to the enclosing function after the calls that run
+// the function literal synchronously (see addProductionsForClosureVars).
+func (r *RootAssertionNode) addConsumptionsForClosureVars(node *ast.ReturnStmt) {
+	info, ok := r.functionContext.funcLitMap[r.functionContext.funcLit]
+	if !ok {
+		return
+	}
+	for _, v := range info.AssignedVars {
+		r.AddConsumption(&annotation.ConsumeTrigger{
+			Annotation: &annotation.UseAsClosureVarReturn{
+				TriggerIfNonNil: &annotation.TriggerIfNonNil{
+					Ann: annotation.NewClosureVarRetKey(info.FakeFuncObj, v.Obj),
+				}},
+			Expr:   r.closureVarIdent(v, node.Pos()),
+			Guards: guard.NoGuards(),
+		})
+	}
+}
+
+// addProductionsForClosureVars handles the function literals that the call expression runs
+// synchronously (see anonymousfunc.FuncLitInfo.SyncCalls), either by invoking them directly
+// (e.g., `func() {...}()`) or by passing them to a synchronous caller (e.g.,
+// `run(func() {...})`). The captured variables assigned by such a literal are produced with
+// their ClosureVarRetAnnotationKey after the call. If the call may not run the literal (see
+// anonymousfunc.FuncLitInfo.MaybeSyncCalls, e.g., `once.Do(func() {...})`), these productions are
+// merged with the values of the variables before the call instead. For the calls other than direct
+// invocations, the captured variables are also passed to the fake parameters of the literal before
+// the call, which is otherwise done when consuming the arguments of a direct invocation.
+//
+// This must be called before the arguments of the call are consumed, since the productions
+// happen after the call.
+func (r *RootAssertionNode) addProductionsForClosureVars(expr *ast.CallExpr) {
+	for i, e := range slices.Concat([]ast.Expr{expr.Fun}, expr.Args) {
+		info, ok := r.functionContext.funcLitMap[anonymousfunc.FuncLitOf(e)]
+		if !ok {
+			continue
+		}
+		always := slices.Contains(info.SyncCalls, expr)
+		if !always && !slices.Contains(info.MaybeSyncCalls, expr) {
+			continue
+		}
+
+		for _, v := range info.AssignedVars {
+			producer := &annotation.ProduceTrigger{
+				Annotation: &annotation.ClosureVarReturn{
+					TriggerIfNilable: &annotation.TriggerIfNilable{
+						Ann: annotation.NewClosureVarRetKey(info.FakeFuncObj, v.Obj),
+					}},
+				Expr: r.closureVarIdent(v, expr.Rparen),
+			}
+			if always {
+				r.AddProduction(producer)
+			} else {
+				r.addMaybeProduction(producer)
+			}
+		}
+
+		if i == 0 {
+			// The closure variables of a direct invocation are consumed as its arguments.
+			continue
+		}
+		numParams := info.FakeFuncObj.Signature().Params().Len() - len(info.ClosureVars)
+		for j, v := range info.ClosureVars {
+			r.AddConsumption(&annotation.ConsumeTrigger{
+				Annotation: &annotation.ArgPass{
+					TriggerIfNonNil: &annotation.TriggerIfNonNil{
+						Ann: annotation.ParamKeyFromArgNum(info.FakeFuncObj, numParams+j),
+					}},
+				Expr:   r.closureVarIdent(v, e.Pos()),
+				Guards: guard.NoGuards(),
+			})
+		}
+	}
+}
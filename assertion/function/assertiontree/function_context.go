@@ -20,6 +20,8 @@ import (
 
 	"go.uber.org/nilaway/assertion/anonymousfunc"
 	"go.uber.org/nilaway/assertion/function/functioncontracts"
+	"go.uber.org/nilaway/assertion/function/functionvalues"
+	"go.uber.org/nilaway/config"
 	"go.uber.org/nilaway/util/analysishelper"
 )
 
@@ -63,6 +65,22 @@ type FunctionContext struct {
 
 	// funcContracts stores the function contracts of all the functions.
 	funcContracts functioncontracts.Map
+
+	// funcValues stores the resolved targets of the calls to function values in the package.
+	funcValues functionvalues.Map
+
+	// closedChanOps stores the channel receives and sends that may happen after the channels are
+	// closed in the function, mapped to the `close` calls (see closedChanOps).
+	closedChanOps map[ast.Node]*ast.CallExpr
+
+	// closureVarIdents caches the fake identifiers created for captured variables (see
+	// closureVarIdent). Similar to selectorExpressionCache, creating a new identifier in every
+	// round of backpropagation would prevent the analysis from reaching a fixpoint.
+	closureVarIdents map[closureVarSite]*ast.Ident
+
+	// unsetGlobals stores the package-level variables that still hold nil at the entry of the
+	// function if it is an `init()` function (see global.UnsetGlobalsAtEntry); otherwise nil.
+	unsetGlobals map[*types.Var]bool
 }
 
 // FunctionConfig is meant to hold all the user set configuration for analyzing a function
@@ -71,6 +89,28 @@ type FunctionConfig struct {
 	EnableStructInitCheck bool
 	// EnableAnonymousFunc is a flag to enable checking anonymous functions.
 	EnableAnonymousFunc bool
+	// EnableObjectSensitiveFields is a flag to track the nilability of struct fields per access path (e.g., `a.f` and
+	// `b.f` are tracked separately) rather than per field declaration. The assignments to the fields of non-global
+	// variables then no longer affect the field declaration, and the nilability of such fields flows across functions
+	// via param field and return field annotation sites.
+	EnableObjectSensitiveFields bool
+	// StableRoundLimit is the number of rounds without changes in the collected triggers after which the
+	// backpropagation halts. A non-positive value means config.DefaultStableRoundLimit.
+	StableRoundLimit int
+}
+
+// tracksParamAndReturnFields returns true if the nilability of struct fields is tracked across functions via param
+// field and return field annotation sites.
+func (c FunctionConfig) tracksParamAndReturnFields() bool {
+	return c.EnableStructInitCheck || c.EnableObjectSensitiveFields
+}
+
+// stableRoundLimit returns the number of stable rounds after which the backpropagation halts.
+func (c FunctionConfig) stableRoundLimit() int {
+	if c.StableRoundLimit <= 0 {
+		return config.DefaultStableRoundLimit
+	}
+	return c.StableRoundLimit
 }
 
 // NewFunctionContext returns a new FunctionContext and initializes all the maps
@@ -82,6 +122,7 @@ func NewFunctionContext(
 	funcLitMap map[*ast.FuncLit]*anonymousfunc.FuncLitInfo,
 	pkgFakeIdentMap map[*ast.Ident]types.Object,
 	funcContracts functioncontracts.Map,
+	funcValues functionvalues.Map,
 ) FunctionContext {
 	return FunctionContext{
 		pass:                    pass,
@@ -93,6 +134,8 @@ func NewFunctionContext(
 		funcLitMap:              funcLitMap,
 		pkgFakeIdentMap:         pkgFakeIdentMap,
 		funcContracts:           funcContracts,
+		funcValues:              funcValues,
+		closureVarIdents:        make(map[closureVarSite]*ast.Ident),
 	}
 }
 
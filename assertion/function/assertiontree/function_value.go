@@ -0,0 +1,173 @@
+//  Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package assertiontree
+
+import (
+	"go/ast"
+	"go/types"
+	"slices"
+
+	"go.uber.org/nilaway/annotation"
+	"go.uber.org/nilaway/assertion/function/functionvalues"
+	"go.uber.org/nilaway/assertion/function/producer"
+	"go.uber.org/nilaway/guard"
+	"go.uber.org/nilaway/util/typeshelper"
+)
+
+// funcValueCallee returns the variable (or struct field) of function type called in the call
+// expression, e.g., `f` in `f()` and `s.f()`, or nil if the call is not a call to a function value.
+func (r *RootAssertionNode) funcValueCallee(expr *ast.CallExpr) *types.Var {
+	var ident *ast.Ident
+	switch fun := expr.Fun.(type) {
+	case *ast.Ident:
+		ident = fun
+	case *ast.SelectorExpr:
+		ident = fun.Sel
+	default:
+		return nil
+	}
+	v, _ := r.ObjectOf(ident).(*types.Var)
+	return v
+}
+
+// funcValueTargets returns the possible targets of the call to a function value resolved by
+// functionvalues.Analyzer, where the function literals are replaced by their fake function
+// objects, such that they are connected to the call like the declared functions. The targets are
+// unresolved if the call is unknown to the analyzer (e.g., if the call expression is created
+// during preprocessing), or if any of the function literals is unknown to the anonymous function
+// analysis.
+func (r *RootAssertionNode) funcValueTargets(expr *ast.CallExpr) *functionvalues.Targets {
+	targets, ok := r.functionContext.funcValues[expr.Lparen]
+	if !ok {
+		return &functionvalues.Targets{IsUnresolved: true}
+	}
+	if len(targets.FuncLits) == 0 {
+		return targets
+	}
+	resolved := &functionvalues.Targets{Funcs: slices.Clone(targets.Funcs), IsUnresolved: targets.IsUnresolved}
+	for _, lit := range targets.FuncLits {
+		info, ok := r.functionContext.funcLitMap[lit]
+		if !ok {
+			resolved.IsUnresolved = true
+			continue
+		}
+		resolved.Funcs = append(resolved.Funcs, info.FakeFuncObj)
+	}
+	return resolved
+}
+
+// getFuncValueReturnProducers returns the producers for the results of the call to the function
+// value funcValue, based on its resolved targets:
+//   - if the targets cannot be fully resolved, the results are trusted to be nonnil, except for
+//     the results guarded by the error result following the error contract;
+//   - if the function value may only be nil, the results are trusted to be nonnil since the call
+//     panics regardless;
+//   - if the function value refers to a single function (a declared function, a bound method or
+//     a function literal), the results are produced by the return annotations of that function
+//     directly;
+//   - otherwise, the results are produced by the FuncValueRetAnnotationKey of the call, into
+//     which the results of all the targets flow (see addFuncValueResultTriggers).
+func (r *RootAssertionNode) getFuncValueReturnProducers(funcValue *types.Var, expr *ast.CallExpr) []producer.ParsedProducer {
+	sig := typeshelper.GetFuncSignature(r.Pass().TypesInfo.TypeOf(expr.Fun))
+	if sig == nil {
+		return nil
+	}
+	targets := r.funcValueTargets(expr)
+	if !targets.IsUnresolved && len(targets.Funcs) == 0 {
+		return []producer.ParsedProducer{producer.ShallowParsedProducer{Producer: &annotation.ProduceTrigger{
+			Annotation: &annotation.TrustedFuncNonnil{ProduceTriggerNever: &annotation.ProduceTriggerNever{}},
+			Expr:       expr,
+		}}}
+	}
+
+	// Only the error contract of the results is supported for calls to function values, since the
+	// "ok" form checks are only recognized for calls to declared functions.
+	isErrReturning := typeshelper.FuncIsErrReturning(sig)
+	numResults := sig.Results().Len()
+	producers := make([]producer.ParsedProducer, numResults)
+	for i := 0; i < numResults; i++ {
+		// for an error-returning function value, all but the last result are guarded
+		needsGuard := isErrReturning && i != numResults-1
+
+		var shallow annotation.ProducingAnnotationTrigger
+		deep := annotation.DeepNilabilityAsNamedType(sig.Results().At(i).Type())
+		switch {
+		case targets.IsUnresolved && needsGuard:
+			shallow = &annotation.UnresolvedFuncValueGuardedReturn{
+				ProduceTriggerNever: &annotation.ProduceTriggerNever{NeedsGuard: true},
+				RetNum:              i,
+				FuncValueName:       funcValue.Name(),
+			}
+		case targets.IsUnresolved:
+			shallow = &annotation.UnresolvedFuncValueReturn{
+				ProduceTriggerNever: &annotation.ProduceTriggerNever{},
+				RetNum:              i,
+				FuncValueName:       funcValue.Name(),
+			}
+		case len(targets.Funcs) == 1:
+			shallow = &annotation.FuncReturn{
+				TriggerIfNilable: &annotation.TriggerIfNilable{
+					Ann:        annotation.RetKeyFromRetNum(targets.Funcs[0], i),
+					NeedsGuard: needsGuard,
+				},
+				IsFromRichCheckEffectFunc: isErrReturning,
+			}
+			deep = annotation.DeepNilabilityOfFuncRet(targets.Funcs[0], i)
+		default:
+			shallow = &annotation.FuncValueReturn{
+				TriggerIfNilable: &annotation.TriggerIfNilable{
+					Ann:        annotation.NewFuncValueRetKey(funcValue, i, r.LocationOf(expr)),
+					NeedsGuard: needsGuard,
+				},
+			}
+		}
+
+		producers[i] = producer.DeepParsedProducer{
+			ShallowProducer: &annotation.ProduceTrigger{Annotation: shallow, Expr: expr},
+			DeepProducer:    &annotation.ProduceTrigger{Annotation: deep, Expr: expr},
+		}
+	}
+	return producers
+}
+
+// addFuncValueResultTriggers adds the full triggers connecting the results of the possible targets
+// of the call to the function value funcValue to the FuncValueRetAnnotationKey of the call, if the
+// key is used for producing the results of the call (see getFuncValueReturnProducers).
+func (r *RootAssertionNode) addFuncValueResultTriggers(funcValue *types.Var, expr *ast.CallExpr, targets *functionvalues.Targets) {
+	if targets.IsUnresolved || len(targets.Funcs) < 2 {
+		return
+	}
+	for _, target := range targets.Funcs {
+		for i := 0; i < typeshelper.FuncNumResults(target); i++ {
+			r.AddNewTriggers(annotation.FullTrigger{
+				Producer: &annotation.ProduceTrigger{
+					Annotation: &annotation.FuncReturn{
+						TriggerIfNilable: &annotation.TriggerIfNilable{Ann: annotation.RetKeyFromRetNum(target, i)},
+					},
+					Expr: expr,
+				},
+				Consumer: &annotation.ConsumeTrigger{
+					Annotation: &annotation.UseAsFuncValueResult{
+						TriggerIfNonNil: &annotation.TriggerIfNonNil{
+							Ann: annotation.NewFuncValueRetKey(funcValue, i, r.LocationOf(expr)),
+						},
+					},
+					Expr:   expr,
+					Guards: guard.NoGuards(),
+				},
+			})
+		}
+	}
+}
@@ -20,6 +20,7 @@ import (
 	"go/types"
 
 	"go.uber.org/nilaway/annotation"
+	"go.uber.org/nilaway/assertion/anonymousfunc"
 	"go.uber.org/nilaway/assertion/function/producer"
 	"go.uber.org/nilaway/hook"
 	"go.uber.org/nilaway/util/asthelper"
@@ -262,7 +263,7 @@ func (r *RootAssertionNode) ParseExprAsProducer(expr ast.Expr, doNotTrack bool)
 			} else {
 				// TODO: this is a temporary fix to handle the case of anonymous functions.
 				//  Remove this once we have have enabled the anonymous function support.
-				funcLit := getFuncLitFromAssignment(fun)
+				funcLit := anonymousfunc.FuncLitFromAssignment(fun)
 				if funcLit != nil {
 					sig := typeshelper.GetFuncSignature(r.Pass().TypesInfo.TypeOf(funcLit))
 					if sig != nil && typeshelper.FuncIsErrReturning(sig) {
@@ -274,33 +275,9 @@ func (r *RootAssertionNode) ParseExprAsProducer(expr ast.Expr, doNotTrack bool)
 				}
 			}
 
-			// Check if it is a type alias for a function type.
-			// e.g., type MyFunc func() (*int, error)
-			// func foo(f MyErrRetFunc) {
-			// 		x, err := f()
-			// 		if err != nil {
-			// 			return
-			// 		}
-			// 		_ = *x
-			// }
-			// TODO: this is only a temporary fix to suppress false positives caused by type aliases.
-			//  Remove this once we have implemented complete support for type aliases.
-			t := r.Pass().TypesInfo.TypeOf(fun)
-			if _, ok := t.(*types.Alias); ok {
-				return nil, []producer.ParsedProducer{producer.ShallowParsedProducer{Producer: &annotation.ProduceTrigger{
-					Annotation: &annotation.TrustedFuncNonnil{ProduceTriggerNever: &annotation.ProduceTriggerNever{}},
-					Expr:       expr,
-				}}}
-			}
-
-			// Check if the method is a function value, e.g., `f := func() {}` and then `f()`.
-			// TODO: this is a temporary fix to suppress false positives caused by function values.
-			//  Remove this once we have have implemented the function value support.
+			// Check if the function is a function value, e.g., `f := foo` and then `f()`.
 			if r.isVariable(fun) {
-				return nil, []producer.ParsedProducer{producer.ShallowParsedProducer{Producer: &annotation.ProduceTrigger{
-					Annotation: &annotation.TrustedFuncNonnil{ProduceTriggerNever: &annotation.ProduceTriggerNever{}},
-					Expr:       expr,
-				}}}
+				return nil, r.getFuncValueReturnProducers(r.ObjectOf(fun).(*types.Var), expr)
 			}
 
 			if fun != nil && !r.isFunc(fun) {
@@ -334,8 +311,9 @@ func (r *RootAssertionNode) ParseExprAsProducer(expr ast.Expr, doNotTrack bool)
 				// anonymous functions will also fall into this case
 				return nil, nil
 			}
-			// non-builtin funcs
-			if !doNotTrack && litArgs() {
+			// non-builtin funcs. The calls to the contracted functions are not tracked since their
+			// results have unique sites at every call site (see HasContract).
+			if !doNotTrack && litArgs() && !r.HasContract(r.ObjectOf(fun).(*types.Func)) {
 				return TrackableExpr{&funcAssertionNode{
 					decl: r.ObjectOf(fun).(*types.Func), args: expr.Args}}, nil
 			}
@@ -344,21 +322,17 @@ func (r *RootAssertionNode) ParseExprAsProducer(expr ast.Expr, doNotTrack bool)
 			return nil, r.getFuncReturnProducers(fun, expr)
 
 		case *ast.SelectorExpr: // method call
-			// Check if the method is a function value, e.g., `f := func() {}` and then `f()`.
-			// TODO: this is a temporary fix to handle the case of function values.
-			//  Remove this once we have have implemented the function value support.
+			// Check if the method is a function value stored in a struct field or a package-level
+			// variable, e.g., `s.f()` or `pkg.f()`.
 			if r.isVariable(fun.Sel) {
-				return nil, []producer.ParsedProducer{producer.ShallowParsedProducer{Producer: &annotation.ProduceTrigger{
-					Annotation: &annotation.TrustedFuncNonnil{ProduceTriggerNever: &annotation.ProduceTriggerNever{}},
-					Expr:       expr,
-				}}}
+				return nil, r.getFuncValueReturnProducers(r.ObjectOf(fun.Sel).(*types.Var), expr)
 			}
 
 			if !r.isFunc(fun.Sel) {
 				// we assume builtins and type casts don't return nil
 				return nil, nil
 			}
-			if doNotTrack {
+			if doNotTrack || r.HasContract(r.ObjectOf(fun.Sel).(*types.Func)) {
 				return nil, r.getFuncReturnProducers(fun.Sel, expr)
 			}
 			if litArgs() {
@@ -377,6 +351,18 @@ func (r *RootAssertionNode) ParseExprAsProducer(expr ast.Expr, doNotTrack bool)
 			// function call has non-literal args, so is not literal, use its return annotation
 			return nil, r.getFuncReturnProducers(fun.Sel, expr)
 
+		case *ast.IndexExpr, *ast.IndexListExpr: // explicitly instantiated generic function call
+			funcIdent := asthelper.FuncIdentFromInstantiation(fun)
+			if funcIdent == nil || !r.isFunc(funcIdent) {
+				// calls to function values stored in slices or maps (e.g., `fs[0]()`) and conversions
+				// to instantiated generic types (e.g., `List[int](x)`)
+				return nil, nil
+			}
+			// We do not track the call since different instantiations (e.g., `Zero[*A]()` and
+			// `Zero[*B]()`) could otherwise be treated as the same expression, so we directly use
+			// the return annotations of the generic function.
+			return nil, r.getFuncReturnProducers(funcIdent, expr)
+
 		case *ast.FuncLit:
 			// TODO: This case can possibly be combined with the case of *ast.Ident above.
 			var funcIdent *ast.Ident
@@ -519,7 +505,24 @@ func (r *RootAssertionNode) ParseExprAsProducer(expr ast.Expr, doNotTrack bool)
 		return nil, parseDeepRead(recv, expr.X, expr, rproducers)
 	case *ast.UnaryExpr:
 		if expr.Op == token.ARROW {
-			// we've found a receive expression
+			// we've found a receive expression. A receive on a channel that may have been closed
+			// in this function yields the zero value, which is nil for nilable element types.
+			if closeCall, ok := r.functionContext.closedChanOps[expr]; ok && !r.Pass().ExprBarsNilness(expr) {
+				return nil, []producer.ParsedProducer{producer.DeepParsedProducer{
+					ShallowProducer: &annotation.ProduceTrigger{
+						Annotation: &annotation.ClosedChanRecv{
+							ProduceTriggerTautology: &annotation.ProduceTriggerTautology{},
+							ChanName:                types.ExprString(expr.X),
+							CloseLine:               r.Pass().Fset.Position(closeCall.Pos()).Line,
+						},
+						Expr: expr,
+					},
+					DeepProducer: &annotation.ProduceTrigger{
+						Annotation: annotation.DeepNilabilityAsNamedType(r.Pass().TypesInfo.Types[expr].Type),
+						Expr:       expr,
+					},
+				}}
+			}
 			_, rproducers := r.ParseExprAsProducer(expr.X, true)
 			return nil, parseDeepRead(nil, expr.X, expr, rproducers)
 		}
@@ -569,7 +572,7 @@ func (r *RootAssertionNode) getFuncReturnProducers(ident *ast.Ident, expr *ast.C
 
 		var fieldProducers []*annotation.ProduceTrigger
 
-		if r.functionContext.functionConfig.EnableStructInitCheck {
+		if r.functionContext.functionConfig.tracksParamAndReturnFields() {
 			fieldProducers = r.getFieldProducersForFuncReturns(funcObj, i)
 		}
 
@@ -23,6 +23,7 @@ import (
 	"go.uber.org/nilaway/annotation"
 	"go.uber.org/nilaway/guard"
 	"go.uber.org/nilaway/hook"
+	"go.uber.org/nilaway/util/analysishelper"
 	"go.uber.org/nilaway/util/asthelper"
 	"go.uber.org/nilaway/util/typeshelper"
 	"golang.org/x/tools/go/cfg"
@@ -153,6 +154,7 @@ func (f *FuncErrRetNonnilArg) equals(effect RichCheckEffect) bool {
 // Concrete examples of patterns supported are:
 // - map ok read: `v, ok := m[k]`
 // - channel ok receive: `v, ok := <-ch`
+// - type assertion ok: `v, ok := x.(T)`
 // - function ok return: `r0, r1, r2, ..., ok := f()`
 type okRead struct {
 	root  *RootAssertionNode // an associated root node
@@ -228,6 +230,20 @@ type FuncOkReturn struct {
 	okRead
 }
 
+// A TypeAssertOkRead is a RichCheckEffect for the `ok` in `v, ok := x.(T)` assignment, where `T` is
+// a nilable type (see isNilableTypeAssertion). To have the intended effect, an `if ok { }` must be
+// encountered before an assignment to either `v` or `ok`.
+type TypeAssertOkRead struct {
+	okRead
+}
+
+// A TypeAssertOkReadRefl indicates that a type assertion was encountered with a `v, ok := x.(T)`
+// assignment, and now if `ok` is checked it should produce non-nil for `x` because a nil interface
+// value fails all type assertions.
+type TypeAssertOkReadRefl struct {
+	okRead
+}
+
 // A RichCheckNoop is a placeholder instance of RichCheckEffect that functions as a total noop.
 // It is used to allow in place modification of collections of RichCheckEffects.
 type RichCheckNoop struct{}
@@ -282,10 +298,11 @@ func parseExpr(rootNode *RootAssertionNode, expr ast.Expr) TrackableExpr {
 	return parsed
 }
 
-// NodeTriggersOkRead is a case of a node creating a rich bool effect for map reads, channel receives, and user-defined
-// functions in the "ok" form. Specifically, it matches on `AssignStmt`s of the form
+// NodeTriggersOkRead is a case of a node creating a rich bool effect for map reads, channel receives, type assertions,
+// and user-defined functions in the "ok" form. Specifically, it matches on `AssignStmt`s of the form
 // - `v, ok := mp[k]`
 // - `v, ok := <-ch`
+// - `v, ok := x.(T)`
 // - `r0, r1, r2, ..., ok := f()`
 func NodeTriggersOkRead(rootNode *RootAssertionNode, nonceGenerator *guard.NonceGenerator, node ast.Node) ([]RichCheckEffect, bool) {
 	lhs, rhs := asthelper.ExtractLHSRHS(node)
@@ -384,6 +401,36 @@ func NodeTriggersOkRead(rootNode *RootAssertionNode, nonceGenerator *guard.Nonce
 					}})
 			}
 		}
+	case *ast.TypeAssertExpr:
+		// this is the case of `v, ok := x.(T)`. Early return if the lhs is not a type assertion of
+		// the expected format, or if it is a type switch (which has a nil `Type`)
+		if len(lhs) != 2 || rhs.Type == nil {
+			return nil, false
+		}
+
+		if isNilableTypeAssertion(rootNode.Pass(), rhs) {
+			if lhsValueParsed := parseExpr(rootNode, lhs[0]); lhsValueParsed != nil {
+				// here, the lhs `value` operand is trackable
+				effects = append(effects, &TypeAssertOkRead{
+					okRead{
+						root:  rootNode,
+						value: lhsValueParsed,
+						ok:    lhsOkParsed,
+						guard: nonceGenerator.Next(lhs[0]),
+					}})
+			}
+		}
+
+		if rhsInterfaceParsed := parseExpr(rootNode, rhs.X); rhsInterfaceParsed != nil {
+			// here, the rhs `interface` operand is trackable
+			effects = append(effects, &TypeAssertOkReadRefl{
+				okRead{
+					root:  rootNode,
+					value: rhsInterfaceParsed,
+					ok:    lhsOkParsed,
+					guard: nonceGenerator.Next(rhs.X),
+				}})
+		}
 	case *ast.CallExpr:
 		callIdent := asthelper.FuncIdentFromCallExpr(rhs)
 		if callIdent == nil {
@@ -421,6 +468,23 @@ func NodeTriggersOkRead(rootNode *RootAssertionNode, nonceGenerator *guard.Nonce
 	return nil, false
 }
 
+// isNilableTypeAssertion returns true if the type `T` asserted by `x.(T)` is a pointer, map, slice,
+// channel or function type, such that the value of a failed `v, ok := x.(T)` is nil.
+func isNilableTypeAssertion(pass *analysishelper.EnhancedPass, expr *ast.TypeAssertExpr) bool {
+	if expr.Type == nil {
+		return false
+	}
+	t := pass.TypesInfo.TypeOf(expr.Type)
+	if t == nil {
+		return false
+	}
+	switch t.Underlying().(type) {
+	case *types.Pointer, *types.Map, *types.Slice, *types.Chan, *types.Signature:
+		return true
+	}
+	return false
+}
+
 // NodeTriggersFuncErrRet is a case of a node creating a rich check effect.
 // it matches on calls to functions with error-returning types
 func NodeTriggersFuncErrRet(rootNode *RootAssertionNode, nonceGenerator *guard.NonceGenerator, node ast.Node) ([]RichCheckEffect, bool) {
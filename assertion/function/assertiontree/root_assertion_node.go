@@ -15,14 +15,19 @@
 package assertiontree
 
 import (
+	"cmp"
 	"fmt"
 	"go/ast"
 	"go/token"
 	"go/types"
+	"slices"
 
 	"go.uber.org/nilaway/annotation"
+	"go.uber.org/nilaway/assertion/anonymousfunc"
+	"go.uber.org/nilaway/assertion/global"
 	"go.uber.org/nilaway/config"
 	"go.uber.org/nilaway/guard"
+	"go.uber.org/nilaway/hook"
 	"go.uber.org/nilaway/util/analysishelper"
 	"go.uber.org/nilaway/util/asthelper"
 	"go.uber.org/nilaway/util/typeshelper"
@@ -158,14 +163,15 @@ func (r *RootAssertionNode) GetDeclaringIdent(obj types.Object) *ast.Ident {
 
 // ObjectOf is the same as [types.Info.ObjectOf], but if an identifier cannot be looked up (e.g.,
 // it is an artificial identifier we created to aid the analysis), we look up the internal backup
-// map instead. ObjectOf returns nil if and only if both attempts fail.
+// map instead. ObjectOf returns nil if and only if both attempts fail. Fields and methods of
+// instantiated generic types are canonicalized to the ones of the generic type.
 func (r *RootAssertionNode) ObjectOf(ident *ast.Ident) types.Object {
 	obj := r.Pass().TypesInfo.ObjectOf(ident)
 	if obj != nil {
-		return obj
+		return typeshelper.OriginObject(obj)
 	}
 	// check if ident points to an anonymous function literal
-	if funcLit := getFuncLitFromAssignment(ident); funcLit != nil {
+	if funcLit := anonymousfunc.FuncLitFromAssignment(ident); funcLit != nil {
 		if info, ok := r.functionContext.funcLitMap[funcLit]; ok {
 			return info.FakeFuncObj
 		}
@@ -181,7 +187,7 @@ func (r *RootAssertionNode) funcArgsFromCallExpr(expr *ast.CallExpr) []ast.Expr
 	if ident, ok := fun.(*ast.Ident); ok {
 		// if the declaration of the ident points to a function literal node,
 		// then update fun with the function literal node
-		if funcLit := getFuncLitFromAssignment(ident); funcLit != nil {
+		if funcLit := anonymousfunc.FuncLitFromAssignment(ident); funcLit != nil {
 			fun = funcLit
 		}
 	}
@@ -350,6 +356,63 @@ func (r *RootAssertionNode) AddProduction(producer *annotation.ProduceTrigger, d
 	detachFromParent(currNode, whichChild)
 }
 
+// addMaybeProduction is like AddProduction, but for a value that may or may not be produced for
+// producer.expr (e.g., by a function literal that the callee may not run). The consume triggers
+// under the expression are matched with the producer, but are also kept in the tree such that they
+// continue to flow to the value of the expression before the production.
+func (r *RootAssertionNode) addMaybeProduction(producer *annotation.ProduceTrigger) {
+	path, _ := r.ParseExprAsProducer(producer.Expr, false)
+	currNode, _ := r.lookupPath(path)
+	if currNode == nil {
+		return
+	}
+	r.triggerProductions(CopyNode(currNode), producer)
+}
+
+// addProductionsForUnsetGlobals handles the expressions in an `init()` function that may assign
+// the globals still unset at its entry (see FunctionContext.unsetGlobals): calls that may assign
+// any of them (see global.CallMayAssignGlobals, e.g., `setupLogger()`), and the address-taking
+// expressions `&g`, which may let the callees assign `g` (e.g., `json.Unmarshal(b, &g)`). Such
+// globals are produced with their annotations after the expression, such that only the reads
+// before any possible assignment are considered unassigned.
+func (r *RootAssertionNode) addProductionsForUnsetGlobals(expr ast.Expr) {
+	unset := r.functionContext.unsetGlobals
+	if len(unset) == 0 {
+		return
+	}
+
+	var vars []*types.Var
+	switch expr := expr.(type) {
+	case *ast.CallExpr:
+		if !global.CallMayAssignGlobals(r.Pass(), expr) {
+			return
+		}
+		for v := range unset {
+			vars = append(vars, v)
+		}
+		// Sort the globals for deterministic triggers.
+		slices.SortFunc(vars, func(a, b *types.Var) int { return cmp.Compare(a.Pos(), b.Pos()) })
+	case *ast.UnaryExpr:
+		if expr.Op != token.AND {
+			return
+		}
+		ident, ok := ast.Unparen(expr.X).(*ast.Ident)
+		if !ok {
+			return
+		}
+		if v, ok := r.ObjectOf(ident).(*types.Var); ok && unset[v] {
+			vars = append(vars, v)
+		}
+	}
+
+	for _, v := range vars {
+		r.AddProduction(&annotation.ProduceTrigger{
+			Annotation: globalVarRead(v),
+			Expr:       r.GetDeclaringIdent(v),
+		})
+	}
+}
+
 // triggerProductions takes a node (assumed to be attached to its parent) and matches any of its
 // consumeTriggers with the given produceTrigger, as well as matching any more deeply found consumeTriggers
 // with the default non-tracked produceTriggers of their consuming expressions. Direct children of the
@@ -592,6 +655,9 @@ func (r *RootAssertionNode) AddComputation(expr ast.Expr) {
 
 		r.AddComputation(expr.X)
 	case *ast.CallExpr:
+		// The productions happen after the call, so they must be added before the consumptions of
+		// the function and its arguments.
+		r.addProductionsForUnsetGlobals(expr)
 		r.AddComputation(expr.Fun)
 		exprArgs := r.funcArgsFromCallExpr(expr)
 		var consumeArg func(int, ast.Expr)
@@ -660,6 +726,11 @@ func (r *RootAssertionNode) AddComputation(expr ast.Expr) {
 						if handleArgFuncIdent(argFunc.Sel) {
 							return consumeArgNoop
 						}
+					case *ast.IndexExpr, *ast.IndexListExpr:
+						// explicitly instantiated generic function, e.g., `foo(bar[int]())`
+						if ident := asthelper.FuncIdentFromInstantiation(argFunc); ident == nil || handleArgFuncIdent(ident) {
+							return consumeArgNoop
+						}
 					default:
 						// application is anonymous - no annotations
 						// TODO implement
@@ -742,13 +813,28 @@ func (r *RootAssertionNode) AddComputation(expr ast.Expr) {
 			// so we can mark its arguments as consumed
 			consumeArg = consumeArgTrigger(r.ObjectOf(fun).(*types.Func))
 
-			if r.functionContext.functionConfig.EnableStructInitCheck {
+			if r.functionContext.functionConfig.tracksParamAndReturnFields() {
 				// Add Productions for struct field params
 				r.addProductionForFuncCallArgAndReceiverFields(expr, fun)
 
 				// Add Consumptions for struct field params
 				r.addConsumptionsForArgAndReceiverFields(expr, fun)
 			}
+		} else if funcValue := r.funcValueCallee(expr); funcValue != nil {
+			// here we have found a call to a function value, so we consume its arguments with the
+			// annotations of all its resolved targets, and connect the results of the targets to
+			// the call
+			targets := r.funcValueTargets(expr)
+			consumeArgs := make([]func(int, ast.Expr), 0, len(targets.Funcs))
+			for _, target := range targets.Funcs {
+				consumeArgs = append(consumeArgs, consumeArgTrigger(target))
+			}
+			consumeArg = func(i int, arg ast.Expr) {
+				for _, consume := range consumeArgs {
+					consume(i, arg)
+				}
+			}
+			r.addFuncValueResultTriggers(funcValue, expr, targets)
 		} else {
 			// here we have found either a builtin function like make or new,
 			// or a typecast like int(x) - in either case (at least for now), do nothing to try
@@ -760,6 +846,8 @@ func (r *RootAssertionNode) AddComputation(expr ast.Expr) {
 		// how to process consumption of this function's arguments (e.g. anonymous funcs) or if
 		// we already have, namely through the multiple consumption case above
 
+		r.addProductionsForClosureVars(expr)
+
 		for i, arg := range exprArgs {
 			consumeArg(i, arg) // if arguments are to a known-annotated function, consume with its annotations
 			r.AddComputation(arg)
@@ -800,6 +888,7 @@ func (r *RootAssertionNode) AddComputation(expr ast.Expr) {
 		//       		restricting support only for non-interfaces due to the challenges of secret nil for interfaces.)
 		//       - Out-of-scope flow:
 		//          - Check 5: consider the criteria satisfied to support optimistic default
+		//       - Protobuf getters (e.g., `req.GetUser()`), in or out of scope, are nil-safe (see hook.IsProtoGetter)
 		//
 		// - (2) Don't allow the expression X to be nilable by creating a FldAccess (ConsumeTriggerTautology) consumer for it.
 		//       This is default behavior which gets triggered if the above special case is not satisfied.
@@ -809,7 +898,11 @@ func (r *RootAssertionNode) AddComputation(expr ast.Expr) {
 			recv := funcObj.Type().(*types.Signature).Recv()
 			if typeshelper.IsPointer(recv.Type()) { // Check 2: receiver is an explicit or implicit pointer receiver
 				conf := r.Pass().ResultOf[config.Analyzer].(*config.Config)
-				if conf.IsPkgInScope(funcObj.Pkg()) { // Check 3: invoked method is in scope
+				if hook.IsProtoGetter(r.Pass(), expr) {
+					// The getters of protobuf messages are generated to handle nil receivers, but
+					// the generated code is usually excluded from analysis, so we trust them here.
+					allowNilable = true
+				} else if conf.IsPkgInScope(funcObj.Pkg()) { // Check 3: invoked method is in scope
 					// Here, `t` can only be of type interface, struct, or named, of which we only support for struct and named types.
 					if !typeshelper.IsDeeplyInterface(r.Pass().TypesInfo.TypeOf(expr.X)) { // Check 4: invoking expression (caller) is of a non-interface type (e.g., struct or named)
 						allowNilable = true
@@ -872,7 +965,16 @@ func (r *RootAssertionNode) AddComputation(expr ast.Expr) {
 		})
 		r.AddComputation(expr.X)
 	case *ast.TypeAssertExpr:
-		// doesn't need to be non-nil, but really should be
+		// a single-value type assertion `x.(T)` panics if `x` is nil, while the type switches
+		// `x.(type)` (with nil `Type`) and the "ok" form `v, ok := x.(T)` (which is handled in
+		// backpropAcrossAssignment) do not
+		if expr.Type != nil {
+			r.AddConsumption(&annotation.ConsumeTrigger{
+				Annotation: &annotation.TypeAssert{ConsumeTriggerTautology: &annotation.ConsumeTriggerTautology{}},
+				Expr:       expr.X,
+				Guards:     guard.NoGuards(),
+			})
+		}
 		r.AddComputation(expr.X)
 	case *ast.UnaryExpr:
 		// Note if expr.Op == token.ARROW it represents a channel receive (<-X), and we have:
@@ -880,9 +982,9 @@ func (r *RootAssertionNode) AddComputation(expr ast.Expr) {
 		// (2) A receive from a closed channel returns the zero value immediately.
 		// (1) falls out of scope of NilAway, and we have a lot of valid Go code that receives
 		// from nil channels (e.g., select statements with nilable channels). So we do not create
-		// consumer for the channel variable here. For (2), since we currently do not track the
-		// state of channels, we currently cannot support it either.
-		// TODO: rethink our strategy of handling channels (#192).
+		// consumer for the channel variable here. For (2), the received value is produced as
+		// nilable if the channel may have been closed in this function (see ParseExprAsProducer).
+		r.addProductionsForUnsetGlobals(expr)
 		r.AddComputation(expr.X)
 	case *ast.FuncLit:
 		// TODO: analyze the bodies of anonymous functions
@@ -904,7 +1006,7 @@ func getFuncIdent(expr *ast.CallExpr, fc *FunctionContext) *ast.Ident {
 		funcLit, _ = expr.Fun.(*ast.FuncLit)
 	} else {
 		// check if the declaration the ident points to a function literal node
-		funcLit = getFuncLitFromAssignment(ident)
+		funcLit = anonymousfunc.FuncLitFromAssignment(ident)
 	}
 
 	if funcLit != nil {
@@ -916,34 +1018,6 @@ func getFuncIdent(expr *ast.CallExpr, fc *FunctionContext) *ast.Ident {
 	return ident
 }
 
-// getFuncLitFromAssignment if the declaration of the ident is an assignment
-// statement and Rhs of the assignment is a call expression which represents an
-// anonymous function, returns the ident of the fake function declaration created
-// for that. Otherwise, return nil.
-func getFuncLitFromAssignment(ident *ast.Ident) *ast.FuncLit {
-	if ident == nil || ident.Obj == nil || ident.Obj.Decl == nil {
-		return nil
-	}
-
-	if assign, ok := ident.Obj.Decl.(*ast.AssignStmt); ok {
-		// TODO get the correct ident for many to one assignments
-		if len(assign.Lhs) != len(assign.Rhs) {
-			return nil
-		}
-
-		for i := range assign.Lhs {
-			if assign.Lhs[i].(*ast.Ident).Obj != ident.Obj {
-				continue
-			}
-			if rhs, ok := assign.Rhs[i].(*ast.FuncLit); ok {
-				return rhs
-			}
-		}
-	}
-
-	return nil
-}
-
 // LiftFromPath takes a `path` of assertion nodes, and searches for it in the assertion tree rooted
 // at `rootNode`. If found, it removes that tree and returns its root as `node`, with `ok` = true.
 // If not found, it returns `node`, `ok` = nil, false
@@ -1015,7 +1089,7 @@ func (r *RootAssertionNode) ProcessEntry() {
 		child := r.Children()[0]
 		builtExpr := child.BuildExpr(nil)
 
-		if r.functionContext.functionConfig.EnableStructInitCheck {
+		if r.functionContext.functionConfig.tracksParamAndReturnFields() {
 			// process field Assertion nodes of function parameters
 			r.addProductionsForParamFields(child, builtExpr)
 		}
@@ -59,6 +59,8 @@ func (r *RootAssertionNode) addProductionsForAssignmentFields(fieldProducers []*
 // that have nilable type
 // 2. If the expression gives field producers, we create full triggers for those producers with the
 // consumers of field return keys
+// With object sensitive fields, only the fields tracked by isResultFieldTracked are consumed, and the fields of other
+// expressions fall back to the nilability of the field declarations.
 func (r *RootAssertionNode) addConsumptionsForFieldsOfReturns(retExpr ast.Expr, retNum int) {
 	fdecl := r.FuncObj()
 
@@ -73,7 +75,7 @@ func (r *RootAssertionNode) addConsumptionsForFieldsOfReturns(retExpr ast.Expr,
 			for fieldID := 0; fieldID < numFields; fieldID++ {
 				fieldDecl := resType.Field(fieldID)
 
-				if typeshelper.TypeBarsNilness(fieldDecl.Type()) {
+				if !r.isResultFieldTracked(fdecl, retNum, fieldDecl) {
 					// We do not create field triggers for types that are not nilable
 					continue
 				}
@@ -86,13 +88,20 @@ func (r *RootAssertionNode) addConsumptionsForFieldsOfReturns(retExpr ast.Expr,
 				consumer := annotation.GetRetFldConsumer(retKey, selExpr)
 				r.AddConsumption(consumer)
 
-				// Also add escape consumer
-				escapeConsumer := annotation.GetEscapeFldConsumer(annotation.NewEscapeFldAnnKey(fieldDecl), selExpr)
-				r.AddConsumption(escapeConsumer)
+				if r.functionContext.functionConfig.EnableStructInitCheck {
+					// Also add escape consumer
+					escapeConsumer := annotation.GetEscapeFldConsumer(annotation.NewEscapeFldAnnKey(fieldDecl), selExpr)
+					r.AddConsumption(escapeConsumer)
+				}
 			}
 			return
 		}
 
+		if !r.functionContext.functionConfig.EnableStructInitCheck {
+			r.addFallbackConsumptionsForFieldsOfReturn(retExpr, retNum, resType)
+			return
+		}
+
 		// For the expressions that return field producers we create full triggers
 		_, producer := r.ParseExprAsProducer(retExpr, true)
 
@@ -122,6 +131,60 @@ func (r *RootAssertionNode) addConsumptionsForFieldsOfReturns(retExpr ast.Expr,
 	}
 }
 
+// addFallbackConsumptionsForFieldsOfReturn is called with object sensitive fields for a return expression `retExpr` that
+// is not a chain of field accesses (e.g., `return g()` or `return nil`). The tracked fields of the retNum-th result are
+// then consumed from the field producers of the expression if any, or from the field declarations otherwise.
+func (r *RootAssertionNode) addFallbackConsumptionsForFieldsOfReturn(retExpr ast.Expr, retNum int, resType *types.Struct) {
+	fdecl := r.FuncObj()
+
+	var fieldProducers []*annotation.ProduceTrigger
+	if _, producers := r.ParseExprAsProducer(retExpr, true); len(producers) != 0 {
+		fieldProducers = producers[0].GetFieldProducers()
+	}
+
+	for fieldIdx := 0; fieldIdx < resType.NumFields(); fieldIdx++ {
+		fieldDecl := resType.Field(fieldIdx)
+		if !r.isResultFieldTracked(fdecl, retNum, fieldDecl) {
+			continue
+		}
+
+		var fieldProducer *annotation.ProduceTrigger
+		if fieldIdx < len(fieldProducers) {
+			fieldProducer = fieldProducers[fieldIdx]
+		}
+		if fieldProducer == nil {
+			fieldProducer = &annotation.ProduceTrigger{
+				Annotation: &annotation.FldRead{
+					TriggerIfNilable: &annotation.TriggerIfNilable{
+						Ann: &annotation.FieldAnnotationKey{FieldDecl: fieldDecl},
+					},
+				},
+				Expr: retExpr,
+			}
+		}
+
+		r.AddNewTriggers(annotation.FullTrigger{
+			Producer: fieldProducer,
+			Consumer: annotation.GetRetFldConsumer(annotation.NewRetFldAnnKey(fdecl, retNum, fieldDecl), retExpr),
+		})
+	}
+}
+
+// isResultFieldTracked returns true if the nilability of field `fieldDecl` of the retNum-th result of function
+// `funcObj` is tracked via the return field annotation site. All nilable fields are tracked with the struct
+// initialization checking, while with object sensitive fields only the fields assigned on the returned variable (see
+// structfield.FieldContext) are tracked to keep the number of triggers small.
+func (r *RootAssertionNode) isResultFieldTracked(funcObj *types.Func, retNum int, fieldDecl *types.Var) bool {
+	if typeshelper.TypeBarsNilness(fieldDecl.Type()) {
+		return false
+	}
+	if r.functionContext.functionConfig.EnableStructInitCheck {
+		return true
+	}
+	result := r.Pass().ResultOf[structfield.Analyzer].(*analysishelper.Result[*structfield.FieldContext])
+	return result.Res.IsFieldAssignedForResult(funcObj, retNum, fieldDecl.Name())
+}
+
 // getFieldProducersForFuncReturns creates and returns producers for nilable-typed fields of the struct at return index retNum.
 // The method returns nil if the return at index retNum is not a struct. calledFuncDecl is the declaration of the function that
 // is called.
@@ -136,9 +199,9 @@ func (r *RootAssertionNode) getFieldProducersForFuncReturns(calledFuncDecl *type
 		for fieldID := 0; fieldID < numFields; fieldID++ {
 			fieldDecl := resType.Field(fieldID)
 
-			if typeshelper.TypeBarsNilness(fieldDecl.Type()) {
+			if !r.isResultFieldTracked(calledFuncDecl, retNum, fieldDecl) {
 				// We do not create field triggers for types that are not nilable
-				return nil
+				continue
 			}
 
 			retKey := annotation.NewRetFldAnnKey(calledFuncDecl, retNum, fieldDecl)
@@ -215,6 +278,7 @@ func (r *RootAssertionNode) addProductionsForParamFieldNode(selExpr *ast.Selecto
 								Ann: annotation.NewParamFldAnnKey(funcObj, index, node.decl)}},
 						Expr: selExpr,
 					})
+				r.addFallbackConsumptionForParamField(selExpr, funcObj, index, node.decl)
 			}
 			index++
 		}
@@ -237,10 +301,40 @@ func (r *RootAssertionNode) addProductionsForParamFieldNode(selExpr *ast.Selecto
 							Ann: annotation.NewParamFldAnnKey(funcObj, annotation.ReceiverParamIndex, node.decl)}},
 					Expr: selExpr,
 				})
+			r.addFallbackConsumptionForParamField(selExpr, funcObj, annotation.ReceiverParamIndex, node.decl)
 		}
 	}
 }
 
+// addFallbackConsumptionForParamField is called with object sensitive fields when a field of a param (or receiver) is
+// produced at the entry of the function. The callers that we do not see (e.g., from other packages or via function
+// values) do not consume the param field site, hence the nilability of the field declaration flows to it.
+func (r *RootAssertionNode) addFallbackConsumptionForParamField(selExpr *ast.SelectorExpr, funcObj *types.Func, paramIdx int, fieldDecl *types.Var) {
+	if !r.functionContext.functionConfig.EnableObjectSensitiveFields {
+		return
+	}
+
+	r.AddNewTriggers(annotation.FullTrigger{
+		Producer: &annotation.ProduceTrigger{
+			Annotation: &annotation.FldRead{
+				TriggerIfNilable: &annotation.TriggerIfNilable{
+					Ann: &annotation.FieldAnnotationKey{FieldDecl: fieldDecl},
+				},
+			},
+			Expr: selExpr,
+		},
+		Consumer: &annotation.ConsumeTrigger{
+			Annotation: &annotation.ArgFldPass{
+				TriggerIfNonNil: &annotation.TriggerIfNonNil{
+					Ann: annotation.NewParamFldAnnKey(funcObj, paramIdx, fieldDecl),
+				},
+			},
+			Expr:   selExpr,
+			Guards: guard.NoGuards(),
+		},
+	})
+}
+
 // addConsumptionsForArgAndReceiverFields adds consumptions for fields of receivers and arguments of a function call. It takes
 // the call expression and adds consumptions for each param and receiver by calling addConsumptionsForArgFields and
 // addConsumptionsForReceiverFields respectively
@@ -313,9 +407,11 @@ func (r *RootAssertionNode) addConsumptionsForArgFieldsAtIndex(arg ast.Expr, fun
 							Expr:   selExpr})
 				}
 
-				// Also add escape consumer
-				escapeConsumer := annotation.GetEscapeFldConsumer(annotation.NewEscapeFldAnnKey(fieldDecl), selExpr)
-				r.AddConsumption(escapeConsumer)
+				if r.functionContext.functionConfig.EnableStructInitCheck {
+					// Also add escape consumer
+					escapeConsumer := annotation.GetEscapeFldConsumer(annotation.NewEscapeFldAnnKey(fieldDecl), selExpr)
+					r.AddConsumption(escapeConsumer)
+				}
 			}
 			return
 		}
@@ -345,9 +441,11 @@ func (r *RootAssertionNode) addConsumptionsForArgFieldsAtIndex(arg ast.Expr, fun
 				Consumer: consumer,
 			})
 
-			// add escape trigger
-			// TODO: Do not call this for list of special functions
-			r.addEscapeFullTrigger(arg, paramType, fieldIdx, fieldProducer)
+			if r.functionContext.functionConfig.EnableStructInitCheck {
+				// add escape trigger
+				// TODO: Do not call this for list of special functions
+				r.addEscapeFullTrigger(arg, paramType, fieldIdx, fieldProducer)
+			}
 		}
 	}
 }
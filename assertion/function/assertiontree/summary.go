@@ -0,0 +1,81 @@
+//  Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package assertiontree
+
+import (
+	"go/ast"
+	"slices"
+
+	"go.uber.org/nilaway/annotation"
+	"go.uber.org/nilaway/guard"
+)
+
+// SummarizeFuncReturns is a cheap fallback for BackpropAcrossFunc on functions that are too large
+// to be fully analyzed. Instead of backpropagating across the CFG, it considers each return
+// statement in isolation, and only keeps the triggers that connect the returned expressions whose
+// nilability is known locally (e.g., nil literals, composite literals or calls to other
+// functions) to the result sites of the function. The returned variables (or fields) are not
+// tracked since that requires the flow analysis, hence their nilability is left undetermined.
+// This way, callers of the function still get useful facts about its results, while the nil
+// flows within the function are not checked.
+func SummarizeFuncReturns(functionContext FunctionContext) ([]annotation.FullTrigger, error) {
+	var (
+		triggers []annotation.FullTrigger
+		err      error
+	)
+	ast.Inspect(functionContext.funcDecl.Body, func(node ast.Node) bool {
+		if err != nil {
+			return false
+		}
+		switch node := node.(type) {
+		case *ast.FuncLit:
+			// The returns of function literals are not the returns of the function.
+			return false
+		case *ast.ReturnStmt:
+			rootNode := newRootAssertionNode(make(guard.ExprNonceMap), functionContext)
+			if err = backpropAcrossReturn(rootNode, node); err != nil {
+				return false
+			}
+			triggers = append(triggers, summarizeReturnTriggers(node, rootNode.triggers)...)
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	return triggers, nil
+}
+
+// summarizeReturnTriggers returns the triggers of the given return statement whose consumers are
+// the returned expressions. The triggers for the non-error results whose nilability depends on an
+// error result of unknown nilability are only kept if the trigger for the error result is also
+// present, since otherwise the error contract cannot be applied to them (see
+// FilterTriggersForErrorReturn).
+func summarizeReturnTriggers(node *ast.ReturnStmt, triggers []annotation.FullTrigger) []annotation.FullTrigger {
+	triggers = slices.DeleteFunc(triggers, func(t annotation.FullTrigger) bool {
+		return !slices.Contains(node.Results, t.Consumer.Expr)
+	})
+	hasErrRetTrigger := slices.ContainsFunc(triggers, func(t annotation.FullTrigger) bool {
+		_, ok := t.Consumer.Annotation.(*annotation.UseAsErrorRetWithNilabilityUnknown)
+		return ok
+	})
+	if hasErrRetTrigger {
+		return triggers
+	}
+	return slices.DeleteFunc(triggers, func(t annotation.FullTrigger) bool {
+		_, ok := t.Consumer.Annotation.(*annotation.UseAsNonErrorRetDependentOnErrorRetNilability)
+		return ok
+	})
+}
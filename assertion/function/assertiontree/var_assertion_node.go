@@ -52,10 +52,13 @@ func (v *varAssertionNode) DefaultTrigger() annotation.ProducingAnnotationTrigge
 		}
 	}
 	if annotation.VarIsGlobal(v.decl) {
-		return &annotation.GlobalVarRead{
-			TriggerIfNilable: &annotation.TriggerIfNilable{
-				Ann: &annotation.GlobalVarAnnotationKey{
-					VarDecl: v.decl}}}
+		// In an `init()` function, the globals not yet assigned by the package initialization
+		// are still nil. The ones that may be assigned before the read are produced with their
+		// annotations at the possible assignments instead (see addProductionsForUnsetGlobals).
+		if v.Root().functionContext.unsetGlobals[v.decl] {
+			return &annotation.NoVarAssign{ProduceTriggerTautology: &annotation.ProduceTriggerTautology{}, VarObj: v.decl}
+		}
+		return globalVarRead(v.decl)
 	}
 
 	// By process of elimination we know that here `v` is a local variable
@@ -76,6 +79,15 @@ func (v *varAssertionNode) DefaultTrigger() annotation.ProducingAnnotationTrigge
 	return &annotation.NoVarAssign{ProduceTriggerTautology: &annotation.ProduceTriggerTautology{}, VarObj: v.decl}
 }
 
+// globalVarRead returns the producer for a read of the given global variable, whose nilability is
+// determined by its annotation.
+func globalVarRead(v *types.Var) *annotation.GlobalVarRead {
+	return &annotation.GlobalVarRead{
+		TriggerIfNilable: &annotation.TriggerIfNilable{
+			Ann: &annotation.GlobalVarAnnotationKey{
+				VarDecl: v}}}
+}
+
 // BuildExpr for a varAssertionNode returns the underlying variable's AST node
 func (v *varAssertionNode) BuildExpr(_ ast.Expr) ast.Expr {
 	if v.Root() == nil {
@@ -18,12 +18,15 @@
 package functioncontracts
 
 import (
+	"cmp"
 	"errors"
 	"fmt"
 	"go/ast"
+	"go/token"
 	"go/types"
 	"reflect"
 	"runtime/debug"
+	"slices"
 	"sync"
 
 	"go.uber.org/nilaway/config"
@@ -37,12 +40,12 @@ import (
 const _doc = "Read the contracts of each function in this package, returning the results."
 
 // Analyzer here is the analyzer than reads function contracts. It returns the map generated from
-// reading the function contracts in the source code.
+// reading the function contracts in the source code, along with the malformed contracts.
 var Analyzer = &analysis.Analyzer{
 	Name:       "nilaway_function_contracts_analyzer",
 	Doc:        _doc,
 	Run:        analysishelper.WrapRun(run),
-	ResultType: reflect.TypeOf((*analysishelper.Result[Map])(nil)),
+	ResultType: reflect.TypeOf((*analysishelper.Result[Result])(nil)),
 	FactTypes:  []analysis.Fact{new(Contracts)},
 	Requires:   []*analysis.Analyzer{config.Analyzer, buildssa.Analyzer},
 }
@@ -56,17 +59,52 @@ func (*Contracts) AFact() {}
 // Map stores the mappings from *types.Func to associated function contracts.
 type Map map[*types.Func]Contracts
 
-func run(p *analysis.Pass) (Map, error) {
+// InvalidContract is a handwritten contract that cannot be parsed or does not match the signature
+// of its function. It is ignored by the analysis and reported as a diagnostic instead.
+type InvalidContract struct {
+	// Pos is the position of the contract in the comment.
+	Pos token.Pos
+	// Text is the text of the contract, i.e., `contract(...)`.
+	Text string
+	// Reason describes why the contract is invalid.
+	Reason string
+}
+
+// ViolatedContract is a handwritten contract that is violated by a return path of its function. It
+// is still respected by the analysis, but reported as a diagnostic such that it can be fixed.
+type ViolatedContract struct {
+	// Pos is the position of the contract in the comment.
+	Pos token.Pos
+	// Text is the text of the contract, i.e., `contract(...)`.
+	Text string
+	// ReturnPos is the position of the return statement violating the contract.
+	ReturnPos token.Pos
+	// Reason describes the violating path, e.g., which result is nil under which conditions.
+	Reason string
+}
+
+// Result is the result of the function contracts analyzer.
+type Result struct {
+	// Contracts stores the handwritten and inferred contracts of the functions.
+	Contracts Map
+	// Invalid stores the malformed handwritten contracts in the current package.
+	Invalid []InvalidContract
+	// Violated stores the handwritten contracts in the current package that are violated by the
+	// bodies of their functions.
+	Violated []ViolatedContract
+}
+
+func run(p *analysis.Pass) (Result, error) {
 	pass := analysishelper.NewEnhancedPass(p)
 	conf := pass.ResultOf[config.Analyzer].(*config.Config)
 	if !conf.IsPkgInScope(pass.Pkg) {
-		return make(Map), nil
+		return Result{Contracts: make(Map)}, nil
 	}
 
 	// Collect contracts from the current package.
-	contracts, err := collectFunctionContracts(pass)
+	contracts, invalid, violated, err := collectFunctionContracts(pass)
 	if err != nil {
-		return nil, err
+		return Result{}, err
 	}
 
 	// The fact mechanism only allows exporting pointer types. However, internally we are using
@@ -89,7 +127,7 @@ func run(p *analysis.Pass) (Map, error) {
 		// The existing contracts are imported from upstream packages about upstream functions,
 		// therefore there should not be any conflicts with contracts collected from the current package.
 		if _, ok := contracts[fn]; ok {
-			return nil, fmt.Errorf("function %s has multiple contracts", fn.Name())
+			return Result{}, fmt.Errorf("function %s has multiple contracts", fn.Name())
 		}
 		contracts[fn] = *ctrts
 	}
@@ -109,21 +147,57 @@ func run(p *analysis.Pass) (Map, error) {
 			pass.ExportObjectFact(fn, &ctrts)
 		}
 	}
-	return contracts, nil
+	return Result{Contracts: contracts, Invalid: invalid, Violated: violated}, nil
+}
+
+// canInferContracts returns true if a function with the given signature may have contracts to
+// infer, i.e., it has a nilable or boolean result, and a nilable parameter (see isInferableParam)
+// or a guard result (see GuardResultIndex) that the result can be conditioned on. We do not infer
+// contracts for error-returning functions, whose results are already handled by the analysis of
+// error returns.
+func canInferContracts(sig *types.Signature) bool {
+	if typeshelper.FuncIsErrReturning(sig) {
+		return false
+	}
+	hasResult := false
+	for i := 0; i < sig.Results().Len(); i++ {
+		typ := sig.Results().At(i).Type()
+		if i == GuardResultIndex(sig) {
+			continue
+		}
+		if !typeshelper.TypeBarsNilness(typ) || types.Identical(typ, typeshelper.BoolType) {
+			hasResult = true
+		}
+	}
+	if !hasResult {
+		return false
+	}
+	if GuardResultIndex(sig) >= 0 {
+		return true
+	}
+	for i := 0; i < sig.Params().Len(); i++ {
+		if isInferableParam(sig, i) {
+			return true
+		}
+	}
+	return false
 }
 
 // functionResult is the struct that is received from the channel for each function.
 type functionResult struct {
 	funcObj   *types.Func
 	contracts Contracts
+	violated  []ViolatedContract
 	err       error
 }
 
 // collectFunctionContracts collects all the function contracts and returns a map that associates
-// every function with its contracts if it has any. We prefer to parse handwritten contracts from
-// the comments at the top of each function. Only when there are no handwritten contracts there,
-// do we try to automatically infer contracts.
-func collectFunctionContracts(pass *analysishelper.EnhancedPass) (Map, error) {
+// every function with its contracts if it has any, along with the malformed handwritten contracts
+// and the handwritten contracts violated by their functions. We prefer to parse handwritten
+// contracts from the comments at the top of each function, and verify them against the function
+// body. Only when there are no handwritten contracts there (valid or not), do we try to
+// automatically infer contracts.
+func collectFunctionContracts(pass *analysishelper.EnhancedPass) (Map, []InvalidContract, []ViolatedContract, error) {
 	conf := pass.ResultOf[config.Analyzer].(*config.Config)
 
 	// Collect ssa for every function.
@@ -143,8 +217,22 @@ func collectFunctionContracts(pass *analysishelper.EnhancedPass) (Map, error) {
 	// Set up variables for synchronization and communication.
 	var wg sync.WaitGroup
 	funcChan := make(chan functionResult)
+	// analyze runs the analysis of a function in a separate goroutine and sends the result back.
+	analyze := func(funcObj *types.Func, f func() functionResult) {
+		wg.Go(func() {
+			// As a last resort, convert the panics into errors and return.
+			defer func() {
+				if r := recover(); r != nil {
+					e := fmt.Errorf("INTERNAL PANIC: %s\n%s", r, string(debug.Stack()))
+					funcChan <- functionResult{err: e, funcObj: funcObj}
+				}
+			}()
+			funcChan <- f()
+		})
+	}
 
 	m := Map{}
+	var invalid []InvalidContract
 	for _, file := range pass.Files {
 		if !conf.IsFileInScope(file) {
 			continue
@@ -160,30 +248,31 @@ func collectFunctionContracts(pass *analysishelper.EnhancedPass) (Map, error) {
 			funcObj := pass.TypesInfo.ObjectOf(funcDecl.Name).(*types.Func)
 
 			// First, we try to parse the contracts from the comments at the top of the function.
-			// If there are any, we do not need to infer contracts for this function.
-			if parsedContracts := parseContracts(funcDecl.Doc); len(parsedContracts) != 0 {
-				m[funcObj] = parsedContracts
+			// If there are any, we do not need to infer contracts for this function, but we
+			// verify them against the function body since a wrong contract makes the analysis
+			// unsound.
+			parsedContracts, invalidContracts := parseContracts(funcDecl.Doc, funcObj.Signature())
+			invalid = append(invalid, invalidContracts...)
+			if len(parsedContracts) != 0 {
+				contracts := make(Contracts, 0, len(parsedContracts))
+				for _, c := range parsedContracts {
+					contracts = append(contracts, c.Contract)
+				}
+				m[funcObj] = contracts
+
+				if fnssa, ok := ssaOfFunc[funcObj]; ok && len(fnssa.Blocks) != 0 {
+					analyze(funcObj, func() functionResult {
+						return functionResult{funcObj: funcObj, violated: verifyContracts(fnssa, parsedContracts)}
+					})
+				}
+			}
+			if len(parsedContracts) != 0 || len(invalidContracts) != 0 {
 				continue
 			}
 
 			// If we reach here, it means that there are no handwritten contracts for this
 			// function. We need to infer contracts for this function.
-			if funcDecl.Type.Params.NumFields() != 1 ||
-				funcDecl.Type.Results.NumFields() != 1 ||
-				typeshelper.TypeBarsNilness(funcObj.Type().(*types.Signature).Params().At(0).Type()) ||
-				typeshelper.TypeBarsNilness(funcObj.Type().(*types.Signature).Results().At(0).Type()) ||
-				funcObj.Type().(*types.Signature).Variadic() {
-				// We definitely want to ignore any function without any parameters or return
-				// values since they cannot have any contracts.
-
-				// TODO: However, we want to analyze for multiple param/return in the future; for
-				//  now we consider contract(nonnil->nonnil) only.
-
-				// TODO: If the function has only one parameter and the parameter is variadic, then
-				//  it may happen that no argument is passed when calling the function. Such cases
-				//  are not handled well when duplicating full triggers from contracted functions,
-				//  so we don't infer contract(nonnil->nonnil) for such a function although we can
-				//  already.
+			if !canInferContracts(funcObj.Signature()) {
 				continue
 			}
 			fnssa, ok := ssaOfFunc[funcObj]
@@ -200,21 +289,8 @@ func collectFunctionContracts(pass *analysishelper.EnhancedPass) (Map, error) {
 			}
 
 			// Infer contracts for a function that does not have any contracts specified.
-			wg.Go(func() {
-				// As a last resort, convert the panics into errors and return.
-				defer func() {
-					if r := recover(); r != nil {
-						e := fmt.Errorf("INTERNAL PANIC: %s\n%s", r, string(debug.Stack()))
-						funcChan <- functionResult{err: e, funcObj: funcObj}
-					}
-				}()
-
-				if contracts := inferContracts(fnssa); len(contracts) != 0 {
-					funcChan <- functionResult{
-						funcObj:   funcObj,
-						contracts: contracts,
-					}
-				}
+			analyze(funcObj, func() functionResult {
+				return functionResult{funcObj: funcObj, contracts: inferContracts(fnssa)}
 			})
 		}
 	}
@@ -226,12 +302,21 @@ func collectFunctionContracts(pass *analysishelper.EnhancedPass) (Map, error) {
 		close(funcChan)
 	}()
 
-	// Collect inferred contracts from the channel.
-	var err error
+	// Collect inferred contracts and violated handwritten contracts from the channel.
+	var (
+		violated []ViolatedContract
+		err      error
+	)
 	for r := range funcChan {
-		m[r.funcObj] = r.contracts
+		if len(r.contracts) != 0 {
+			m[r.funcObj] = r.contracts
+		}
+		violated = append(violated, r.violated...)
 		err = errors.Join(err, r.err)
 	}
+	// The results are received in a nondeterministic order, so we sort the violated contracts to
+	// keep the diagnostics stable.
+	slices.SortFunc(violated, func(a, b ViolatedContract) int { return cmp.Compare(a.Pos, b.Pos) })
 
-	return m, err
+	return m, invalid, violated, err
 }
@@ -35,7 +35,7 @@ func TestAnalyzer(t *testing.T) {
 	// and convert it to an error via the result struct.
 	r, err := Analyzer.Run(nil /* pass */)
 	require.NoError(t, err)
-	require.ErrorContains(t, r.(*analysishelper.Result[Map]).Err, "INTERNAL PANIC")
+	require.ErrorContains(t, r.(*analysishelper.Result[Result]).Err, "INTERNAL PANIC")
 }
 
 func TestContractCollection(t *testing.T) {
@@ -48,15 +48,18 @@ func TestContractCollection(t *testing.T) {
 	require.NotNil(t, r[0])
 
 	pass, result := r[0].Pass, r[0].Result
-	require.IsType(t, &analysishelper.Result[Map]{}, result)
-	funcContractsMap := result.(*analysishelper.Result[Map]).Res
-	require.NoError(t, result.(*analysishelper.Result[Map]).Err)
+	require.IsType(t, &analysishelper.Result[Result]{}, result)
+	funcContractsMap := result.(*analysishelper.Result[Result]).Res.Contracts
+	require.NoError(t, result.(*analysishelper.Result[Result]).Err)
 
 	require.NotNil(t, funcContractsMap)
 
 	actual := make(Map)
 	for funcObj, contracts := range funcContractsMap {
-		actual[funcObj] = contracts
+		// Skip the contracts imported from the dependencies (e.g., the standard library).
+		if funcObj.Pkg() == pass.Pkg {
+			actual[funcObj] = contracts
+		}
 	}
 
 	expected := Map{
@@ -76,12 +79,82 @@ func TestContractCollection(t *testing.T) {
 			Contract{Ins: []ContractVal{Any, NonNil}, Outs: []ContractVal{NonNil, True}},
 			Contract{Ins: []ContractVal{NonNil, Any}, Outs: []ContractVal{NonNil, True}},
 		},
+		getFuncObj(pass, "nilToNil"): {
+			Contract{Ins: []ContractVal{Nil}, Outs: []ContractVal{Nil}},
+			Contract{Ins: []ContractVal{NonNil}, Outs: []ContractVal{NonNil}},
+		},
+		getFuncObj(pass, "errorAware"): {
+			Contract{Ins: []ContractVal{Any}, Outs: []ContractVal{NonNil, Nil}},
+		},
+		getFuncObj(pass, "multipleParams"): {
+			Contract{Ins: []ContractVal{NonNil, Any}, Outs: []ContractVal{True}},
+		},
+		getFuncObj(pass, "multipleContractsInOneLine"): {
+			Contract{Ins: []ContractVal{NonNil}, Outs: []ContractVal{NonNil}},
+			Contract{Ins: []ContractVal{Nil}, Outs: []ContractVal{Nil}},
+		},
+		getFuncObj(pass, "partiallyValid"): {
+			Contract{Ins: []ContractVal{NonNil}, Outs: []ContractVal{NonNil}},
+		},
+		getFuncObj(pass, "genericIdentity"): {
+			Contract{Ins: []ContractVal{NonNil}, Outs: []ContractVal{NonNil}},
+		},
+		getFuncObj(pass, "genericPointer"): {
+			Contract{Ins: []ContractVal{Nil}, Outs: []ContractVal{Nil}},
+		},
 		// function contractCommentInOtherLine should not exist in the map as it has no contract.
+		// functions unknownKeyword, mismatchedParams, mismatchedType and genericNonNilable should
+		// not exist in the map either as their contracts are invalid (and no contracts are inferred for them).
 	}
 	if diff := cmp.Diff(expected, actual); diff != "" {
 		require.Fail(t, fmt.Sprintf("parsed contracts mismatch (-want +got):\n%s", diff))
 	}
+
+	var invalid []string
+	for _, c := range result.(*analysishelper.Result[Result]).Res.Invalid {
+		invalid = append(invalid, fmt.Sprintf("%d: %s: %s", pass.Fset.Position(c.Pos).Line, c.Text, c.Reason))
+	}
+	expectedInvalid := []string{
+		`97: contract(nonil -> nonnil): unknown keyword "nonil", expected one of [nonnil nil true false _]`,
+		`102: contract(nonnil, nonnil -> nonnil): expected 1 input value(s) for the parameters, got 2`,
+		`107: contract(nonnil -> true): parameter 0: "nonnil" does not apply to non-nilable type int`,
+		`113: contract(nonnil => nonnil): expected exactly one "->" between the inputs and the outputs`,
+		`128: contract(nonnil -> nonnil): parameter 0: "nonnil" does not apply to non-nilable type T`,
+	}
+	if diff := cmp.Diff(expectedInvalid, invalid); diff != "" {
+		require.Fail(t, fmt.Sprintf("invalid contracts mismatch (-want +got):\n%s", diff))
+	}
+}
+func TestContractVerification(t *testing.T) {
+	t.Parallel()
+
+	testdata := analysistest.TestData()
+	r := analysistest.Run(t, testdata, Analyzer, "go.uber.org/verify")
+	require.Len(t, r, 1)
+	pass, result := r[0].Pass, r[0].Result
+	require.IsType(t, &analysishelper.Result[Result]{}, result)
+	require.NoError(t, result.(*analysishelper.Result[Result]).Err)
+
+	var violated []string
+	for _, c := range result.(*analysishelper.Result[Result]).Res.Violated {
+		violated = append(violated, fmt.Sprintf("%d: %s: line %d: %s",
+			pass.Fset.Position(c.Pos).Line, c.Text, pass.Fset.Position(c.ReturnPos).Line, c.Reason))
+	}
+	expected := []string{
+		"27: contract(nonnil -> nonnil): line 30: result 0 is nil while parameter `x` is nonnil",
+		"35: contract(nonnil -> nil): line 37: result 0 is nonnil while parameter `x` is nonnil",
+		"40: contract(nonnil -> nonnil): line 45: result 0 is nil while parameter `x` is nonnil",
+		"48: contract(_ -> nonnil, nil): line 54: result 0 is nil while result 1 is nil",
+		"67: contract(nil -> false): line 70: result 0 is true while parameter `x` is nil",
+	}
+	if diff := cmp.Diff(expected, violated); diff != "" {
+		require.Fail(t, fmt.Sprintf("violated contracts mismatch (-want +got):\n%s", diff))
+	}
+
+	// The violated contracts are still respected by the analysis.
+	require.Len(t, result.(*analysishelper.Result[Result]).Res.Contracts[getFuncObj(pass, "nilOnNonnilPath")], 1)
 }
+
 func TestInfer(t *testing.T) {
 	t.Parallel()
 
@@ -92,41 +165,80 @@ func TestInfer(t *testing.T) {
 	require.NotNil(t, r[0])
 
 	pass, result := r[0].Pass, r[0].Result
-	require.IsType(t, &analysishelper.Result[Map]{}, result)
-	funcContractsMap := result.(*analysishelper.Result[Map]).Res
-	require.NoError(t, result.(*analysishelper.Result[Map]).Err)
+	require.IsType(t, &analysishelper.Result[Result]{}, result)
+	funcContractsMap := result.(*analysishelper.Result[Result]).Res.Contracts
+	require.NoError(t, result.(*analysishelper.Result[Result]).Err)
 
 	require.NotNil(t, funcContractsMap)
 
 	actual := make(Map)
 	for funcObj, contracts := range funcContractsMap {
-		actual[funcObj] = contracts
+		// Skip the contracts imported from the dependencies (e.g., the standard library).
+		if funcObj.Pkg() == pass.Pkg {
+			actual[funcObj] = contracts
+		}
 	}
 
 	expected := Map{
 		getFuncObj(pass, "onlyLocalVar"): {
 			Contract{Ins: []ContractVal{NonNil}, Outs: []ContractVal{NonNil}},
+			Contract{Ins: []ContractVal{Nil}, Outs: []ContractVal{Nil}},
 		},
 		getFuncObj(pass, "unknownCondition"): {
 			Contract{Ins: []ContractVal{NonNil}, Outs: []ContractVal{NonNil}},
+			Contract{Ins: []ContractVal{Nil}, Outs: []ContractVal{Nil}},
 		},
 		getFuncObj(pass, "noLocalVar"): {
 			Contract{Ins: []ContractVal{NonNil}, Outs: []ContractVal{NonNil}},
 		},
+		getFuncObj(pass, "nonnilToNil"): {
+			Contract{Ins: []ContractVal{NonNil}, Outs: []ContractVal{Nil}},
+			Contract{Ins: []ContractVal{Nil}, Outs: []ContractVal{NonNil}},
+		},
+		getFuncObj(pass, "nonnilToUnknown"): {
+			Contract{Ins: []ContractVal{Nil}, Outs: []ContractVal{Nil}},
+		},
+		getFuncObj(pass, "field"): {
+			Contract{Ins: []ContractVal{Nil}, Outs: []ContractVal{Nil}},
+		},
 		getFuncObj(pass, "learnUnderlyingFromOuterMakeInterface"): {
 			Contract{Ins: []ContractVal{NonNil}, Outs: []ContractVal{NonNil}},
+			Contract{Ins: []ContractVal{Nil}, Outs: []ContractVal{Nil}},
 		},
 		getFuncObj(pass, "twoCondsMerge"): {
 			Contract{Ins: []ContractVal{NonNil}, Outs: []ContractVal{NonNil}},
+			Contract{Ins: []ContractVal{Nil}, Outs: []ContractVal{Nil}},
 		},
 		getFuncObj(pass, "unknownToUnknownButSameValue"): {
 			Contract{Ins: []ContractVal{NonNil}, Outs: []ContractVal{NonNil}},
+			Contract{Ins: []ContractVal{Nil}, Outs: []ContractVal{Nil}},
 		},
 		getFuncObj(pass, "SI2.cloneLike"): {
 			Contract{Ins: []ContractVal{NonNil}, Outs: []ContractVal{NonNil}},
+			Contract{Ins: []ContractVal{Nil}, Outs: []ContractVal{Nil}},
+		},
+		getFuncObj(pass, "firstNonnil"): {
+			Contract{Ins: []ContractVal{NonNil, Any}, Outs: []ContractVal{NonNil}},
+			Contract{Ins: []ContractVal{Any, NonNil}, Outs: []ContractVal{NonNil}},
+		},
+		getFuncObj(pass, "join"): {
+			Contract{Ins: []ContractVal{Nil, Any}, Outs: []ContractVal{Nil}},
+			Contract{Ins: []ContractVal{Any, Nil}, Outs: []ContractVal{Nil}},
+			Contract{Ins: []ContractVal{NonNil, NonNil}, Outs: []ContractVal{NonNil}},
 		},
-		// other functions should not exist in the map as the contract nonnil->nonnil does not hold
-		// for them.
+		getFuncObj(pass, "lookup"): {
+			Contract{Ins: []ContractVal{Any}, Outs: []ContractVal{NonNil, True}},
+		},
+		getFuncObj(pass, "scale"): {
+			Contract{Ins: []ContractVal{NonNil, Any}, Outs: []ContractVal{NonNil}},
+			Contract{Ins: []ContractVal{Nil, Any}, Outs: []ContractVal{Nil}},
+		},
+		getFuncObj(pass, "isPositive"): {
+			Contract{Ins: []ContractVal{Nil}, Outs: []ContractVal{False}},
+		},
+		// other functions should not exist in the map as no contract holds for them (or the
+		// contracts hold regardless of the arguments, e.g., for alwaysReturnNonnil, or the
+		// functions are error-returning, e.g., load and parse).
 
 		// TODO: uncomment this when we support field access when inferring contracts.
 		// getFuncObj(pass, "field"): {
@@ -161,9 +273,9 @@ func TestFactImport(t *testing.T) {
 	r := analysistest.Run(t, testdata, Analyzer, "go.uber.org/factexport/downstream")
 	require.Len(t, r, 1)
 	pass, result := r[0].Pass, r[0].Result
-	require.IsType(t, &analysishelper.Result[Map]{}, result)
-	require.NoError(t, result.(*analysishelper.Result[Map]).Err)
-	actual := result.(*analysishelper.Result[Map]).Res
+	require.IsType(t, &analysishelper.Result[Result]{}, result)
+	require.NoError(t, result.(*analysishelper.Result[Result]).Err)
+	actual := result.(*analysishelper.Result[Result]).Res.Contracts
 
 	expected := Map{
 		getFuncObj(pass, "localManual"): {
@@ -174,6 +286,10 @@ func TestFactImport(t *testing.T) {
 		},
 		getFuncObj(pass, "upstream.ExportedInferred"): {
 			Contract{Ins: []ContractVal{NonNil}, Outs: []ContractVal{NonNil}},
+			Contract{Ins: []ContractVal{Nil}, Outs: []ContractVal{Nil}},
+		},
+		getFuncObj(pass, "upstream.ExportedLookup"): {
+			Contract{Ins: []ContractVal{Any}, Outs: []ContractVal{NonNil, True}},
 		},
 	}
 	if diff := cmp.Diff(expected, actual); diff != "" {
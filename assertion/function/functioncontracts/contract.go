@@ -14,12 +14,20 @@
 
 package functioncontracts
 
+import (
+	"go/types"
+
+	"go.uber.org/nilaway/util/typeshelper"
+)
+
 // ContractVal represents the possible value appearing in a function contract.
 type ContractVal string
 
 const (
 	// NonNil has keyword "nonnil".
 	NonNil ContractVal = "nonnil"
+	// Nil has keyword "nil".
+	Nil ContractVal = "nil"
 	// False has keyword "false".
 	False ContractVal = "false"
 	// True has keyword "true".
@@ -28,29 +36,37 @@ const (
 	Any ContractVal = "_"
 )
 
-// newContractVal converts a keyword string into the corresponding function ContractVal.
-func newContractVal(keyword string) ContractVal {
-	switch keyword {
-	case "nonnil":
-		return NonNil
-	case "false":
-		return False
-	case "true":
-		return True
-	case "_":
-		return Any
+// newContractVal converts a keyword string into the corresponding function ContractVal, and
+// returns false if the keyword is unknown.
+func newContractVal(keyword string) (ContractVal, bool) {
+	switch val := ContractVal(keyword); val {
+	case NonNil, Nil, False, True, Any:
+		return val, true
 	default:
-		// TODO: The ideal way to handle this is to keep track of this contract parsing error and
-		//  move on to the other contracts. But this may also require some refactoring of other
-		//  parts (we do not currently handle partial recoveries anyways)
-		panic("Unexpected keyword for ContractVal: " + keyword)
+		return "", false
 	}
 }
 
-// Contract represents a function contract.
+// Contract represents a function contract. A contract `contract(I1, ..., In -> O1, ..., Om)` states
+// that if every argument of a call matches its input value, then every result matches its output
+// value. For functions returning an error or an ok bool as the last result (see GuardResultIndex),
+// the outputs describe the results in the case indicated by the output for that result:
+// `contract(_ -> nonnil, nil)` states that the first result is nonnil whenever the error is nil,
+// and `contract(_ -> nonnil, true)` states that the first result is nonnil whenever ok is true.
 type Contract struct {
 	// Ins is the list of input contract values, where the index is the index of the parameter.
 	Ins []ContractVal
 	// Outs is the list of output contract values, where the index is the index of the return.
 	Outs []ContractVal
 }
+
+// GuardResultIndex returns the index of the result guarding the other results of a function with
+// the given signature, i.e., the last result of an error-returning or ok-returning function with
+// more than one result, or -1 if there is no such result.
+func GuardResultIndex(sig *types.Signature) int {
+	n := sig.Results().Len()
+	if n < 2 || !(typeshelper.FuncIsErrReturning(sig) || typeshelper.FuncIsOkReturning(sig)) {
+		return -1
+	}
+	return n - 1
+}
@@ -15,8 +15,10 @@
 package functioncontracts
 
 import (
+	"go/constant"
 	"go/token"
 	"go/types"
+	"slices"
 
 	"go.uber.org/nilaway/util/typeshelper"
 	"golang.org/x/tools/go/ssa"
@@ -28,19 +30,24 @@ import (
 const _maxNumTablesPerBlock = 1024
 
 // inferContracts infers function contracts for a function if it has no contracts written. It
-// returns a list of inferred contracts, which may be empty if no contract is inferred but is never
-// nil.
+// returns a list of inferred contracts, which may be empty if no contract is inferred.
 func inferContracts(fn *ssa.Function) Contracts {
-	nilnessTableSetByBB := make(map[*ssa.BasicBlock]nilnessTableSet)
-	retInstrs := getReturnInstrs(fn) // TODO: Consider *ssa.Panic
-	// No need of an expensive dataflow analysis if we can derive contracts from the return
-	// instructions directly.
-	if ctrs := deriveContracts(retInstrs, fn, nilnessTableSetByBB); len(ctrs) != 0 {
-		return ctrs
+	nilnessTableSetByBB, ok := computeNilnessTables(fn)
+	if !ok {
+		return nil
 	}
+	return deriveContracts(getReturnInstrs(fn), fn, nilnessTableSetByBB)
+}
+
+// computeNilnessTables runs a path-sensitive dataflow analysis over the blocks of the function,
+// and returns the set of nilnessTables (one for each distinct path condition) at every block. It
+// returns false if the number of tables explodes, in which case the function should not be
+// analyzed further.
+func computeNilnessTables(fn *ssa.Function) (map[*ssa.BasicBlock]nilnessTableSet, bool) {
+	nilnessTableSetByBB := make(map[*ssa.BasicBlock]nilnessTableSet)
 
-	// Add the entry block to the queue.
-	// TODO: visit fn.Recover.
+	// Add the entry block to the queue. Note that the fn.Recover block (if any) is not reachable
+	// from the entry block, so nothing is known about the values on its return paths (see tablesAt).
 	var queue []*ssa.BasicBlock
 	queue = append(queue, fn.Blocks[0])
 	seen := make([]bool, len(fn.Blocks)) // seen[i] means we have visited block i at least once.
@@ -139,15 +146,15 @@ func inferContracts(fn *ssa.Function) Contracts {
 
 		// TODO: nicely handle exponential explosion of tables.
 		if len(nilnessTableSetByBB[b]) >= _maxNumTablesPerBlock {
-			// Too many tables, we should give up inferring contracts for this function.
-			return nil
+			// Too many tables, we should give up analyzing this function.
+			return nil, false
 		}
 
 		// Add successors to queue since the nilness table set of this block has been updated.
 		queue = append(queue, b.Succs...)
 	}
 
-	return deriveContracts(retInstrs, fn, nilnessTableSetByBB)
+	return nilnessTableSetByBB, true
 }
 
 // learnNilness learns nilness for the block succ, extended from one nilnessTable table of its
@@ -199,100 +206,268 @@ func learnNilness(succ *ssa.BasicBlock, pred *ssa.BasicBlock, table nilnessTable
 	return lTable, true
 }
 
-// deriveContracts checks nilness of parameter and return values at every exit block to infer
-// contracts.
+// deriveContracts checks the nilness of the parameters and results on every return path to infer
+// contracts. It considers the following contracts, where an input or output is `_` unless stated:
+//
+//  1. for every nilable parameter, the contracts with a `nonnil` or `nil` input for it, e.g.,
+//     `contract(nil -> nil)` for a function returning nil if and only if its argument is nil;
+//  2. the contract with `nonnil` inputs for all nilable parameters if there are more than one, e.g.,
+//     `contract(nonnil, nonnil -> nonnil)`;
+//  3. for ok-returning functions, the contract for the success case, e.g.,
+//     `contract(_ -> nonnil, true)` for a function whose result is nonnil whenever ok is true.
+//
+// An output is derived if it definitely holds on every return path matching the inputs. The
+// outputs that hold on every return path regardless of the inputs are left as `_` since they are
+// not conditioned on the arguments, and a contract is inferred only if it has at least one output
+// derived for the results (other than the guard result, see GuardResultIndex). Note that the paths
+// ending with a panic never return, so they never contradict a contract; for example, a function
+// panicking on a nil argument has no return path for the `nil` input, for which no contract is
+// inferred.
 func deriveContracts(
 	retInstrs []*ssa.Return,
 	fn *ssa.Function,
 	nilnessTableSetByBB map[*ssa.BasicBlock]nilnessTableSet,
 ) Contracts {
-	// TODO: verify other or multiple param/return contracts in the future; for now we consider
-	//  contract(nonnil->nonnil) only.
-	//
-	// For methods, fn.Params[0] is the receiver, while the contract (and its application at call
-	// sites, which uses the declared argument and parameter annotation sites) is about the
-	// declared parameter, so we skip the receiver here. Otherwise, fluent methods that return
-	// their receiver (e.g., `func (z *Int) Neg(x *Int) *Int { ...; return z }`) would incorrectly
-	// match the `param == ret` rule below via the receiver and be given a contract about `x`.
-	param := fn.Params[len(fn.Params)-fn.Signature.Params().Len()]
-	nonnilOrUnknownParamChoices := 0
-	nilParamChoices := 0
-	nonnilRetChoices := 0
-	totalChoices := 0
-
-	// We try to find a counterexample to nonnil->nonnil. If we find one, we immediately break out
-	// of the loop and return the contract(nonnil->nonnil) does not hold. Otherwise, we will
-	// move on to post-check before we can conclude the contaract(nonnil->nonnil) holds.
-	for _, retInstr := range retInstrs {
-		// b ends with a return
-		ret := retInstr.Results[0]
-		tables := newNilnessTableSet()
-		if r, ok := nilnessTableSetByBB[retInstr.Block()]; ok {
-			tables = r
-		} else {
-			tables, _ = add(tables, nilnessTable{})
-		}
-		for _, table := range tables {
-			totalChoices++
-			pNil := table.nilnessOf(param)
-			rNil := table.nilnessOf(ret)
-			// All the possibilities:
-			// nonnil->nonnil     // OK
-			// unknown->nonnil    // OK
-			// nil->nonnil        // OK
-			// nonnil->unknown    // counterexample to contract(nonnil->nonnil)
-			// unknown->unknown   // two cases
-			//   unknown->unknown // counterexample in general
-			//   x->x             // OK if two unknown are the same value
-			// nil->unknown       // OK
-			// nonnil->nil        // counterexample to contract(nonnil->nonnil)
-			// unknown->nil       // counterexample to contract(nonnil->nonnil)
-			// nil->nil           // OK
-			//
-			if rNil == isnonnil {
-				nonnilRetChoices++
+	params := declaredParams(fn)
+	paths := returnPaths(retInstrs, nilnessTableSetByBB)
+	guard := GuardResultIndex(fn.Signature)
+
+	anyIns := make([]ContractVal, len(params))
+	for i := range anyIns {
+		anyIns[i] = Any
+	}
+	unconditional := deriveOutputs(paths, params, anyIns, guard, Any)
+	if unconditional == nil {
+		// The function never returns.
+		return nil
+	}
+
+	// conditional returns the outputs with the unconditional ones replaced by `_`, and false if no
+	// output is left for the results other than the guard result.
+	conditional := func(outs []ContractVal) ([]ContractVal, bool) {
+		if outs == nil {
+			// No return path matches the inputs, i.e., the contract is vacuous.
+			return nil, false
+		}
+		useful := false
+		for i, out := range outs {
+			if out == unconditional[i] {
+				outs[i] = Any
+			} else if out != Any && i != guard {
+				useful = true
 			}
-			if pNil == isnil {
-				nilParamChoices++
-				// nil param never leads to a counterexample to contract(nonnil->nonnil)
-				continue
+		}
+		return outs, useful
+	}
+
+	var (
+		contracts Contracts
+		// nonnilOuts stores the outputs of the contracts with a single `nonnil` input.
+		nonnilOuts [][]ContractVal
+		allNonnil  = slices.Clone(anyIns)
+		numNilable = 0
+	)
+	for i := range params {
+		if !isInferableParam(fn.Signature, i) {
+			continue
+		}
+		numNilable++
+		allNonnil[i] = NonNil
+		for _, in := range []ContractVal{NonNil, Nil} {
+			ins := slices.Clone(anyIns)
+			ins[i] = in
+			outs, ok := conditional(deriveOutputs(paths, params, ins, guard, Any))
+			if in == NonNil && outs != nil {
+				nonnilOuts = append(nonnilOuts, outs)
 			}
-			// pNil == isnonnil or unknown, rNil can be anything, i.e. isnonnil, unknown, isnil.
-			nonnilOrUnknownParamChoices++
-			if rNil == isnonnil || // Absolutely OK if rNil == isnonnil
-				(pNil == unknown && rNil == unknown && param == ret) { // The only OK case otherwise
-				// Those cases are not counterexamples to contract(nonnil->nonnil)
-				continue
+			if ok {
+				contracts = append(contracts, Contract{Ins: ins, Outs: outs})
 			}
-			// All the remaining cases are counterexamples to contract(nonnil->nonnil)
-			return nil
 		}
 	}
 
-	// Post-check: we deny contract(nonnil->nonnil) for the following cases:
-	//
-	// 1. If the parameter is nil at every path, the contract nonnil->nonnil is trivially true, but
-	// we suppress inferring such a useless contract to avoid the following overhead, such as
-	// trigger duplication. However, I feel it is not possible that all paths have the parameter as
-	// isnil, since the parameter always starts with unknown, and we do only branching nil and
-	// nonnil.
-	//
-	// 2. It is essentially _->nonnil that holds for this function, so it is not necessary to
-	// infer nonnil->nonnil.
-	if (nilParamChoices == totalChoices && nonnilOrUnknownParamChoices == 0) ||
-		nonnilRetChoices == totalChoices {
-		return nil
+	if numNilable > 1 {
+		outs, _ := conditional(deriveOutputs(paths, params, allNonnil, guard, Any))
+		// The outputs already implied by a contract with a single `nonnil` input are left out, since
+		// the paths matching all `nonnil` inputs are a subset of the paths matching that input.
+		for _, other := range nonnilOuts {
+			for i := range outs {
+				if outs[i] == other[i] {
+					outs[i] = Any
+				}
+			}
+		}
+		if outs, ok := conditional(outs); ok {
+			contracts = append(contracts, Contract{Ins: allNonnil, Outs: outs})
+		}
 	}
 
-	// totalChoices > nilParamChoices >= 0 && totalChoices >= nonnilOrUnknownParamChoices > 0 &&
-	// nonnilRetChoices < totalChoices
+	// Note that we do not infer contracts for error-returning functions (see canInferContracts).
+	if guard >= 0 {
+		outs := deriveOutputs(paths, params, anyIns, guard, True)
+		// Only the `nonnil` outputs are kept for the success case, since the analysis of ok returns
+		// already requires the other results to be nonnil whenever ok is true (and reports the
+		// return statements violating it).
+		for i := range outs {
+			if outs[i] != NonNil {
+				outs[i] = Any
+			}
+		}
+		if outs, ok := conditional(outs); ok {
+			outs[guard] = True
+			contracts = append(contracts, Contract{Ins: slices.Clone(anyIns), Outs: outs})
+		}
+	}
+	return contracts
+}
+
+// deriveOutputs returns the outputs that definitely hold on every return path matching the inputs
+// and the output for the guard result (if any), or nil if no path matches.
+func deriveOutputs(
+	paths []returnPath,
+	params []*ssa.Parameter,
+	ins []ContractVal,
+	guard int,
+	guardOut ContractVal,
+) []ContractVal {
+	var outs []ContractVal
+	for _, path := range paths {
+		if !path.matches(params, ins, guard, guardOut) {
+			continue
+		}
+		if outs == nil {
+			outs = path.outcomes(params, ins)
+			continue
+		}
+		for i, out := range path.outcomes(params, ins) {
+			if outs[i] != out {
+				outs[i] = Any
+			}
+		}
+	}
+	return outs
+}
+
+// declaredParams returns the SSA parameters for the declared parameters of the function. For
+// methods, fn.Params[0] is the receiver, while the contracts (and their applications at call
+// sites, which use the declared argument and parameter annotation sites) are about the declared
+// parameters, so we skip the receiver here. Otherwise, fluent methods that return their receiver
+// (e.g., `func (z *Int) Neg(x *Int) *Int { ...; return z }`) would incorrectly be given contracts
+// about `x`.
+func declaredParams(fn *ssa.Function) []*ssa.Parameter {
+	return fn.Params[len(fn.Params)-fn.Signature.Params().Len():]
+}
+
+// isInferableParam returns true if contracts about the i-th parameter can be inferred, i.e., it is
+// nilable and not variadic: no argument may be passed for a variadic parameter when calling the
+// function, in which case the contracts about it cannot be applied at the call site.
+func isInferableParam(sig *types.Signature, i int) bool {
+	if sig.Variadic() && i == sig.Params().Len()-1 {
+		return false
+	}
+	return !typeshelper.TypeBarsNilness(sig.Params().At(i).Type())
+}
+
+// returnPath is a path reaching a return instruction, represented by the return instruction and
+// one of the nilnessTables at its block.
+type returnPath struct {
+	ret   *ssa.Return
+	table nilnessTable
+}
+
+// returnPaths returns all return paths of the given return instructions.
+func returnPaths(retInstrs []*ssa.Return, nilnessTableSetByBB map[*ssa.BasicBlock]nilnessTableSet) []returnPath {
+	var paths []returnPath
+	for _, retInstr := range retInstrs {
+		for _, table := range tablesAt(retInstr.Block(), nilnessTableSetByBB) {
+			paths = append(paths, returnPath{ret: retInstr, table: table})
+		}
+	}
+	return paths
+}
+
+// nilnessOf returns the nilness of the value on the path, where the parameters whose nilness is
+// unknown are assumed to match their input values. This covers the x->x case, e.g., a function
+// returning its parameter satisfies `contract(nonnil -> nonnil)`.
+func (p returnPath) nilnessOf(v ssa.Value, params []*ssa.Parameter, ins []ContractVal) nilness {
+	if n := p.table.nilnessOf(v); n != unknown {
+		return n
+	}
+	for i, param := range params {
+		if v != param {
+			continue
+		}
+		switch ins[i] {
+		case NonNil:
+			return isnonnil
+		case Nil:
+			return isnil
+		}
+	}
+	return unknown
+}
+
+// matches returns true if the arguments on the path may match the inputs, and the guard result
+// (if guard is not -1) definitely matches the output guardOut.
+func (p returnPath) matches(params []*ssa.Parameter, ins []ContractVal, guard int, guardOut ContractVal) bool {
+	for i, param := range params {
+		if contradicts(ins[i], param, p.nilnessOf(param, params, ins)) {
+			return false
+		}
+	}
+	if guard < 0 || guardOut == Any {
+		return true
+	}
+	v := p.ret.Results[guard]
+	return outcome(v, p.nilnessOf(v, params, ins)) == guardOut
+}
+
+// outcomes returns the outputs that definitely describe the results on the path.
+func (p returnPath) outcomes(params []*ssa.Parameter, ins []ContractVal) []ContractVal {
+	outs := make([]ContractVal, len(p.ret.Results))
+	for i, v := range p.ret.Results {
+		outs[i] = outcome(v, p.nilnessOf(v, params, ins))
+	}
+	return outs
+}
+
+// outcome returns the contract value that definitely describes the value with the given nilness,
+// or `_` if nothing is known about it.
+func outcome(v ssa.Value, n nilness) ContractVal {
+	switch n {
+	case isnil:
+		return Nil
+	case isnonnil:
+		return NonNil
+	}
+	if c, ok := v.(*ssa.Const); ok && c.Value != nil && c.Value.Kind() == constant.Bool {
+		if constant.BoolVal(c.Value) {
+			return True
+		}
+		return False
+	}
+	return Any
+}
+
+// contradicts returns true if the value with the given nilness definitely does not match the
+// contract value.
+func contradicts(val ContractVal, v ssa.Value, n nilness) bool {
+	out := outcome(v, n)
+	return val != Any && out != Any && out != val
+}
 
-	// nonnil->nonnil is valid at all exit blocks
-	return Contracts{
-		{Ins: []ContractVal{NonNil}, Outs: []ContractVal{NonNil}},
+// tablesAt returns the nilnessTables at the given block, or a single empty table (i.e., nothing is
+// known) if no nilness is learned for the block.
+func tablesAt(b *ssa.BasicBlock, nilnessTableSetByBB map[*ssa.BasicBlock]nilnessTableSet) nilnessTableSet {
+	if tables, ok := nilnessTableSetByBB[b]; ok && len(tables) != 0 {
+		return tables
 	}
+	tables, _ := add(newNilnessTableSet(), nilnessTable{})
+	return tables
 }
 
+// getReturnInstrs returns the return instructions of the function. The exit blocks ending with a
+// panic are skipped, since they never return to the caller.
 func getReturnInstrs(fn *ssa.Function) []*ssa.Return {
 	returnInstrs := make([]*ssa.Return, 0)
 	for _, b := range fn.Blocks {
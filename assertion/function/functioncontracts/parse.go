@@ -17,54 +17,136 @@ package functioncontracts
 import (
 	"fmt"
 	"go/ast"
+	"go/token"
+	"go/types"
 	"regexp"
 	"strings"
+
+	"go.uber.org/nilaway/util/typeshelper"
 )
 
 const _sep = ","
+const _arrow = "->"
 const _contractKeyword = "contract"
-const _contractValKeyword = NonNil + "|" + False + "|" + True + "|" + Any
 
-// _contractRE matches multiple function contracts in the same line. Each contract looks like
-// `contract(VALUE(,VALUE)+ -> VALUE(,VALUE)+)`. The RE also captures two lists of VALUEs,
-// i.e., the part before and after `->` for each contract.
-// Note that we match start and end of the line here and add a non-capturing group for the entire
-// contract, and we disallow anything else except whitespace to appear between contracts, so we
-// acknowledge only the contracts written in their own line.
-var _contractRE = regexp.MustCompile(
-	fmt.Sprintf("^\\s*//\\s*(?:\\s*%s\\s*\\(\\s*((?:%s)(?:\\s*,\\s*(?:%s))*)\\s*->\\s*((?:%s)(?:\\s*,\\s*(?:%s))*)\\s*\\)\\s*)+$",
-		_contractKeyword, _contractValKeyword, _contractValKeyword, _contractValKeyword, _contractValKeyword))
+// _contractValKeywords lists the keywords of all contract values, used in the diagnostics.
+var _contractValKeywords = []ContractVal{NonNil, Nil, True, False, Any}
+
+// _contractLineRE matches a line comment that consists of one or more function contracts, i.e.,
+// `contract(...)`, separated only by whitespace. Note that we match start and end of the line here,
+// so we acknowledge only the contracts written in their own line.
+var _contractLineRE = regexp.MustCompile(fmt.Sprintf(`^\s*//\s*(?:%s\s*\([^()]*\)\s*)+$`, _contractKeyword))
+
+// _contractRE matches a single function contract `contract(VALUE(,VALUE)* -> VALUE(,VALUE)*)` in
+// a line accepted by _contractLineRE, and captures the part between the parentheses. The VALUEs
+// are checked separately such that malformed contracts can be reported.
+var _contractRE = regexp.MustCompile(fmt.Sprintf(`%s\s*\(([^()]*)\)`, _contractKeyword))
 
-// parseContracts parses a slice of function contracts from a singe comment group. If no contract
-// is found from the comment group, an empty slice is returned.
-func parseContracts(doc *ast.CommentGroup) Contracts {
+// writtenContract is a successfully parsed handwritten contract, along with its position and
+// text in the comment such that it can be referred to in the diagnostics.
+type writtenContract struct {
+	Contract
+	pos  token.Pos
+	text string
+}
+
+// parseContracts parses a slice of function contracts from a singe comment group for a function
+// with the given signature. If no contract is found from the comment group, an empty slice is
+// returned. The malformed contracts (e.g., with unknown keywords or a mismatching number of
+// values) are skipped and returned separately.
+func parseContracts(doc *ast.CommentGroup, sig *types.Signature) ([]writtenContract, []InvalidContract) {
 	if doc == nil {
-		return nil
+		return nil, nil
 	}
 
-	var contracts Contracts
+	var (
+		contracts []writtenContract
+		invalid   []InvalidContract
+	)
 	for _, lineComment := range doc.List {
-		for _, matching := range _contractRE.FindAllStringSubmatch(lineComment.Text, -1) {
-			// matching is a slice of three elements; the first is the whole matched string and the
-			// next two are the captured groups of contract values before and after `->`.
-			ins := parseListOfContractValues(matching[1])
-			outs := parseListOfContractValues(matching[2])
-			contracts = append(contracts, Contract{
-				Ins:  ins,
-				Outs: outs,
-			})
+		if !_contractLineRE.MatchString(lineComment.Text) {
+			continue
+		}
+		// Each matching is a slice of four indices: the start and end of the whole contract, and
+		// the start and end of the captured part between the parentheses.
+		for _, matching := range _contractRE.FindAllStringSubmatchIndex(lineComment.Text, -1) {
+			pos := lineComment.Pos() + token.Pos(matching[0])
+			text := lineComment.Text[matching[0]:matching[1]]
+			contract, err := parseContract(lineComment.Text[matching[2]:matching[3]], sig)
+			if err != nil {
+				invalid = append(invalid, InvalidContract{Pos: pos, Text: text, Reason: err.Error()})
+				continue
+			}
+			contracts = append(contracts, writtenContract{Contract: contract, pos: pos, text: text})
+		}
+	}
+	return contracts, invalid
+}
+
+// parseContract parses a single contract from the part between its parentheses, and checks it
+// against the signature of the function.
+func parseContract(str string, sig *types.Signature) (Contract, error) {
+	parts := strings.Split(str, _arrow)
+	if len(parts) != 2 {
+		return Contract{}, fmt.Errorf("expected exactly one %q between the inputs and the outputs", _arrow)
+	}
+	ins, err := parseListOfContractValues(parts[0])
+	if err != nil {
+		return Contract{}, err
+	}
+	outs, err := parseListOfContractValues(parts[1])
+	if err != nil {
+		return Contract{}, err
+	}
+
+	if len(ins) != sig.Params().Len() {
+		return Contract{}, fmt.Errorf("expected %d input value(s) for the parameters, got %d", sig.Params().Len(), len(ins))
+	}
+	if len(outs) != sig.Results().Len() {
+		return Contract{}, fmt.Errorf("expected %d output value(s) for the results, got %d", sig.Results().Len(), len(outs))
+	}
+	for i, val := range ins {
+		if err := checkContractVal(val, sig.Params().At(i).Type()); err != nil {
+			return Contract{}, fmt.Errorf("parameter %d: %w", i, err)
+		}
+	}
+	for i, val := range outs {
+		if err := checkContractVal(val, sig.Results().At(i).Type()); err != nil {
+			return Contract{}, fmt.Errorf("result %d: %w", i, err)
 		}
 	}
-	return contracts
+	return Contract{Ins: ins, Outs: outs}, nil
 }
 
 // parseListOfContractValues splits a string of comma separated contract value keywords and returns
-// a slice of ContractVal.
-func parseListOfContractValues(wholeStr string) []ContractVal {
+// a slice of ContractVal, or an error if any of the keywords is unknown.
+func parseListOfContractValues(wholeStr string) ([]ContractVal, error) {
 	valKeywords := strings.Split(wholeStr, _sep)
 	contractVals := make([]ContractVal, len(valKeywords))
 	for i, v := range valKeywords {
-		contractVals[i] = newContractVal(strings.TrimSpace(v))
+		keyword := strings.TrimSpace(v)
+		val, ok := newContractVal(keyword)
+		if !ok {
+			return nil, fmt.Errorf("unknown keyword %q, expected one of %v", keyword, _contractValKeywords)
+		}
+		contractVals[i] = val
+	}
+	return contractVals, nil
+}
+
+// checkContractVal checks if the contract value can describe a value of the given type: `nil` and
+// `nonnil` apply to nilable types only (including type parameters whose type sets may contain nil,
+// e.g., `T any`), and `true` and `false` apply to boolean types only.
+func checkContractVal(val ContractVal, typ types.Type) error {
+	switch val {
+	case NonNil, Nil:
+		if typeshelper.TypeBarsNilness(typ) {
+			return fmt.Errorf("%q does not apply to non-nilable type %s", val, typ)
+		}
+	case True, False:
+		if basic, ok := typ.Underlying().(*types.Basic); !ok || basic.Info()&types.IsBoolean == 0 {
+			return fmt.Errorf("%q does not apply to non-boolean type %s", val, typ)
+		}
 	}
-	return contractVals
+	return nil
 }
@@ -25,7 +25,7 @@ func ExportedManual(p *int) *int { //want ExportedManual:"&\\[{\\[nonnil\\] \\[n
 	return nil
 }
 
-func ExportedInferred(p *int) *int { //want ExportedInferred:"&\\[{\\[nonnil\\] \\[nonnil\\]}\\]"
+func ExportedInferred(p *int) *int { //want ExportedInferred:"&\\[{\\[nonnil\\] \\[nonnil\\]} {\\[nil\\] \\[nil\\]}\\]"
 	if p != nil {
 		a := 1
 		return &a
@@ -33,6 +33,16 @@ func ExportedInferred(p *int) *int { //want ExportedInferred:"&\\[{\\[nonnil\\]
 	return nil
 }
 
+var _store map[string]*int
+
+func ExportedLookup(key string) (*int, bool) { //want ExportedLookup:"&\\[{\\[_\\] \\[nonnil true\\]}\\]"
+	v := _store[key]
+	if v == nil {
+		return nil, false
+	}
+	return v, true
+}
+
 //contract(nonnil -> nonnil)
 func unexportedManual(p *int) *int { // Notice here we do not want to export the contracts for it.
 	if p != nil {
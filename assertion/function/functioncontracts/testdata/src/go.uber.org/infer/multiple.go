@@ -0,0 +1,93 @@
+//  Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package infer
+
+// The functions below test the inference of contracts beyond contract(nonnil -> nonnil).
+
+// contract(nonnil, _ -> nonnil), contract(_, nonnil -> nonnil) and contract(nil, nil -> nil) hold,
+// but we infer only the first two since the contracts with multiple `nil` inputs are not inferred.
+func firstNonnil(a, b *int) *int {
+	if a != nil {
+		return a
+	}
+	return b
+}
+
+// contract(nonnil, nonnil -> nonnil) holds, while no contract with a single `nonnil` input holds.
+// contract(nil, _ -> nil) and contract(_, nil -> nil) hold as well.
+func join(a, b *int) *int {
+	if a == nil || b == nil {
+		return nil
+	}
+	sum := *a + *b
+	return &sum
+}
+
+var _store map[string]*int
+
+type inputError struct{}
+
+func (*inputError) Error() string { return "invalid input" }
+
+// contract(_ -> nonnil, true) holds: the result is nonnil whenever ok is true.
+func lookup(key string) (*int, bool) {
+	v, ok := _store[key]
+	if !ok || v == nil {
+		return nil, false
+	}
+	return v, true
+}
+
+// contract(_ -> nonnil, nil) holds, but we do not infer contracts for error-returning functions
+// since their results are already handled by the analysis of error returns.
+func load(key string) (*int, error) {
+	v := _store[key]
+	if v == nil {
+		return nil, &inputError{}
+	}
+	return v, nil
+}
+
+// contract(nil, _ -> nil, nonnil) holds, but it is not inferred either.
+func parse(p *int, strict bool) (*int, error) {
+	if p == nil {
+		return nil, &inputError{}
+	}
+	if strict && *p < 0 {
+		return nil, &inputError{}
+	}
+	return p, nil
+}
+
+// contract(nonnil, _ -> nonnil) and contract(nil, _ -> nil) hold. The function panics on a nil
+// factor, which does not lead to a return, so no contract is inferred for factor.
+func scale(x *int, factor *int) *int {
+	if factor == nil {
+		panic("nil factor")
+	}
+	if x == nil {
+		return nil
+	}
+	y := *x * *factor
+	return &y
+}
+
+// contract(nil -> false) holds.
+func isPositive(p *int) bool {
+	if p == nil {
+		return false
+	}
+	return *p > 0
+}
@@ -14,6 +14,8 @@
 
 package parse
 
+import "errors"
+
 // contract(nonnil -> nonnil)
 func f1(x *int) *int {
 	if x == nil {
@@ -64,3 +66,66 @@ func multipleContracts(x *int, y *int) (*int, bool) {
 // function has no param or return. Only a contract in its own line should be parsed, not even `//
 // contract(nonnil -> nonnil)`.
 func contractCommentInOtherLine() {}
+
+// contract(nil -> nil)
+// contract(nonnil -> nonnil)
+func nilToNil(x *int) *int {
+	if x == nil {
+		return nil
+	}
+	return new(int)
+}
+
+// contract(_ -> nonnil, nil)
+func errorAware(key string) (*int, error) {
+	if key == "" {
+		return nil, errors.New("empty key")
+	}
+	return new(int), nil
+}
+
+// contract(nonnil, _ -> true)
+func multipleParams(x *int, y *int) bool {
+	return x != nil || y != nil
+}
+
+// contract(nonnil -> nonnil) contract(nil -> nil)
+func multipleContractsInOneLine(x *int) *int {
+	return x
+}
+
+// contract(nonil -> nonnil)
+func unknownKeyword(x *int) *int {
+	return x
+}
+
+// contract(nonnil, nonnil -> nonnil)
+func mismatchedParams(x *int) *int {
+	return x
+}
+
+// contract(nonnil -> true)
+func mismatchedType(x int) *int {
+	return &x
+}
+
+// contract(nonnil -> nonnil)
+// contract(nonnil => nonnil)
+func partiallyValid(x *int) *int {
+	return x
+}
+
+// contract(nonnil -> nonnil)
+func genericIdentity[T any](x T) T {
+	return x
+}
+
+// contract(nil -> nil)
+func genericPointer[T any, P ~*T](x P) P {
+	return x
+}
+
+// contract(nonnil -> nonnil)
+func genericNonNilable[T ~int | ~string](x T) T {
+	return x
+}
@@ -0,0 +1,78 @@
+//  Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package verify
+
+import "errors"
+
+// contract(nonnil -> nonnil)
+func correct(x *int) *int {
+	if x == nil {
+		return nil
+	}
+	return x
+}
+
+// contract(nonnil -> nonnil)
+func nilOnNonnilPath(x *int) *int {
+	if x != nil {
+		return nil
+	}
+	return new(int)
+}
+
+// contract(nonnil -> nil)
+func returnsParam(x *int) *int {
+	return x
+}
+
+// contract(nil -> nil) contract(nonnil -> nonnil)
+func secondContractViolated(x *int) *int {
+	if x == nil {
+		return x
+	}
+	return nil
+}
+
+// contract(_ -> nonnil, nil)
+func errorAware(key string) (*int, error) {
+	if key == "" {
+		return nil, errors.New("empty key")
+	}
+	if key == "unset" {
+		return nil, nil
+	}
+	return new(int), nil
+}
+
+// contract(_ -> nonnil, nil)
+func errorAwareCorrect(key string) (*int, error) {
+	if key == "" {
+		return nil, errors.New("empty key")
+	}
+	return new(int), nil
+}
+
+// contract(nil -> false)
+func isValid(x *int) bool {
+	if x == nil {
+		return true
+	}
+	return *x > 0
+}
+
+// contract(nonnil, _ -> nonnil)
+func unknownResult(x *int, y *int) *int {
+	return y
+}
@@ -0,0 +1,109 @@
+//  Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functioncontracts
+
+import (
+	"fmt"
+	"strings"
+
+	"golang.org/x/tools/go/ssa"
+)
+
+// verifyContracts checks the handwritten contracts of a function against its body, reusing the
+// nilness tables computed for contract inference, and returns the contracts that are violated by
+// some return path of the function. To avoid false positives, a return path is considered as a
+// violation only if its arguments may match the inputs of the contract while one of its results
+// definitely does not match the corresponding output.
+func verifyContracts(fn *ssa.Function, contracts []writtenContract) []ViolatedContract {
+	retInstrs := getReturnInstrs(fn)
+	if len(retInstrs) == 0 {
+		return nil
+	}
+	nilnessTableSetByBB, ok := computeNilnessTables(fn)
+	if !ok {
+		return nil
+	}
+
+	var violated []ViolatedContract
+	for _, ctr := range contracts {
+		retInstr, reason := findViolation(fn, ctr.Contract, retInstrs, nilnessTableSetByBB)
+		if retInstr == nil {
+			continue
+		}
+		violated = append(violated, ViolatedContract{
+			Pos:       ctr.pos,
+			Text:      ctr.text,
+			ReturnPos: retInstr.Pos(),
+			Reason:    reason,
+		})
+	}
+	return violated
+}
+
+// findViolation returns the first return instruction (and the description of its path) that
+// violates the contract, or nil if no violation is found.
+func findViolation(
+	fn *ssa.Function,
+	ctr Contract,
+	retInstrs []*ssa.Return,
+	nilnessTableSetByBB map[*ssa.BasicBlock]nilnessTableSet,
+) (*ssa.Return, string) {
+	params := declaredParams(fn)
+	// For error-returning and ok-returning functions, the outputs describe the results in the case
+	// indicated by the guard result, so we check only the paths where it definitely matches its
+	// output.
+	guard := GuardResultIndex(fn.Signature)
+	guardOut := Any
+	if guard >= 0 {
+		guardOut = ctr.Outs[guard]
+	}
+
+	for _, path := range returnPaths(retInstrs, nilnessTableSetByBB) {
+		if !path.matches(params, ctr.Ins, guard, guardOut) {
+			continue
+		}
+		for i, out := range ctr.Outs {
+			if i == guard {
+				continue
+			}
+			ret := path.ret.Results[i]
+			if n := path.nilnessOf(ret, params, ctr.Ins); contradicts(out, ret, n) {
+				return path.ret, describeViolation(ctr, params, guard, i, outcome(ret, n))
+			}
+		}
+	}
+	return nil, ""
+}
+
+// describeViolation describes the path on which the result at retIndex (whose actual value is
+// described by actual) violates the contract, e.g., "result 0 is nil while parameter `x` is
+// nonnil".
+func describeViolation(ctr Contract, params []*ssa.Parameter, guard int, retIndex int, actual ContractVal) string {
+	var conditions []string
+	for i, in := range ctr.Ins {
+		if in != Any {
+			conditions = append(conditions, fmt.Sprintf("parameter `%s` is %s", params[i].Name(), in))
+		}
+	}
+	if guard >= 0 && ctr.Outs[guard] != Any {
+		conditions = append(conditions, fmt.Sprintf("result %d is %s", guard, ctr.Outs[guard]))
+	}
+
+	desc := fmt.Sprintf("result %d is %s", retIndex, actual)
+	if len(conditions) != 0 {
+		desc += " while " + strings.Join(conditions, " and ")
+	}
+	return desc
+}
@@ -0,0 +1,76 @@
+//  Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package functionvalues implements a sub-analyzer to resolve the possible targets of calls to
+// function values (e.g., `f()` where `f` is a local variable, a parameter or a struct field of
+// function type) in a package, such that the param and return annotation sites of the targets can
+// be connected to the call sites.
+package functionvalues
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"reflect"
+
+	"go.uber.org/nilaway/config"
+	"go.uber.org/nilaway/util/analysishelper"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/buildssa"
+)
+
+const _doc = "Resolve the possible targets of the calls to function values in this package, returning the results."
+
+// Analyzer here is the analyzer that resolves the targets of function value calls. It returns
+// the map from the calls to their resolved targets.
+var Analyzer = &analysis.Analyzer{
+	Name:       "nilaway_function_values_analyzer",
+	Doc:        _doc,
+	Run:        analysishelper.WrapRun(run),
+	ResultType: reflect.TypeOf((*analysishelper.Result[Map])(nil)),
+	Requires:   []*analysis.Analyzer{config.Analyzer, buildssa.Analyzer},
+}
+
+// Targets stores the possible targets of a call to a function value.
+type Targets struct {
+	// Funcs are the declared functions and bound methods that the function value may refer to.
+	Funcs []*types.Func
+	// FuncLits are the function literals that the function value may refer to. Their annotation
+	// sites are the ones of their fake function objects (see anonymousfunc.FuncLitInfo).
+	FuncLits []*ast.FuncLit
+	// IsUnresolved is true if the function value may refer to functions that cannot be determined
+	// within the package, e.g., when it is read from a map or passed from another package.
+	IsUnresolved bool
+}
+
+// Map stores the mappings from the calls to function values, identified by the positions of the
+// left parentheses of the call expressions, to their possible targets.
+type Map map[token.Pos]*Targets
+
+func run(p *analysis.Pass) (Map, error) {
+	pass := analysishelper.NewEnhancedPass(p)
+	conf := pass.ResultOf[config.Analyzer].(*config.Config)
+	if !conf.IsPkgInScope(pass.Pkg) {
+		return make(Map), nil
+	}
+
+	ssaInput := pass.ResultOf[buildssa.Analyzer].(*buildssa.SSA)
+	funcs := ssaInput.SrcFuncs
+	// The synthetic package initializer is not a source function, but it contains the stores of
+	// the initializers of the global variables.
+	if init := ssaInput.Pkg.Func("init"); init != nil {
+		funcs = append(funcs[:len(funcs):len(funcs)], init)
+	}
+	return newResolver(pass.Pkg, funcs).resolveCalls(), nil
+}
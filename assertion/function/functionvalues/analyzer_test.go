@@ -0,0 +1,116 @@
+//  Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functionvalues
+
+import (
+	"go/ast"
+	"go/types"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+	"go.uber.org/nilaway/util/analysishelper"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestAnalyzer(t *testing.T) {
+	t.Parallel()
+
+	// Intentionally give a nil pass variable to trigger a panic, but we should recover from it
+	// and convert it to an error via the result struct.
+	r, err := Analyzer.Run(nil /* pass */)
+	require.NoError(t, err)
+	require.ErrorContains(t, r.(*analysishelper.Result[Map]).Err, "INTERNAL PANIC")
+}
+
+// expectedTargets is a simplified version of Targets with the names of the target functions.
+type expectedTargets struct {
+	funcs        []string
+	numFuncLits  int
+	isUnresolved bool
+}
+
+func TestResolve(t *testing.T) {
+	t.Parallel()
+
+	testdata := analysistest.TestData()
+	r := analysistest.Run(t, testdata, Analyzer, "go.uber.org/resolve")
+	require.Len(t, r, 1)
+	pass, result := r[0].Pass, r[0].Result
+	require.IsType(t, &analysishelper.Result[Map]{}, result)
+	require.NoError(t, result.(*analysishelper.Result[Map]).Err)
+	funcValues := result.(*analysishelper.Result[Map]).Res
+
+	// Collect the targets of the calls to function values in each function.
+	actual := make(map[string]expectedTargets)
+	for _, file := range pass.Files {
+		for _, decl := range file.Decls {
+			funcDecl, ok := decl.(*ast.FuncDecl)
+			if !ok {
+				continue
+			}
+			ast.Inspect(funcDecl, func(node ast.Node) bool {
+				call, ok := node.(*ast.CallExpr)
+				if !ok {
+					return true
+				}
+				var ident *ast.Ident
+				switch fun := call.Fun.(type) {
+				case *ast.Ident:
+					ident = fun
+				case *ast.SelectorExpr:
+					ident = fun.Sel
+				}
+				if _, ok := pass.TypesInfo.ObjectOf(ident).(*types.Var); !ok {
+					return true
+				}
+				targets, ok := funcValues[call.Lparen]
+				require.True(t, ok, "call at %s is not resolved", pass.Fset.Position(call.Pos()))
+				var funcs []string
+				for _, f := range targets.Funcs {
+					funcs = append(funcs, f.Name())
+				}
+				actual[funcDecl.Name.Name] = expectedTargets{funcs, len(targets.FuncLits), targets.IsUnresolved}
+				return true
+			})
+		}
+	}
+
+	expected := map[string]expectedTargets{
+		"callLocal":         {funcs: []string{"foo"}},
+		"callPhi":           {funcs: []string{"foo", "bar"}},
+		"callFuncLit":       {funcs: []string{"foo"}, numFuncLits: 1},
+		"callCaptured":      {funcs: []string{"foo", "bar"}},
+		"callField":         {funcs: []string{"foo", "bar"}},
+		"callExportedField": {funcs: []string{"foo"}, isUnresolved: true},
+		"callFieldPerAlloc": {funcs: []string{"bar"}},
+		"callFieldViaParam": {funcs: []string{"bar"}},
+		"callValueField":    {funcs: []string{"bar"}},
+		"apply":             {funcs: []string{"foo", "bar"}},
+		"Apply":             {isUnresolved: true},
+		"callMethodValue":   {funcs: []string{"method"}},
+		"callMap":           {isUnresolved: true},
+		"callResult":        {funcs: []string{"bar"}},
+		"callGlobal":        {funcs: []string{"foo"}},
+		"callCycleFirst":    {funcs: []string{"foo"}},
+		"callCycleLater":    {funcs: []string{"foo"}},
+		"callOnlyFuncLit":   {numFuncLits: 1},
+	}
+	require.Equal(t, expected, actual)
+}
+
+func TestMain(m *testing.M) {
+	goleak.VerifyTestMain(m)
+}
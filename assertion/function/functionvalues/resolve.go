@@ -0,0 +1,767 @@
+//  Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functionvalues
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"slices"
+
+	"go.uber.org/nilaway/util/typeshelper"
+	"golang.org/x/tools/go/ssa"
+)
+
+// resolver implements a lightweight, flow-insensitive points-to analysis for function values over
+// the SSA form of the functions in a package. Local variables are resolved via their SSA
+// definitions, global variables via all the stores to them in the package, struct fields via the
+// values set into the same local struct variables (e.g., composite literals passed as arguments)
+// or the stores to the same field of the objects allocated at the same sites (or all the stores to
+// the field in the package if neither is known), parameters of unexported functions via the
+// arguments at all their call sites, and results of calls via the returned values of the callees. Anything else (e.g., map or
+// slice elements, results of dynamic calls, or values that may be set outside the package) is
+// unresolved.
+type resolver struct {
+	pkg   *types.Package
+	funcs []*ssa.Function
+
+	// stores maps the addresses of local variables, free variables and global variables to the
+	// values stored into them.
+	stores map[ssa.Value][]ssa.Value
+	// fieldStores maps the struct fields to the stores into them.
+	fieldStores map[*types.Var][]fieldStore
+	// closures maps the functions to the closures created for them, for resolving free variables.
+	closures map[*ssa.Function][]*ssa.MakeClosure
+	// staticCalls maps the functions to their static call sites.
+	staticCalls map[*ssa.Function][]*ssa.CallCommon
+	// escaped stores the functions that are used as values, i.e., may have unknown callers.
+	escaped map[*ssa.Function]bool
+
+	// memo stores the resolved targets of the values.
+	memo map[ssa.Value]*Targets
+	// allocMemo stores the allocation sites of the struct pointers used as bases of field loads.
+	allocMemo map[ssa.Value]allocSites
+
+	// The following fields store the state of Tarjan's algorithm for finding the strongly
+	// connected components of the values (e.g., cycles of phi nodes), see visit.
+	order         map[ssa.Value]int
+	stack         []ssa.Value
+	onStack       map[ssa.Value]bool
+	contributions map[ssa.Value]*contribution
+}
+
+// contribution is the direct contribution of a value to its targets: the targets it adds by
+// itself, and the values whose targets flow into it.
+type contribution struct {
+	targets Targets
+	flows   []ssa.Value
+}
+
+// fieldStore is a store of val into a struct field of the object pointed to by base.
+type fieldStore struct {
+	base ssa.Value
+	val  ssa.Value
+}
+
+// allocSites are the possible allocation sites of the objects a pointer may point to.
+type allocSites struct {
+	sites map[*ssa.Alloc]bool
+	// isUnknown is true if the pointer may point to objects allocated elsewhere, e.g., passed
+	// from another package or loaded from a map.
+	isUnknown bool
+}
+
+// mayAlias returns true if the pointers with the allocation sites a and b may point to the same
+// object.
+func (a allocSites) mayAlias(b allocSites) bool {
+	if a.isUnknown || b.isUnknown {
+		return true
+	}
+	for site := range a.sites {
+		if b.sites[site] {
+			return true
+		}
+	}
+	return false
+}
+
+// flow records that the targets of v flow into the value.
+func (c *contribution) flow(v ssa.Value) {
+	c.flows = append(c.flows, v)
+}
+
+func newResolver(pkg *types.Package, funcs []*ssa.Function) *resolver {
+	r := &resolver{
+		pkg:         pkg,
+		funcs:       funcs,
+		stores:      make(map[ssa.Value][]ssa.Value),
+		fieldStores: make(map[*types.Var][]fieldStore),
+		closures:    make(map[*ssa.Function][]*ssa.MakeClosure),
+		staticCalls: make(map[*ssa.Function][]*ssa.CallCommon),
+		escaped:     make(map[*ssa.Function]bool),
+		memo:        make(map[ssa.Value]*Targets),
+		allocMemo:   make(map[ssa.Value]allocSites),
+
+		order:         make(map[ssa.Value]int),
+		onStack:       make(map[ssa.Value]bool),
+		contributions: make(map[ssa.Value]*contribution),
+	}
+
+	for _, fn := range funcs {
+		for _, block := range fn.Blocks {
+			for _, instr := range block.Instrs {
+				r.index(instr)
+			}
+		}
+	}
+	return r
+}
+
+// index records the information of the instruction needed for resolving function values.
+func (r *resolver) index(instr ssa.Instruction) {
+	var callee *ssa.Function
+	switch instr := instr.(type) {
+	case *ssa.Store:
+		switch addr := instr.Addr.(type) {
+		case *ssa.Alloc, *ssa.FreeVar, *ssa.Global:
+			r.stores[addr] = append(r.stores[addr], instr.Val)
+		case *ssa.FieldAddr:
+			if field := fieldOf(addr.X.Type(), addr.Field); field != nil {
+				r.fieldStores[field] = append(r.fieldStores[field], fieldStore{base: addr.X, val: instr.Val})
+			}
+		}
+	case *ssa.MakeClosure:
+		if fn, ok := instr.Fn.(*ssa.Function); ok {
+			r.closures[origin(fn)] = append(r.closures[origin(fn)], instr)
+			callee = fn
+		}
+	case ssa.CallInstruction:
+		if fn := instr.Common().StaticCallee(); fn != nil && !instr.Common().IsInvoke() {
+			r.staticCalls[origin(fn)] = append(r.staticCalls[origin(fn)], instr.Common())
+			if mc, ok := instr.Common().Value.(*ssa.MakeClosure); ok {
+				callee = mc.Fn.(*ssa.Function)
+			} else {
+				callee = fn
+			}
+		}
+	}
+
+	// Any other use of a function as an operand makes it escape.
+	for _, op := range instr.Operands(nil) {
+		if fn, ok := (*op).(*ssa.Function); ok && fn != callee {
+			r.escaped[origin(fn)] = true
+		}
+	}
+}
+
+// resolveCalls resolves the targets of all calls to function values in the package.
+func (r *resolver) resolveCalls() Map {
+	m := make(Map)
+	for _, fn := range r.funcs {
+		for _, block := range fn.Blocks {
+			for _, instr := range block.Instrs {
+				call, ok := instr.(ssa.CallInstruction)
+				if !ok {
+					continue
+				}
+				// Note that the calls to function values may also be static calls in the SSA form,
+				// e.g., `f := foo; f()`, hence we resolve all the calls here.
+				common := call.Common()
+				if common.IsInvoke() || !common.Pos().IsValid() {
+					continue
+				}
+				if _, ok := common.Value.(*ssa.Builtin); ok {
+					continue
+				}
+				m[common.Pos()] = r.resolve(common.Value)
+			}
+		}
+	}
+	return m
+}
+
+// resolve returns the possible targets of the function value v.
+func (r *resolver) resolve(v ssa.Value) *Targets {
+	if _, ok := r.memo[v]; !ok {
+		r.visit(v)
+	}
+	return r.memo[v]
+}
+
+// visit resolves the targets of v and all values flowing into it with Tarjan's algorithm, and
+// returns the lowest order of the values on the stack reachable from v. The values in a cycle
+// (i.e., a strongly connected component) share the same targets, which are only memoized once the
+// whole cycle is resolved, such that no partial targets are observed.
+func (r *resolver) visit(v ssa.Value) int {
+	order := len(r.order)
+	r.order[v] = order
+	low := order
+	r.stack = append(r.stack, v)
+	r.onStack[v] = true
+
+	c := r.contribution(v)
+	r.contributions[v] = c
+	for _, w := range c.flows {
+		if _, ok := r.memo[w]; ok {
+			continue
+		}
+		if o, ok := r.order[w]; !ok {
+			low = min(low, r.visit(w))
+		} else if r.onStack[w] {
+			low = min(low, o)
+		}
+	}
+	if low != order {
+		return low
+	}
+
+	// v is the root of a strongly connected component, pop all its values and merge their
+	// contributions along with the targets of the (already resolved) values flowing into them.
+	var members []ssa.Value
+	for {
+		w := r.stack[len(r.stack)-1]
+		r.stack = r.stack[:len(r.stack)-1]
+		r.onStack[w] = false
+		members = append(members, w)
+		if w == v {
+			break
+		}
+	}
+	t := &Targets{}
+	for _, w := range members {
+		t.merge(&r.contributions[w].targets)
+	}
+	for _, w := range members {
+		for _, f := range r.contributions[w].flows {
+			if ft, ok := r.memo[f]; ok {
+				t.merge(ft)
+			}
+		}
+	}
+	for _, w := range members {
+		r.memo[w] = t
+		delete(r.contributions, w)
+	}
+	return low
+}
+
+// contribution returns the direct contribution of the function value v to its targets.
+func (r *resolver) contribution(v ssa.Value) *contribution {
+	c := &contribution{}
+	switch v := v.(type) {
+	case *ssa.Function:
+		r.addFunc(c, v)
+	case *ssa.MakeClosure:
+		r.addFunc(c, v.Fn.(*ssa.Function))
+	case *ssa.Const:
+		// A nil function value, calling it panics regardless of the targets.
+	case *ssa.Phi:
+		for _, edge := range v.Edges {
+			c.flow(edge)
+		}
+	case *ssa.ChangeType:
+		c.flow(v.X)
+	case *ssa.UnOp:
+		if v.Op != token.MUL {
+			c.targets.IsUnresolved = true
+			break
+		}
+		r.addLoad(c, v.X)
+	case *ssa.Field:
+		r.addValueField(c, v.X, fieldOf(v.X.Type(), v.Field))
+	case *ssa.FreeVar:
+		r.addFreeVar(c, v)
+	case *ssa.Parameter:
+		r.addParam(c, v)
+	case *ssa.Call:
+		r.addResult(c, v.Common(), 0)
+	case *ssa.Extract:
+		if call, ok := v.Tuple.(*ssa.Call); ok {
+			r.addResult(c, call.Common(), v.Index)
+		} else {
+			c.targets.IsUnresolved = true
+		}
+	default:
+		c.targets.IsUnresolved = true
+	}
+	return c
+}
+
+// addFunc adds the function fn used as a value to the targets.
+func (r *resolver) addFunc(c *contribution, fn *ssa.Function) {
+	if fn.Parent() != nil {
+		lit, ok := origin(fn).Syntax().(*ast.FuncLit)
+		if !ok {
+			c.targets.IsUnresolved = true
+			return
+		}
+		if !slices.Contains(c.targets.FuncLits, lit) {
+			c.targets.FuncLits = append(c.targets.FuncLits, lit)
+		}
+		return
+	}
+	funcObj, ok := fn.Object().(*types.Func)
+	if !ok {
+		c.targets.IsUnresolved = true
+		return
+	}
+	funcObj = funcObj.Origin()
+	// Method expressions (e.g., `T.M`) take the receiver as the first param, hence their params
+	// do not match the ones of the method.
+	if funcObj.Signature().Params().Len() != fn.Signature.Params().Len() {
+		c.targets.IsUnresolved = true
+		return
+	}
+	if !slices.Contains(c.targets.Funcs, funcObj) {
+		c.targets.Funcs = append(c.targets.Funcs, funcObj)
+	}
+}
+
+// addLoad adds the targets of the function value loaded from the address addr.
+func (r *resolver) addLoad(c *contribution, addr ssa.Value) {
+	switch addr := addr.(type) {
+	case *ssa.Alloc, *ssa.FreeVar:
+		r.addLocal(c, addr)
+	case *ssa.Global:
+		if addr.Pkg == nil || addr.Pkg.Pkg != r.pkg || addr.Object() == nil || addr.Object().Exported() {
+			// The exported global variables may be set by other packages.
+			c.targets.IsUnresolved = true
+		}
+		for _, val := range r.stores[addr] {
+			c.flow(val)
+		}
+	case *ssa.FieldAddr:
+		r.addField(c, fieldOf(addr.X.Type(), addr.Field), addr.X)
+	default:
+		c.targets.IsUnresolved = true
+	}
+}
+
+// addLocal adds the targets of the function value stored in the local variable (or a free
+// variable capturing a local variable) addr.
+func (r *resolver) addLocal(c *contribution, addr ssa.Value) {
+	vals, escapes := r.localStores(addr)
+	if escapes {
+		c.targets.IsUnresolved = true
+	}
+	for _, val := range vals {
+		c.flow(val)
+	}
+}
+
+// localStores returns the values stored into the local variable (or a free variable capturing a
+// local variable) addr, and whether its address escapes such that it may be set elsewhere.
+func (r *resolver) localStores(addr ssa.Value) (vals []ssa.Value, escapes bool) {
+	for _, alias := range r.aliases(addr) {
+		vals = append(vals, r.stores[alias]...)
+
+		refs := alias.Referrers()
+		if refs == nil {
+			continue
+		}
+		for _, ref := range *refs {
+			switch ref := ref.(type) {
+			case *ssa.Store:
+				if ref.Addr != alias {
+					// The address itself is stored somewhere else.
+					escapes = true
+				}
+			case *ssa.UnOp, *ssa.DebugRef, *ssa.MakeClosure:
+				// Loads of the variable, or captures of it (handled as aliases).
+			default:
+				// The address of the variable escapes (e.g., passed to a call).
+				escapes = true
+			}
+		}
+	}
+	return vals, escapes
+}
+
+// aliases returns the addresses referring to the same variable as addr, i.e., the local variable
+// and the free variables of the closures capturing it.
+func (r *resolver) aliases(addr ssa.Value) []ssa.Value {
+	visited := map[ssa.Value]bool{addr: true}
+	worklist := []ssa.Value{addr}
+	for i := 0; i < len(worklist); i++ {
+		cur := worklist[i]
+		visit := func(v ssa.Value) {
+			if !visited[v] {
+				visited[v] = true
+				worklist = append(worklist, v)
+			}
+		}
+
+		// Inward: the free variables of the closures capturing cur.
+		if refs := cur.Referrers(); refs != nil {
+			for _, ref := range *refs {
+				if mc, ok := ref.(*ssa.MakeClosure); ok {
+					fn := mc.Fn.(*ssa.Function)
+					for j, binding := range mc.Bindings {
+						if binding == cur && j < len(fn.FreeVars) {
+							visit(fn.FreeVars[j])
+						}
+					}
+				}
+			}
+		}
+
+		// Outward: the variables bound to cur if it is a free variable.
+		if fv, ok := cur.(*ssa.FreeVar); ok {
+			if idx := freeVarIndex(fv); idx >= 0 {
+				for _, mc := range r.closures[origin(fv.Parent())] {
+					visit(mc.Bindings[idx])
+				}
+			}
+		}
+	}
+	return worklist
+}
+
+// addField adds the targets of the function values stored into the struct field of the object
+// pointed to by base. If base is a local struct variable, only the values set into it are
+// considered (see localFieldValues), regardless of whether the field is exported. Otherwise, only
+// the stores into the objects that may be allocated at the same sites as the ones of base are
+// considered, such that, e.g., the function values stored by different constructors are not mixed
+// up. A nil base (e.g., a field of a struct value) or a base whose allocation sites cannot be
+// determined matches all the stores into the field.
+func (r *resolver) addField(c *contribution, field *types.Var, base ssa.Value) {
+	if alloc, ok := base.(*ssa.Alloc); ok && field != nil {
+		if vals, ok := r.localFieldValues(alloc, field, make(map[ssa.Value]bool)); ok {
+			for _, val := range vals {
+				c.flow(val)
+			}
+			return
+		}
+	}
+	if field == nil || field.Pkg() != r.pkg || field.Exported() {
+		// The exported fields, or fields from other packages, may be set outside the package.
+		c.targets.IsUnresolved = true
+		if field == nil {
+			return
+		}
+	}
+	sites := allocSites{isUnknown: true}
+	if base != nil {
+		sites = r.allocSitesOf(base)
+	}
+	for _, store := range r.fieldStores[field] {
+		if sites.mayAlias(r.allocSitesOf(store.base)) {
+			c.flow(store.val)
+		}
+	}
+}
+
+// addValueField adds the targets of the function value read from the field of the struct value x.
+// If the values of the field cannot be determined from the definitions of x (see
+// valueFieldValues), all the stores into the field are considered.
+func (r *resolver) addValueField(c *contribution, x ssa.Value, field *types.Var) {
+	if field != nil {
+		if vals, ok := r.valueFieldValues(x, field, make(map[ssa.Value]bool)); ok {
+			for _, val := range vals {
+				c.flow(val)
+			}
+			return
+		}
+	}
+	r.addField(c, field, nil)
+}
+
+// localFieldValues returns the values stored into the field of the local struct variable (or
+// composite literal) alloc, either directly or as part of the struct values assigned to it as a
+// whole, e.g., the param `o` of an unexported function called with `Opts{OnError: f}` (which is
+// spilled to a local variable in the SSA form). It returns false if the field may be set
+// elsewhere, e.g., if the address of the variable escapes, or if any of the struct values cannot
+// be resolved within the package. The struct values visited are skipped to handle cycles.
+func (r *resolver) localFieldValues(alloc *ssa.Alloc, field *types.Var, visited map[ssa.Value]bool) ([]ssa.Value, bool) {
+	if !r.isLocalStruct(alloc) {
+		return nil, false
+	}
+	var vals []ssa.Value
+	for _, store := range r.fieldStores[field] {
+		if store.base == alloc {
+			vals = append(vals, store.val)
+		}
+	}
+	for _, whole := range r.stores[alloc] {
+		wholeVals, ok := r.valueFieldValues(whole, field, visited)
+		if !ok {
+			return nil, false
+		}
+		vals = append(vals, wholeVals...)
+	}
+	return vals, true
+}
+
+// valueFieldValues returns the values of the field of the struct value v (see localFieldValues),
+// following the same definitions as the ones of the function values (see contribution).
+func (r *resolver) valueFieldValues(v ssa.Value, field *types.Var, visited map[ssa.Value]bool) ([]ssa.Value, bool) {
+	if visited[v] {
+		return nil, true
+	}
+	visited[v] = true
+
+	c := &contribution{}
+	switch v := v.(type) {
+	case *ssa.UnOp:
+		if alloc, ok := v.X.(*ssa.Alloc); ok && v.Op == token.MUL {
+			return r.localFieldValues(alloc, field, visited)
+		}
+		return nil, false
+	case *ssa.Phi:
+		for _, edge := range v.Edges {
+			c.flow(edge)
+		}
+	case *ssa.Parameter:
+		r.addParam(c, v)
+	case *ssa.Call:
+		r.addResult(c, v.Common(), 0)
+	case *ssa.Extract:
+		call, ok := v.Tuple.(*ssa.Call)
+		if !ok {
+			return nil, false
+		}
+		r.addResult(c, call.Common(), v.Index)
+	default:
+		return nil, false
+	}
+	if c.targets.IsUnresolved {
+		return nil, false
+	}
+
+	var vals []ssa.Value
+	for _, w := range c.flows {
+		wVals, ok := r.valueFieldValues(w, field, visited)
+		if !ok {
+			return nil, false
+		}
+		vals = append(vals, wVals...)
+	}
+	return vals, true
+}
+
+// isLocalStruct returns true if the local variable alloc is only assigned as a whole or via the
+// stores into its fields, i.e., neither its address nor the addresses of its fields escape.
+func (r *resolver) isLocalStruct(alloc *ssa.Alloc) bool {
+	refs := alloc.Referrers()
+	if alloc.Heap || refs == nil {
+		return false
+	}
+	for _, ref := range *refs {
+		switch ref := ref.(type) {
+		case *ssa.UnOp, *ssa.DebugRef:
+			// Loads of the variable.
+		case *ssa.Store:
+			if ref.Addr != alloc {
+				return false
+			}
+		case *ssa.FieldAddr:
+			fieldRefs := ref.Referrers()
+			if fieldRefs == nil {
+				continue
+			}
+			for _, fieldRef := range *fieldRefs {
+				switch fieldRef := fieldRef.(type) {
+				case *ssa.UnOp, *ssa.DebugRef:
+					// Loads of the field.
+				case *ssa.Store:
+					if fieldRef.Addr != ref {
+						return false
+					}
+				default:
+					return false
+				}
+			}
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// allocSitesOf returns the possible allocation sites of the objects the pointer v may point to.
+// The sites are unknown if any of them cannot be determined within the package, or if v is not
+// known to point to any object at all (e.g., a param of a function without callers).
+func (r *resolver) allocSitesOf(v ssa.Value) allocSites {
+	if sites, ok := r.allocMemo[v]; ok {
+		return sites
+	}
+	sites := allocSites{sites: make(map[*ssa.Alloc]bool)}
+	r.collectAllocSites(v, &sites, make(map[ssa.Value]bool))
+	if len(sites.sites) == 0 {
+		sites.isUnknown = true
+	}
+	r.allocMemo[v] = sites
+	return sites
+}
+
+// collectAllocSites adds the possible allocation sites of the pointer v to sites, following the
+// same definitions as the ones of the function values (see contribution).
+func (r *resolver) collectAllocSites(v ssa.Value, sites *allocSites, visited map[ssa.Value]bool) {
+	if visited[v] || sites.isUnknown {
+		return
+	}
+	visited[v] = true
+
+	c := &contribution{}
+	switch v := v.(type) {
+	case *ssa.Alloc:
+		sites.sites[v] = true
+		return
+	case *ssa.Phi:
+		for _, edge := range v.Edges {
+			c.flow(edge)
+		}
+	case *ssa.ChangeType:
+		c.flow(v.X)
+	case *ssa.UnOp:
+		switch v.X.(type) {
+		case *ssa.Alloc, *ssa.FreeVar:
+			r.addLocal(c, v.X)
+		default:
+			// The pointers loaded from globals, fields or other pointers are not tracked.
+			c.targets.IsUnresolved = true
+		}
+	case *ssa.FreeVar:
+		r.addFreeVar(c, v)
+	case *ssa.Parameter:
+		r.addParam(c, v)
+	case *ssa.Call:
+		r.addResult(c, v.Common(), 0)
+	case *ssa.Extract:
+		if call, ok := v.Tuple.(*ssa.Call); ok {
+			r.addResult(c, call.Common(), v.Index)
+		} else {
+			c.targets.IsUnresolved = true
+		}
+	default:
+		c.targets.IsUnresolved = true
+	}
+
+	if c.targets.IsUnresolved {
+		sites.isUnknown = true
+		return
+	}
+	for _, w := range c.flows {
+		r.collectAllocSites(w, sites, visited)
+	}
+}
+
+// addFreeVar adds the targets of the function value captured by value in the free variable fv.
+func (r *resolver) addFreeVar(c *contribution, fv *ssa.FreeVar) {
+	idx := freeVarIndex(fv)
+	closures := r.closures[origin(fv.Parent())]
+	if idx < 0 || len(closures) == 0 {
+		c.targets.IsUnresolved = true
+		return
+	}
+	for _, mc := range closures {
+		c.flow(mc.Bindings[idx])
+	}
+}
+
+// addParam adds the targets of the function value passed as the param p, which can only be
+// resolved if all the callers of the function are known.
+func (r *resolver) addParam(c *contribution, p *ssa.Parameter) {
+	fn := origin(p.Parent())
+	if r.escaped[fn] || fn.Signature.Recv() != nil ||
+		(fn.Parent() == nil && (fn.Object() == nil || fn.Object().Exported())) {
+		// The function may be called from unknown call sites (e.g., other packages or via
+		// interfaces), or used as a value.
+		c.targets.IsUnresolved = true
+		return
+	}
+	idx := -1
+	for i, param := range fn.Params {
+		if param == p {
+			idx = i
+		}
+	}
+	if idx < 0 {
+		c.targets.IsUnresolved = true
+		return
+	}
+	for _, call := range r.staticCalls[fn] {
+		if idx >= len(call.Args) {
+			c.targets.IsUnresolved = true
+			continue
+		}
+		c.flow(call.Args[idx])
+	}
+}
+
+// addResult adds the targets of the function value returned as the idx-th result of the call.
+func (r *resolver) addResult(c *contribution, call *ssa.CallCommon, idx int) {
+	callee := call.StaticCallee()
+	if callee == nil || call.IsInvoke() {
+		c.targets.IsUnresolved = true
+		return
+	}
+	callee = origin(callee)
+	if callee.Pkg == nil || callee.Pkg.Pkg != r.pkg || len(callee.Blocks) == 0 {
+		c.targets.IsUnresolved = true
+		return
+	}
+	for _, block := range callee.Blocks {
+		if ret, ok := block.Instrs[len(block.Instrs)-1].(*ssa.Return); ok && idx < len(ret.Results) {
+			c.flow(ret.Results[idx])
+		}
+	}
+}
+
+// merge adds the targets in other to t.
+func (t *Targets) merge(other *Targets) {
+	for _, f := range other.Funcs {
+		if !slices.Contains(t.Funcs, f) {
+			t.Funcs = append(t.Funcs, f)
+		}
+	}
+	for _, lit := range other.FuncLits {
+		if !slices.Contains(t.FuncLits, lit) {
+			t.FuncLits = append(t.FuncLits, lit)
+		}
+	}
+	t.IsUnresolved = t.IsUnresolved || other.IsUnresolved
+}
+
+// freeVarIndex returns the index of the free variable fv in its function, or -1 if not found.
+func freeVarIndex(fv *ssa.FreeVar) int {
+	for i, v := range fv.Parent().FreeVars {
+		if v == fv {
+			return i
+		}
+	}
+	return -1
+}
+
+// origin returns the generic function of an instantiation, or the function itself otherwise.
+func origin(fn *ssa.Function) *ssa.Function {
+	if o := fn.Origin(); o != nil {
+		return o
+	}
+	return fn
+}
+
+// fieldOf returns the idx-th field of the struct (or pointer to struct) type t, or nil if it is
+// not a struct type.
+func fieldOf(t types.Type, idx int) *types.Var {
+	t = typeshelper.CoreType(t)
+	if ptr, ok := t.(*types.Pointer); ok {
+		t = typeshelper.CoreType(ptr.Elem())
+	}
+	if s, ok := t.(*types.Struct); ok && idx < s.NumFields() {
+		return s.Field(idx).Origin()
+	}
+	return nil
+}
@@ -0,0 +1,166 @@
+//  Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package resolve contains the calls to function values to be resolved. Each function whose name
+// starts with "call" contains exactly one call to a function value.
+package resolve
+
+var dummy bool
+
+func foo() *int { return nil }
+
+func bar() *int { return new(int) }
+
+func callLocal() {
+	f := foo
+	f()
+}
+
+func callPhi() {
+	f := foo
+	if dummy {
+		f = bar
+	}
+	f()
+}
+
+func callFuncLit() {
+	f := foo
+	if dummy {
+		f = func() *int { return nil }
+	}
+	f()
+}
+
+func callCaptured() {
+	f := foo
+	func() {
+		f = bar
+	}()
+	f()
+}
+
+type S struct {
+	hook     func() *int
+	Exported func() *int
+}
+
+func newS() *S {
+	return &S{hook: foo, Exported: foo}
+}
+
+func callField(s *S) {
+	s.hook()
+}
+
+func callExportedField(s *S) {
+	s.Exported()
+}
+
+func newSWithHook(hook func() *int) *S {
+	return &S{hook: hook}
+}
+
+func newSWithBar() *S {
+	s := &S{}
+	s.hook = bar
+	return s
+}
+
+// Only the function values stored into the objects allocated by newSWithBar are considered.
+func callFieldPerAlloc() {
+	s := newSWithBar()
+	s.hook()
+}
+
+// The objects allocated by newSWithHook may store the function values passed by its callers.
+func callFieldViaParam() {
+	s := newSWithHook(bar)
+	s.hook()
+}
+
+type Opts struct {
+	OnError func() *int
+}
+
+// The exported field of the struct value can only be set by the composite literals passed by the
+// callers.
+func callValueField(o Opts) {
+	o.OnError()
+}
+
+func useValueField() {
+	callValueField(Opts{OnError: bar})
+}
+
+func apply(f func() *int) {
+	f()
+}
+
+func useApply() {
+	apply(foo)
+	apply(bar)
+}
+
+func Apply(f func() *int) {
+	f()
+}
+
+func (s *S) method() *int { return nil }
+
+func callMethodValue(s *S) {
+	m := s.method
+	m()
+}
+
+func callMap(m map[string]func() *int) {
+	f := m["a"]
+	f()
+}
+
+func getter() func() *int { return bar }
+
+func callResult() {
+	f := getter()
+	f()
+}
+
+var global = foo
+
+func callGlobal() {
+	global()
+}
+
+type cyclic struct {
+	a, b func() *int
+}
+
+func callCycleFirst(c *cyclic) {
+	c.a()
+}
+
+// The fields form a cycle, the targets must not depend on the order in which the calls are
+// resolved.
+func callCycleLater(c *cyclic) {
+	c.a = c.b
+	f := c.a
+	c.b = f
+	c.b = foo
+	f()
+}
+
+func callOnlyFuncLit() {
+	f := func() *int { return nil }
+	f()
+}
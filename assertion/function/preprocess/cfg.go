@@ -18,7 +18,9 @@ import (
 	"fmt"
 	"go/ast"
 	"go/token"
+	"go/types"
 
+	"go.uber.org/nilaway/assertion/function/functioncontracts"
 	"go.uber.org/nilaway/hook"
 	"go.uber.org/nilaway/util/asthelper"
 	"golang.org/x/tools/go/cfg"
@@ -42,6 +44,9 @@ import (
 // Canonicalize explicit boolean comparisons:
 // - replace `if x == true {T} {F}` with `if x {T} {F}`
 // - replace `if x == false {T} {F}` with `if !x {T} {F}`
+//
+// Splice deferred function literals:
+// - run the bodies of `defer func() {...}()` after every return statement (see spliceDeferredFuncLits)
 func (p *Preprocessor) CFG(graph *cfg.CFG, funcDecl *ast.FuncDecl) *cfg.CFG {
 	// The ASTs and CFGs are shared across all analyzers in the nogo framework, so we should never
 	// modify them directly. Here, we make a copy of the graph (and all blocks in it) and modify
@@ -51,6 +56,10 @@ func (p *Preprocessor) CFG(graph *cfg.CFG, funcDecl *ast.FuncDecl) *cfg.CFG {
 	// Important: add all new blocks to the end, don't try to "move around" any existing blocks
 	// because they're all referenced by index!
 
+	// Splice the bodies of the deferred function literals at the exits of the function first,
+	// such that the following transformations apply to them as well.
+	p.spliceDeferredFuncLits(graph, funcDecl)
+
 	// Create a failure block at the end of the blocks list to be used for trusted functions.
 	failureBlock := &cfg.Block{Index: int32(len(graph.Blocks))}
 	graph.Blocks = append(graph.Blocks, failureBlock)
@@ -264,6 +273,9 @@ func (p *Preprocessor) replaceConditional(graph *cfg.CFG, block *cfg.Block) {
 	}
 
 	replaced := hook.ReplaceConditional(p.pass, call)
+	if replaced == nil {
+		replaced = p.contractConditional(call)
+	}
 	if replaced == nil {
 		return
 	}
@@ -274,6 +286,92 @@ func (p *Preprocessor) replaceConditional(graph *cfg.CFG, block *cfg.Block) {
 	p.canonicalizeConditional(graph, block)
 }
 
+// contractConditional returns an equivalent expression for a call to a contracted function with a
+// single boolean result, where the contracts imply the nilability of an argument from the result.
+// A contract `contract(nil -> false)` states that the result is false if the argument is nil, so
+// the result being true implies that the argument is nonnil, and `f(x)` is replaced with
+// `f(x) && x != nil`. Similarly, for `contract(nil -> true)`, the result being false implies that
+// the argument is nonnil, so `f(x)` is replaced with `f(x) || x == nil`. The contracts with
+// `nonnil` inputs are handled in the same way with the opposite nil comparisons, and multiple
+// contracts are chained together.
+//
+// Only the contracts with exactly one `nil` or `nonnil` input are considered, since the opposite
+// of multiple inputs would be a disjunction over the arguments. The arguments are also required to
+// be identifiers or selectors, such that the nil comparisons do not duplicate any side effects.
+//
+// If the call does not match any such contract, nil is returned.
+//
+// nilable(result 0)
+func (p *Preprocessor) contractConditional(call *ast.CallExpr) ast.Expr {
+	ident := asthelper.FuncIdentFromCallExpr(call)
+	if ident == nil {
+		return nil
+	}
+	funcObj, ok := p.pass.TypesInfo.ObjectOf(ident).(*types.Func)
+	if !ok {
+		return nil
+	}
+
+	var replaced ast.Expr = call
+	for _, ctr := range p.funcContracts[funcObj] {
+		if len(ctr.Outs) != 1 || (ctr.Outs[0] != functioncontracts.True && ctr.Outs[0] != functioncontracts.False) {
+			continue
+		}
+		argIndex := -1
+		for i, in := range ctr.Ins {
+			if in != functioncontracts.Nil && in != functioncontracts.NonNil {
+				continue
+			}
+			if argIndex != -1 {
+				argIndex = -1
+				break
+			}
+			argIndex = i
+		}
+		if argIndex == -1 || argIndex >= len(call.Args) ||
+			(funcObj.Signature().Variadic() && argIndex == len(ctr.Ins)-1) {
+			continue
+		}
+		arg := ast.Unparen(call.Args[argIndex])
+		if _, ok := arg.(*ast.Ident); !ok {
+			if _, ok := arg.(*ast.SelectorExpr); !ok {
+				continue
+			}
+		}
+
+		// The contract states that the input implies the output, so the opposite of the output
+		// implies the opposite of the input.
+		argIsNil := ctr.Ins[argIndex] == functioncontracts.Nil
+		if ctr.Outs[0] == functioncontracts.False {
+			op := token.EQL
+			if argIsNil {
+				op = token.NEQ
+			}
+			replaced = &ast.BinaryExpr{X: replaced, Op: token.LAND, OpPos: call.Pos(), Y: nilComparison(arg, op)}
+		} else {
+			op := token.NEQ
+			if argIsNil {
+				op = token.EQL
+			}
+			replaced = &ast.BinaryExpr{X: replaced, Op: token.LOR, OpPos: call.Pos(), Y: nilComparison(arg, op)}
+		}
+	}
+	if replaced == call {
+		return nil
+	}
+	return replaced
+}
+
+// nilComparison creates a new binary expression "expr op nil".
+func nilComparison(expr ast.Expr, op token.Token) *ast.BinaryExpr {
+	return &ast.BinaryExpr{
+		X:     expr,
+		OpPos: expr.Pos(),
+		Op:    op,
+		Y:     &ast.Ident{NamePos: expr.Pos(), Name: "nil"},
+	}
+}
+
 // canonicalizeConditional canonicalizes the conditional CFG structures to make it easier to reason
 // about control flows later. For example, it rewrites
 // `if !cond {T} {F}` to `if cond {F} {T}` (swap successors), and rewrites
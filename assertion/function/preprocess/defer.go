@@ -0,0 +1,262 @@
+//  Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package preprocess
+
+import (
+	"go/ast"
+	"go/token"
+	"slices"
+
+	"golang.org/x/tools/go/analysis/passes/ctrlflow"
+	"golang.org/x/tools/go/cfg"
+)
+
+// _deferredExit is the synthetic return statement marking the exit of a function without named
+// results after all its deferred function literals have run (see spliceDeferredFuncLits). It
+// carries no results and is shared by all functions, so it must never be modified.
+var _deferredExit = &ast.ReturnStmt{}
+
+// IsDeferredExit returns true if the return statement is the synthetic exit of a function after
+// its deferred function literals, which has no effect other than marking the end of the function.
+func IsDeferredExit(ret *ast.ReturnStmt) bool {
+	return ret == _deferredExit
+}
+
+// DeferredFuncLit returns the function literal deferred by the defer statement if it can be
+// spliced into the CFG of the enclosing function (see spliceDeferredFuncLits), or nil otherwise.
+// Only function literals without parameters (e.g., `defer func() { ... }()`) can be spliced, the
+// other deferred calls are evaluated at the defer statements.
+func DeferredFuncLit(stmt *ast.DeferStmt) *ast.FuncLit {
+	funcLit, ok := ast.Unparen(stmt.Call.Fun).(*ast.FuncLit)
+	if !ok || funcLit.Body == nil || funcLit.Type.Params.NumFields() > 0 {
+		return nil
+	}
+	return funcLit
+}
+
+// DeferredFuncLits returns the spliced deferred function literals (see DeferredFuncLit) of the
+// function body in the order of their appearance. Only the function literals deferred
+// unconditionally are spliced, i.e., the ones whose defer statements are not nested in conditional
+// or loop statements (or nested function literals), the other ones are analyzed as standalone
+// functions called at their defer statements.
+func DeferredFuncLits(body *ast.BlockStmt) []*ast.FuncLit {
+	var funcLits []*ast.FuncLit
+	var visit func(stmt ast.Stmt)
+	visit = func(stmt ast.Stmt) {
+		switch s := stmt.(type) {
+		case *ast.BlockStmt:
+			for _, inner := range s.List {
+				visit(inner)
+			}
+		case *ast.LabeledStmt:
+			visit(s.Stmt)
+		case *ast.DeferStmt:
+			if funcLit := DeferredFuncLit(s); funcLit != nil {
+				funcLits = append(funcLits, funcLit)
+			}
+		}
+	}
+	visit(body)
+	return funcLits
+}
+
+// IsSplicedDeferStmt returns true if the function literal deferred by the defer statement in the
+// function body is spliced into the CFG of the function (see DeferredFuncLits).
+func IsSplicedDeferStmt(body *ast.BlockStmt, stmt *ast.DeferStmt) bool {
+	funcLit := DeferredFuncLit(stmt)
+	return funcLit != nil && slices.Contains(DeferredFuncLits(body), funcLit)
+}
+
+// spliceDeferredFuncLits splices the bodies of the deferred function literals (see
+// DeferredFuncLits) into the CFG at the exits of the function dominated by their defer statements,
+// such that they are analyzed as running after the return statements, e.g.,
+//
+//	func f() (r *int) {
+//	    defer func() { print(*r) }()
+//	    return nil
+//	}
+//
+// is analyzed as `r = nil; print(*r); return`. Specifically, every returning block of the function
+// is linked to a chain of the bodies of the function literals that must have been deferred when
+// reaching it (e.g., not the ones deferred after an early return), in reverse order of their
+// deferral, where the return statements inside the function literals are removed. For functions
+// without named results, the deferred function literals cannot modify the results, so the results
+// are consumed at the return statements and the chain ends at a synthetic exit block (see
+// IsDeferredExit). For functions with named results, the return statement is replaced by the
+// assignment of its results (if any) to the named results, which evaluates them only once, and
+// the named results are consumed at a bare return statement at the end of the chain instead, such
+// that the modifications by the function literals (e.g., a deferred `err = nil`) are taken into
+// account. The returning blocks without any deferred function literals to run are left as is.
+//
+// Functions with blank named results (e.g., `(_ *int, err error)`) are handled like the ones
+// without named results, since the values of blank results cannot be consumed by name.
+func (p *Preprocessor) spliceDeferredFuncLits(graph *cfg.CFG, funcDecl *ast.FuncDecl) {
+	if funcDecl.Body == nil {
+		return
+	}
+	funcLits := DeferredFuncLits(funcDecl.Body)
+	if len(funcLits) == 0 {
+		return
+	}
+
+	// Collect the returning blocks, and the blocks reachable without passing each defer statement,
+	// before appending any blocks.
+	var returns []*cfg.Block
+	for _, block := range graph.Blocks {
+		if block.Live && block.Return() != nil && len(block.Succs) == 0 {
+			returns = append(returns, block)
+		}
+	}
+	bypassed := make([]map[*cfg.Block]bool, len(funcLits))
+	for i, funcLit := range funcLits {
+		bypassed[i] = reachableWithout(graph, deferBlock(graph, funcLit))
+	}
+
+	cfgs := p.pass.ResultOf[ctrlflow.Analyzer].(*ctrlflow.CFGs)
+	// appendChain builds the chain backwards from the given exit of the returning block: the first
+	// deferred function literal runs last. It returns nil if no function literal is deferred when
+	// reaching the returning block.
+	appendChain := func(block *cfg.Block, exit *ast.ReturnStmt) *cfg.Block {
+		var next *cfg.Block
+		for i, funcLit := range funcLits {
+			litGraph := cfgs.FuncLit(funcLit)
+			if litGraph == nil || bypassed[i][block] {
+				continue
+			}
+			if next == nil {
+				next = appendBlock(graph, []ast.Node{exit})
+			}
+			next = appendFuncLitBlocks(graph, litGraph, next)
+		}
+		return next
+	}
+
+	namedResults, ok := namedResults(funcDecl)
+	for _, block := range returns {
+		if !ok {
+			if next := appendChain(block, _deferredExit); next != nil {
+				block.Succs = []*cfg.Block{next}
+			}
+			continue
+		}
+
+		ret := block.Return()
+		next := appendChain(block, &ast.ReturnStmt{Return: ret.Return})
+		if next == nil {
+			continue
+		}
+		nodes := make([]ast.Node, len(block.Nodes)-1, len(block.Nodes))
+		copy(nodes, block.Nodes)
+		if len(ret.Results) > 0 {
+			nodes = append(nodes, &ast.AssignStmt{
+				Lhs:    namedResults,
+				TokPos: ret.Pos(),
+				Tok:    token.ASSIGN,
+				Rhs:    ret.Results,
+			})
+		}
+		block.Nodes = nodes
+		block.Succs = []*cfg.Block{next}
+	}
+}
+
+// deferBlock returns the block of the graph containing the defer statement of the function
+// literal, or nil if not found.
+func deferBlock(graph *cfg.CFG, funcLit *ast.FuncLit) *cfg.Block {
+	for _, block := range graph.Blocks {
+		for _, node := range block.Nodes {
+			if stmt, ok := node.(*ast.DeferStmt); ok && DeferredFuncLit(stmt) == funcLit {
+				return block
+			}
+		}
+	}
+	return nil
+}
+
+// reachableWithout returns the blocks reachable from the entry of the graph without passing the
+// given block, i.e., the blocks not dominated by it. All blocks are reachable if it is nil.
+func reachableWithout(graph *cfg.CFG, without *cfg.Block) map[*cfg.Block]bool {
+	reachable := make(map[*cfg.Block]bool)
+	if len(graph.Blocks) == 0 || graph.Blocks[0] == without {
+		return reachable
+	}
+	worklist := []*cfg.Block{graph.Blocks[0]}
+	reachable[graph.Blocks[0]] = true
+	for len(worklist) > 0 {
+		block := worklist[len(worklist)-1]
+		worklist = worklist[:len(worklist)-1]
+		for _, succ := range block.Succs {
+			if succ != without && !reachable[succ] {
+				reachable[succ] = true
+				worklist = append(worklist, succ)
+			}
+		}
+	}
+	return reachable
+}
+
+// namedResults returns the named results of the function, or false if the function has unnamed
+// or blank results.
+func namedResults(funcDecl *ast.FuncDecl) ([]ast.Expr, bool) {
+	if funcDecl.Type.Results == nil {
+		return nil, false
+	}
+	var names []ast.Expr
+	for _, field := range funcDecl.Type.Results.List {
+		if len(field.Names) == 0 {
+			return nil, false
+		}
+		for _, name := range field.Names {
+			if name.Name == "_" {
+				return nil, false
+			}
+			names = append(names, name)
+		}
+	}
+	return names, len(names) > 0
+}
+
+// appendFuncLitBlocks appends copies of the blocks of the function literal's CFG to the graph, where
+// the return statements are removed and the returning blocks are linked to the given successor. It
+// returns the copied entry block.
+func appendFuncLitBlocks(graph *cfg.CFG, litGraph *cfg.CFG, succ *cfg.Block) *cfg.Block {
+	copiedBlocks := make(map[*cfg.Block]*cfg.Block, len(litGraph.Blocks))
+	for _, block := range litGraph.Blocks {
+		nodes := make([]ast.Node, len(block.Nodes))
+		copy(nodes, block.Nodes)
+		newBlock := appendBlock(graph, nodes)
+		newBlock.Live = block.Live
+		copiedBlocks[block] = newBlock
+	}
+	for _, block := range litGraph.Blocks {
+		newBlock := copiedBlocks[block]
+		if block.Return() != nil {
+			newBlock.Nodes = newBlock.Nodes[:len(newBlock.Nodes)-1]
+			newBlock.Succs = []*cfg.Block{succ}
+			continue
+		}
+		for _, s := range block.Succs {
+			newBlock.Succs = append(newBlock.Succs, copiedBlocks[s])
+		}
+	}
+	return copiedBlocks[litGraph.Blocks[0]]
+}
+
+// appendBlock appends a new live block with the given nodes and successors to the graph.
+func appendBlock(graph *cfg.CFG, nodes []ast.Node, succs ...*cfg.Block) *cfg.Block {
+	block := &cfg.Block{Nodes: nodes, Succs: succs, Index: int32(len(graph.Blocks)), Live: true}
+	graph.Blocks = append(graph.Blocks, block)
+	return block
+}
@@ -17,15 +17,19 @@
 package preprocess
 
 import (
+	"go.uber.org/nilaway/assertion/function/functioncontracts"
 	"go.uber.org/nilaway/util/analysishelper"
 )
 
 // Preprocessor handles different preprocessing logic for different types of input.
 type Preprocessor struct {
 	pass *analysishelper.EnhancedPass
+	// funcContracts stores the function contracts, which are used to refine the conditionals on
+	// the calls to the contracted functions with boolean results.
+	funcContracts functioncontracts.Map
 }
 
 // New returns a new Preprocessor.
-func New(pass *analysishelper.EnhancedPass) *Preprocessor {
-	return &Preprocessor{pass: pass}
+func New(pass *analysishelper.EnhancedPass, funcContracts functioncontracts.Map) *Preprocessor {
+	return &Preprocessor{pass: pass, funcContracts: funcContracts}
 }
@@ -17,7 +17,6 @@ package preprocess
 import (
 	"go/ast"
 	"go/types"
-	"slices"
 
 	"golang.org/x/tools/go/analysis/passes/ctrlflow"
 	"golang.org/x/tools/go/cfg"
@@ -60,8 +59,9 @@ func (p *Preprocessor) inlineTemplComponentFuncLit(graph *cfg.CFG, funcDecl *ast
 
 	cfgs := p.pass.ResultOf[ctrlflow.Analyzer].(*ctrlflow.CFGs)
 	// Now, we "inline" the function literal by replacing the CFG of the function with the CFG of
-	// the function literal.
-	graph.Blocks = slices.Clone(cfgs.FuncLit(funcLit).Blocks)
+	// the function literal. The CFG of the function literal is copied since it is shared with the
+	// analysis of the function literal itself.
+	graph.Blocks = copyGraph(cfgs.FuncLit(funcLit)).Blocks
 	for _, b := range graph.Blocks {
 		if !b.Live || len(b.Nodes) == 0 {
 			continue
@@ -77,6 +77,17 @@ func (p *Preprocessor) inlineTemplComponentFuncLit(graph *cfg.CFG, funcDecl *ast
 	}
 }
 
+// InlinedTemplComponentFuncLit returns the function literal of the templ component function that
+// is inlined into the CFG of the function (see inlineTemplComponentFuncLit), or nil if the function
+// is not a templ component function.
+func (p *Preprocessor) InlinedTemplComponentFuncLit(funcDecl *ast.FuncDecl) *ast.FuncLit {
+	funcLit, returnStmt := p.extractTemplComponentFuncLit(funcDecl)
+	if returnStmt == nil {
+		return nil
+	}
+	return funcLit
+}
+
 func (p *Preprocessor) extractTemplComponentFuncLit(funcDecl *ast.FuncDecl) (*ast.FuncLit, *ast.ReturnStmt) {
 	// Check if the function returns a single result of type `templ.Component`.
 	if funcDecl == nil || funcDecl.Type == nil || funcDecl.Type.Results == nil || len(funcDecl.Type.Results.List) != 1 {
@@ -45,6 +45,7 @@ func run(p *analysis.Pass) ([]annotation.FullTrigger, error) {
 		return nil, nil
 	}
 
+	initialized := InitializedGlobals(pass, conf)
 	var fullTriggers []annotation.FullTrigger
 	for _, file := range pass.Files {
 		if !conf.IsFileInScope(file) {
@@ -57,7 +58,7 @@ func run(p *analysis.Pass) ([]annotation.FullTrigger, error) {
 				continue
 			}
 			for _, spec := range genDecl.Specs {
-				fullTriggers = append(fullTriggers, analyzeValueSpec(pass, spec.(*ast.ValueSpec))...)
+				fullTriggers = append(fullTriggers, analyzeValueSpec(pass, spec.(*ast.ValueSpec), initialized)...)
 			}
 		}
 	}
@@ -25,8 +25,12 @@ import (
 	"go.uber.org/nilaway/util/typeshelper"
 )
 
-// analyzeValueSpec returns full triggers corresponding to the declaration
-func analyzeValueSpec(pass *analysishelper.EnhancedPass, spec *ast.ValueSpec) []annotation.FullTrigger {
+// analyzeValueSpec returns full triggers corresponding to the declaration. The variables in
+// `initialized` are assigned in the `init()` functions (see InitializedGlobals), hence they are not
+// considered uninitialized even if the declaration does not have an initializer. However, the
+// package-level initializers run before any `init()` function, so the reads of such variables in
+// the initializers still observe nil (see analyzeEarlyReads).
+func analyzeValueSpec(pass *analysishelper.EnhancedPass, spec *ast.ValueSpec, initialized map[*types.Var]bool) []annotation.FullTrigger {
 	var fullTriggers []annotation.FullTrigger
 
 	consumers := getGlobalConsumers(pass, spec)
@@ -40,16 +44,21 @@ func analyzeValueSpec(pass *analysishelper.EnhancedPass, spec *ast.ValueSpec) []
 		// Case: variables are not initialized
 		// All the variables in this case have same type
 		if len(spec.Values) == 0 {
+			// The value assigned in the `init()` functions flows to the variable instead, which is
+			// handled by the analysis of the `init()` functions.
+			if v, ok := pass.TypesInfo.ObjectOf(ident).(*types.Var); ok && initialized[v] {
+				continue
+			}
 			prod = &annotation.ProduceTrigger{
 				Annotation: &annotation.ProduceTriggerTautology{},
 				Expr:       ident,
 			}
 		} else if len(spec.Names) == len(spec.Values) {
 			// Case: variables are initialized and the assignment is 1-1
-			prod = getGlobalProducer(pass, spec, i, i)
+			prod = getGlobalProducer(pass, spec, i, i, initialized)
 		} else {
 			// Case: variables are initialized using a multiple return function
-			prod = getGlobalProducer(pass, spec, i, 0)
+			prod = getGlobalProducer(pass, spec, i, 0, initialized)
 		}
 
 		if prod != nil {
@@ -61,6 +70,49 @@ func analyzeValueSpec(pass *analysishelper.EnhancedPass, spec *ast.ValueSpec) []
 		}
 	}
 
+	fullTriggers = append(fullTriggers, analyzeEarlyReads(pass, spec, initialized)...)
+	return fullTriggers
+}
+
+// analyzeEarlyReads returns full triggers for the field accesses `v.f` in the initializers of the
+// declaration, where `v` is a pointer variable in `initialized` (see analyzeValueSpec). Since the
+// initializers run before the `init()` functions assigning `v`, `v` is still nil there and such
+// accesses always panic. The function literals are skipped since they may be invoked later, and the
+// reads in the functions called by the initializers are not tracked.
+func analyzeEarlyReads(pass *analysishelper.EnhancedPass, spec *ast.ValueSpec, initialized map[*types.Var]bool) []annotation.FullTrigger {
+	var fullTriggers []annotation.FullTrigger
+	for _, value := range spec.Values {
+		ast.Inspect(value, func(node ast.Node) bool {
+			switch n := node.(type) {
+			case *ast.FuncLit:
+				return false
+			case *ast.SelectorExpr:
+				ident, ok := ast.Unparen(n.X).(*ast.Ident)
+				if !ok {
+					return true
+				}
+				v, ok := pass.TypesInfo.ObjectOf(ident).(*types.Var)
+				if !ok || !initialized[v] || !typeshelper.IsDeeplyPtr(v.Type()) {
+					return true
+				}
+				if sel, ok := pass.TypesInfo.Selections[n]; !ok || sel.Kind() != types.FieldVal {
+					return true
+				}
+				fullTriggers = append(fullTriggers, annotation.FullTrigger{
+					Producer: &annotation.ProduceTrigger{
+						Annotation: &annotation.NoVarAssign{ProduceTriggerTautology: &annotation.ProduceTriggerTautology{}, VarObj: v},
+						Expr:       ident,
+					},
+					Consumer: &annotation.ConsumeTrigger{
+						Annotation: &annotation.FldAccess{ConsumeTriggerTautology: &annotation.ConsumeTriggerTautology{}, Sel: pass.TypesInfo.ObjectOf(n.Sel)},
+						Expr:       ident,
+						Guards:     guard.NoGuards(),
+					},
+				})
+			}
+			return true
+		})
+	}
 	return fullTriggers
 }
 
@@ -88,8 +140,9 @@ func getGlobalConsumers(pass *analysishelper.EnhancedPass, valspec *ast.ValueSpe
 }
 
 // Returns a producer in the cases: 1) func call 2) literal nil 3) another global var 4) struct field/method.
-// In all other cases, it returns nil.
-func getGlobalProducer(pass *analysishelper.EnhancedPass, valspec *ast.ValueSpec, lid int, rid int) *annotation.ProduceTrigger {
+// In all other cases, it returns nil. The variables in `initialized` are read before the `init()`
+// functions assign them (see analyzeValueSpec).
+func getGlobalProducer(pass *analysishelper.EnhancedPass, valspec *ast.ValueSpec, lid int, rid int, initialized map[*types.Var]bool) *annotation.ProduceTrigger {
 	switch rhs := valspec.Values[rid].(type) {
 	case *ast.CallExpr:
 		if ident, ok := rhs.Fun.(*ast.Ident); ok {
@@ -113,7 +166,7 @@ func getGlobalProducer(pass *analysishelper.EnhancedPass, valspec *ast.ValueSpec
 			}
 		}
 		// if rhs is another global
-		return getProducerForVar(pass, rhs)
+		return getProducerForVar(pass, rhs, initialized)
 	case *ast.SelectorExpr:
 		// Struct field access
 		return getProducerForField(pass, rhs.Sel)
@@ -122,12 +175,20 @@ func getGlobalProducer(pass *analysishelper.EnhancedPass, valspec *ast.ValueSpec
 	return nil
 }
 
-func getProducerForVar(pass *analysishelper.EnhancedPass, rhs *ast.Ident) *annotation.ProduceTrigger {
+func getProducerForVar(pass *analysishelper.EnhancedPass, rhs *ast.Ident, initialized map[*types.Var]bool) *annotation.ProduceTrigger {
 	rhsVar, ok := pass.TypesInfo.ObjectOf(rhs).(*types.Var)
 	if !ok || !annotation.VarIsGlobal(rhsVar) {
 		// If rhs is not a global variable (e.g., a constant), we ignore it.
 		return nil
 	}
+	if initialized[rhsVar] {
+		// The variable is only assigned in the `init()` functions, which run after this
+		// initializer, so it is still nil here.
+		return &annotation.ProduceTrigger{
+			Annotation: &annotation.NoVarAssign{ProduceTriggerTautology: &annotation.ProduceTriggerTautology{}, VarObj: rhsVar},
+			Expr:       rhs,
+		}
+	}
 
 	return &annotation.ProduceTrigger{
 		Annotation: &annotation.GlobalVarRead{
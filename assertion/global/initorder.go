@@ -0,0 +1,338 @@
+//  Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package global
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"go.uber.org/nilaway/annotation"
+	"go.uber.org/nilaway/config"
+	"go.uber.org/nilaway/util/analysishelper"
+	"go.uber.org/nilaway/util/typeshelper"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+// initFunc stores the package-level variables assigned in an `init()` function of the package.
+type initFunc struct {
+	decl *ast.FuncDecl
+	// file is the file declaring the function.
+	file *ast.File
+	// possible stores the variables that are (possibly) assigned anywhere in the function,
+	// including the ones that have their addresses taken.
+	possible map[*types.Var]bool
+	// hasCalls is true if the function has any call that may assign any of the variables (see
+	// CallMayAssignGlobals).
+	hasCalls bool
+}
+
+// initFuncs returns the `init()` functions of the package in the order they are executed, i.e.,
+// in the order of their declarations in the files as presented to the compiler (see "Package
+// initialization" in the Go spec). All files are considered regardless of the scope of the analysis,
+// since the assignments in the out-of-scope files still happen; callers that rely on the analysis
+// of the functions must filter them by their files.
+func initFuncs(pass *analysishelper.EnhancedPass) []initFunc {
+	var funcs []initFunc
+	for _, file := range pass.Files {
+		for _, decl := range file.Decls {
+			funcDecl, ok := decl.(*ast.FuncDecl)
+			if !ok || !IsInitFunc(funcDecl) {
+				continue
+			}
+			funcs = append(funcs, initFunc{
+				decl:     funcDecl,
+				file:     file,
+				possible: possiblyAssignedGlobals(pass, funcDecl.Body),
+				hasCalls: hasCalls(pass, funcDecl.Body),
+			})
+		}
+	}
+	return funcs
+}
+
+// IsInitFunc returns true if the function declaration is a package initialization function `init()`.
+func IsInitFunc(decl *ast.FuncDecl) bool {
+	return decl.Recv == nil && decl.Name.Name == "init" && decl.Body != nil
+}
+
+// InitializedGlobals returns the package-level variables that are declared without an initializer
+// but are assigned on all paths through one of the `init()` functions of the package. Since the
+// `init()` functions run before any other function in the package, such variables are not
+// considered uninitialized at their declarations; instead, the values assigned to them in the
+// `init()` functions determine their nilability. The variables that may be read by the functions
+// called before the assignments (in the same or a preceding `init()` function) are excluded, since
+// they are still nil there, e.g.,
+//
+//	var reg *T
+//	func init() { setup(); reg = new(T) }
+//	func setup() { print(reg.f) } // nil dereference
+//
+// Only the `init()` functions in the files in scope are considered, since the values assigned in
+// the other ones are not analyzed.
+func InitializedGlobals(pass *analysishelper.EnhancedPass, conf *config.Config) map[*types.Var]bool {
+	initialized := make(map[*types.Var]bool)
+	reads := newCalleeReads(pass)
+	for _, f := range initFuncs(pass) {
+		// Only the top-level statements preceding any (possibly) returning statement assign the
+		// variables on all paths.
+		for _, stmt := range f.decl.Body.List {
+			if mayReturn(stmt) {
+				break
+			}
+			// The calls in the statement (e.g., on the right-hand side) run before its assignments.
+			reads.addCalls(stmt)
+			assign, ok := stmt.(*ast.AssignStmt)
+			if !ok || assign.Tok != token.ASSIGN || !conf.IsFileInScope(f.file) {
+				continue
+			}
+			for _, lhs := range assign.Lhs {
+				if v := globalOf(pass, lhs); v != nil && !reads.mayRead(v) {
+					initialized[v] = true
+				}
+			}
+		}
+		// All the calls in the function run before the following `init()` functions.
+		reads.addCalls(f.decl.Body)
+	}
+	return initialized
+}
+
+// calleeReads stores the package-level variables of the current package that may be read by the
+// functions of the package called (directly or transitively) from the added nodes.
+type calleeReads struct {
+	pass *analysishelper.EnhancedPass
+	// decls maps the functions of the package to their declarations.
+	decls map[*types.Func]*ast.FuncDecl
+	// visited stores the functions whose bodies are already added.
+	visited map[*types.Func]bool
+	// vars stores the variables referenced by the called functions.
+	vars map[*types.Var]bool
+	// all is true if any of the calls has an unknown target (e.g., a function value), which may
+	// read any variable.
+	all bool
+}
+
+func newCalleeReads(pass *analysishelper.EnhancedPass) *calleeReads {
+	decls := make(map[*types.Func]*ast.FuncDecl)
+	for _, file := range pass.Files {
+		for _, decl := range file.Decls {
+			funcDecl, ok := decl.(*ast.FuncDecl)
+			if !ok || funcDecl.Body == nil {
+				continue
+			}
+			if fn, ok := pass.TypesInfo.Defs[funcDecl.Name].(*types.Func); ok {
+				decls[fn] = funcDecl
+			}
+		}
+	}
+	return &calleeReads{
+		pass:    pass,
+		decls:   decls,
+		visited: make(map[*types.Func]bool),
+		vars:    make(map[*types.Var]bool),
+	}
+}
+
+// mayRead returns true if the variable may be read by any of the called functions.
+func (c *calleeReads) mayRead(v *types.Var) bool {
+	return c.all || c.vars[v]
+}
+
+// addCalls adds the functions called in the node (see CallMayAssignGlobals), ignoring the calls in
+// function literals, since they may be invoked only after the package initialization.
+func (c *calleeReads) addCalls(node ast.Node) {
+	ast.Inspect(node, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.CallExpr:
+			c.addCall(n)
+		}
+		return !c.all
+	})
+}
+
+// addCall adds the function called by the call, along with the variables referenced and the
+// functions called in its body (including the function literals, which it may invoke).
+func (c *calleeReads) addCall(call *ast.CallExpr) {
+	if !CallMayAssignGlobals(c.pass, call) {
+		return
+	}
+	callee := typeutil.StaticCallee(c.pass.TypesInfo, call)
+	if callee == nil {
+		c.all = true
+		return
+	}
+	callee = callee.Origin()
+	decl, ok := c.decls[callee]
+	if !ok || c.visited[callee] {
+		return
+	}
+	c.visited[callee] = true
+	ast.Inspect(decl.Body, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.Ident:
+			if v := globalOf(c.pass, n); v != nil {
+				c.vars[v] = true
+			}
+		case *ast.CallExpr:
+			c.addCall(n)
+		}
+		return !c.all
+	})
+}
+
+// UnsetGlobalsAtEntry returns the package-level variables of nilable types that still hold their
+// zero values (i.e., nil) at the entry of the given `init()` function: the ones that are declared
+// without an initializer and are not assigned in any `init()` function running before it. If any
+// of those `init()` functions has a call that may assign any variable (see CallMayAssignGlobals),
+// no variable is considered unset. It returns nil if the given function is not an `init()` function.
+func UnsetGlobalsAtEntry(pass *analysishelper.EnhancedPass, decl *ast.FuncDecl) map[*types.Var]bool {
+	if !IsInitFunc(decl) {
+		return nil
+	}
+
+	unset := make(map[*types.Var]bool)
+	for _, file := range pass.Files {
+		for _, d := range file.Decls {
+			genDecl, ok := d.(*ast.GenDecl)
+			if !ok || genDecl.Tok != token.VAR {
+				continue
+			}
+			for _, spec := range genDecl.Specs {
+				spec := spec.(*ast.ValueSpec)
+				if len(spec.Values) != 0 {
+					continue
+				}
+				for _, name := range spec.Names {
+					v, ok := pass.TypesInfo.ObjectOf(name).(*types.Var)
+					if ok && !typeshelper.TypeBarsNilness(v.Type()) {
+						unset[v] = true
+					}
+				}
+			}
+		}
+	}
+
+	for _, f := range initFuncs(pass) {
+		if f.decl == decl {
+			break
+		}
+		if f.hasCalls {
+			return make(map[*types.Var]bool)
+		}
+		for v := range f.possible {
+			delete(unset, v)
+		}
+	}
+	return unset
+}
+
+// possiblyAssignedGlobals returns the package-level variables of the current package that are
+// assigned or have their addresses taken anywhere in the body. The function literals are skipped
+// since they may be invoked only after the package initialization.
+func possiblyAssignedGlobals(pass *analysishelper.EnhancedPass, body *ast.BlockStmt) map[*types.Var]bool {
+	assigned := make(map[*types.Var]bool)
+	add := func(expr ast.Expr) {
+		if v := globalOf(pass, expr); v != nil {
+			assigned[v] = true
+		}
+	}
+	ast.Inspect(body, func(node ast.Node) bool {
+		switch n := node.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.AssignStmt:
+			for _, lhs := range n.Lhs {
+				add(lhs)
+			}
+		case *ast.RangeStmt:
+			if n.Key != nil {
+				add(n.Key)
+			}
+			if n.Value != nil {
+				add(n.Value)
+			}
+		case *ast.UnaryExpr:
+			if n.Op == token.AND {
+				add(n.X)
+			}
+		}
+		return true
+	})
+	return assigned
+}
+
+// CallMayAssignGlobals returns true if the call may assign the package-level variables of the
+// current package, i.e., it calls a function of the current package, or a function value (or an
+// interface method) whose target is unknown. The builtins, the type conversions and the functions
+// of the other packages are excluded, since the other packages can only assign the variables
+// through their addresses.
+func CallMayAssignGlobals(pass *analysishelper.EnhancedPass, call *ast.CallExpr) bool {
+	if tv, ok := pass.TypesInfo.Types[call.Fun]; ok && (tv.IsType() || tv.IsBuiltin()) {
+		return false
+	}
+	callee := typeutil.StaticCallee(pass.TypesInfo, call)
+	return callee == nil || callee.Pkg() == pass.Pkg
+}
+
+// hasCalls returns true if the body has any call that may assign the package-level variables of
+// the current package (see CallMayAssignGlobals), ignoring the calls in function literals.
+func hasCalls(pass *analysishelper.EnhancedPass, body *ast.BlockStmt) bool {
+	found := false
+	ast.Inspect(body, func(node ast.Node) bool {
+		switch n := node.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.CallExpr:
+			found = CallMayAssignGlobals(pass, n)
+		}
+		return !found
+	})
+	return found
+}
+
+// mayReturn returns true if the statement contains a return statement, ignoring the ones in
+// function literals.
+func mayReturn(stmt ast.Stmt) bool {
+	found := false
+	ast.Inspect(stmt, func(node ast.Node) bool {
+		switch node.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.ReturnStmt:
+			found = true
+		}
+		return !found
+	})
+	return found
+}
+
+// globalOf returns the package-level variable of the current package that the expression
+// (possibly parenthesized) refers to, or nil if it does not refer to one.
+//
+// nilable(result 0)
+func globalOf(pass *analysishelper.EnhancedPass, expr ast.Expr) *types.Var {
+	ident, ok := ast.Unparen(expr).(*ast.Ident)
+	if !ok {
+		return nil
+	}
+	v, ok := pass.TypesInfo.ObjectOf(ident).(*types.Var)
+	if !ok || v.Pkg() != pass.Pkg || !annotation.VarIsGlobal(v) {
+		return nil
+	}
+	return v
+}
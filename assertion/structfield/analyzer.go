@@ -40,7 +40,7 @@ func run(p *analysis.Pass) (*FieldContext, error) {
 	pass := analysishelper.NewEnhancedPass(p)
 	conf := pass.ResultOf[config.Analyzer].(*config.Config)
 
-	fieldContext := &FieldContext{fieldMap: make(relevantFieldsMap)}
+	fieldContext := newFieldContext()
 
 	if !conf.IsPkgInScope(pass.Pkg) {
 		return fieldContext, nil
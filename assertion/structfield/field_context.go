@@ -34,9 +34,22 @@ const (
 // relevantFieldsMap is a type to store the assigned/accessed fields of a struct in a function represented by the ParamAnnotationKey
 type relevantFieldsMap map[annotation.ParamAnnotationKey]map[string]fieldUse
 
+// relevantResultFieldsMap is a type to store the fields assigned on a local struct that is returned by a function at the
+// result represented by the RetAnnotationKey
+type relevantResultFieldsMap map[annotation.RetAnnotationKey]map[string]bool
+
 // FieldContext stores field information (i.e., assignment and/or access) collected by parsing a function
 type FieldContext struct {
-	fieldMap relevantFieldsMap
+	fieldMap       relevantFieldsMap
+	resultFieldMap relevantResultFieldsMap
+}
+
+// newFieldContext returns a new, empty FieldContext
+func newFieldContext() *FieldContext {
+	return &FieldContext{
+		fieldMap:       make(relevantFieldsMap),
+		resultFieldMap: make(relevantResultFieldsMap),
+	}
 }
 
 // IsFieldUsedInFunc returns true if the passed `fieldName` of struct at index `param` is found to be direct used in the function `funcDecl` for assignment or access
@@ -51,6 +64,13 @@ func (f *FieldContext) IsFieldUsedInFunc(funcDecl *types.Func, param int, fieldN
 	return false
 }
 
+// IsFieldAssignedForResult returns true if the passed `fieldName` is found to be directly assigned in the function
+// `funcDecl` on a variable that is then returned as the result at index `result`, e.g., `s.f = v; return s`
+func (f *FieldContext) IsFieldAssignedForResult(funcDecl *types.Func, result int, fieldName string) bool {
+	r := annotation.RetAnnotationKey{FuncDecl: funcDecl, RetNum: result}
+	return f.resultFieldMap[r][fieldName]
+}
+
 // addEntry adds a new entry in the map for a field that was assigned or accessed
 func (f *FieldContext) addEntry(funcDecl *types.Func, param int, fieldName string, use fieldUse) {
 	p := annotation.ParamAnnotationKey{FuncDecl: funcDecl, ParamNum: param}
@@ -76,9 +96,19 @@ func (f *FieldContext) processFunc(funcDecl *ast.FuncDecl, pass *analysishelper.
 	funcObj := pass.TypesInfo.ObjectOf(funcDecl.Name).(*types.Func)
 	sig := funcObj.Type().(*types.Signature)
 
+	// assignedFields stores the fields assigned on each non-global variable, to be matched with the returned variables
+	assignedFields := make(map[*types.Var]map[string]bool)
+
 	for _, fldRefUse := range fieldRefUseList {
 		// if fldRefUse.x is a receiver or parameter, then add field name (fldRefUse.field.Name) to the map
 		if selX, ok := pass.TypesInfo.ObjectOf(fldRefUse.x).(*types.Var); ok {
+			if fldRefUse.use == Assigned && !annotation.VarIsGlobal(selX) {
+				if _, ok := assignedFields[selX]; !ok {
+					assignedFields[selX] = make(map[string]bool)
+				}
+				assignedFields[selX][fldRefUse.field.Name] = true
+			}
+
 			// match with params
 			for i := 0; i < sig.Params().Len(); i++ {
 				if sig.Params().At(i) == selX {
@@ -92,6 +122,70 @@ func (f *FieldContext) processFunc(funcDecl *ast.FuncDecl, pass *analysishelper.
 			}
 		}
 	}
+
+	if len(assignedFields) == 0 {
+		return
+	}
+
+	// match the variables with assigned fields against the results of the function
+	for _, returned := range collectReturnedVars(funcDecl, sig, pass) {
+		fields, ok := assignedFields[returned.v]
+		if !ok {
+			continue
+		}
+		r := annotation.RetAnnotationKey{FuncDecl: funcObj, RetNum: returned.result}
+		if _, ok := f.resultFieldMap[r]; !ok {
+			f.resultFieldMap[r] = make(map[string]bool)
+		}
+		for name := range fields {
+			f.resultFieldMap[r][name] = true
+		}
+	}
+}
+
+// returnedVar stores a variable `v` that is returned by a function as the result at index `result`
+type returnedVar struct {
+	v      *types.Var
+	result int
+}
+
+// collectReturnedVars walks over the return statements of the function `f` (ignoring the ones of nested function
+// literals) to collect the variables that are directly returned, either explicitly (e.g., `return s`) or implicitly
+// as named results by a bare `return`.
+func collectReturnedVars(f *ast.FuncDecl, sig *types.Signature, pass *analysishelper.EnhancedPass) []returnedVar {
+	var returned []returnedVar
+	if f.Body == nil {
+		return returned
+	}
+
+	ast.Inspect(f.Body, func(n ast.Node) bool {
+		switch node := n.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.ReturnStmt:
+			if len(node.Results) == 0 {
+				for i := 0; i < sig.Results().Len(); i++ {
+					returned = append(returned, returnedVar{v: sig.Results().At(i), result: i})
+				}
+				return false
+			}
+			if len(node.Results) != sig.Results().Len() {
+				// multiply-returning call (e.g., `return g()`)
+				return false
+			}
+			for i, res := range node.Results {
+				if ident, ok := ast.Unparen(res).(*ast.Ident); ok {
+					if v, ok := pass.TypesInfo.ObjectOf(ident).(*types.Var); ok {
+						returned = append(returned, returnedVar{v: v, result: i})
+					}
+				}
+			}
+			return false
+		}
+		return true
+	})
+
+	return returned
 }
 
 // fieldRefUse stores the selector expression `x.field`, where `x` and `field` are of the type *ast.Ident and `use` indicates how `x.field` was used in the function, assigned or accessed
@@ -0,0 +1,276 @@
+//  Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"strings"
+
+	"go.uber.org/nilaway/accumulation"
+	"go.uber.org/nilaway/annotation"
+	"go.uber.org/nilaway/config"
+	"go.uber.org/nilaway/inference"
+	"golang.org/x/tools/go/analysis"
+)
+
+const _doc = "Run NilAway inference on this package and suggest fixes that insert (or update) the " +
+	"`// nilable(...)` annotations of the declarations whose sites are inferred nilable"
+
+// Analyzer suggests annotating the parameters, results and receivers of functions and methods,
+// the fields of struct types and the global variables that NilAway infers nilable with the
+// doc-comment annotations the annotation parser understands, such that the inferred contracts are
+// frozen in the source code. Sites that are already annotated, or that are nilable by default
+// (e.g., slices and errors), are not annotated.
+var Analyzer = &analysis.Analyzer{
+	Name:     "nilaway_annotate",
+	Doc:      _doc,
+	Run:      run,
+	Requires: []*analysis.Analyzer{config.Analyzer, accumulation.Analyzer},
+}
+
+// _exportedOnly is a flag for only annotating the declarations visible to other packages.
+var _exportedOnly bool
+
+func init() {
+	Analyzer.Flags.BoolVar(&_exportedOnly, "exported-only", false, "Only annotate the exported functions, methods, struct fields and global variables, i.e., the boundaries of the packages.")
+}
+
+func run(pass *analysis.Pass) (interface{}, error) {
+	conf := pass.ResultOf[config.Analyzer].(*config.Config)
+	inferredMap := pass.ResultOf[accumulation.Analyzer].(*accumulation.Result).InferredMap
+	if inferredMap == nil {
+		// The package is not analyzed.
+		return nil, nil
+	}
+
+	a := &annotator{pass: pass, inferredMap: inferredMap}
+	for _, file := range pass.Files {
+		// Contracts of test files are not interesting to freeze.
+		if !conf.IsFileInScope(file) || strings.HasSuffix(pass.Fset.File(file.Pos()).Name(), "_test.go") {
+			continue
+		}
+		for _, decl := range file.Decls {
+			switch decl := decl.(type) {
+			case *ast.FuncDecl:
+				a.annotate(decl, decl.Doc, a.funcIdents(decl))
+			case *ast.GenDecl:
+				// Follow the placement rules of the annotation parser: the annotations of a spec
+				// are read from the doc comment of the declaration if it only has a single spec.
+				for _, spec := range decl.Specs {
+					var (
+						node ast.Node = spec
+						doc  *ast.CommentGroup
+					)
+					switch spec := spec.(type) {
+					case *ast.ValueSpec:
+						doc = spec.Doc
+					case *ast.TypeSpec:
+						doc = spec.Doc
+					}
+					if len(decl.Specs) == 1 {
+						node, doc = decl, decl.Doc
+					}
+					switch spec := spec.(type) {
+					case *ast.ValueSpec:
+						if decl.Tok == token.VAR {
+							a.annotate(node, doc, a.globalIdents(spec))
+						}
+					case *ast.TypeSpec:
+						if s, ok := spec.Type.(*ast.StructType); ok {
+							a.annotate(node, doc, a.fieldIdents(spec, s))
+						}
+					}
+				}
+			}
+		}
+	}
+	return nil, nil
+}
+
+// annotator computes the annotations of the declarations in a package from its inferred map.
+type annotator struct {
+	pass        *analysis.Pass
+	inferredMap *inference.InferredMap
+}
+
+// funcIdents returns the identifiers in the parameters, results and receiver of the function
+// declaration to be annotated as nilable.
+func (a *annotator) funcIdents(decl *ast.FuncDecl) []string {
+	funcObj, ok := a.pass.TypesInfo.Defs[decl.Name].(*types.Func)
+	if !ok || (_exportedOnly && !isExportedFunc(funcObj)) {
+		return nil
+	}
+	sig := funcObj.Signature()
+
+	var idents []string
+	if recv := sig.Recv(); recv != nil && isNamed(recv) {
+		idents = append(idents, a.idents(&annotation.RecvAnnotationKey{FuncDecl: funcObj}, recv.Name(), recv.Type())...)
+	}
+	for i := 0; i < sig.Params().Len(); i++ {
+		// Variadic parameters are treated as their element types by the annotation parser, but
+		// the inferred sites describe the slices.
+		if sig.Variadic() && i == sig.Params().Len()-1 {
+			continue
+		}
+		param := sig.Params().At(i)
+		if name, ok := siteName(param, "param %d", i); ok {
+			idents = append(idents, a.idents(annotation.ParamKeyFromArgNum(funcObj, i), name, param.Type())...)
+		}
+	}
+	for i := 0; i < sig.Results().Len(); i++ {
+		result := sig.Results().At(i)
+		if name, ok := siteName(result, "result %d", i); ok {
+			idents = append(idents, a.idents(annotation.RetKeyFromRetNum(funcObj, i), name, result.Type())...)
+		}
+	}
+	return idents
+}
+
+// globalIdents returns the identifiers of the global variables in the spec to be annotated as
+// nilable.
+func (a *annotator) globalIdents(spec *ast.ValueSpec) []string {
+	var idents []string
+	for _, name := range spec.Names {
+		v, ok := a.pass.TypesInfo.Defs[name].(*types.Var)
+		if !ok || !isNamed(v) || (_exportedOnly && !v.Exported()) {
+			continue
+		}
+		idents = append(idents, a.idents(&annotation.GlobalVarAnnotationKey{VarDecl: v}, v.Name(), v.Type())...)
+	}
+	return idents
+}
+
+// fieldIdents returns the identifiers of the fields of the struct type to be annotated as nilable.
+func (a *annotator) fieldIdents(spec *ast.TypeSpec, s *ast.StructType) []string {
+	if _exportedOnly && !spec.Name.IsExported() {
+		return nil
+	}
+	var idents []string
+	for _, field := range s.Fields.List {
+		for _, name := range field.Names {
+			v, ok := a.pass.TypesInfo.Defs[name].(*types.Var)
+			if !ok || !isNamed(v) || (_exportedOnly && !v.Exported()) {
+				continue
+			}
+			idents = append(idents, a.idents(&annotation.FieldAnnotationKey{FieldDecl: v}, v.Name(), v.Type())...)
+		}
+	}
+	return idents
+}
+
+// idents returns the shallow and deep forms of the name (e.g., "x" and "*x") to be annotated as
+// nilable, depending on the inferred nilabilities of the shallow and deep sites of the given key.
+func (a *annotator) idents(key annotation.Key, name string, t types.Type) []string {
+	var idents []string
+	if !annotation.TypeIsDefaultNilable(t) && a.inferredNilable(key, false /* isDeep */) {
+		idents = append(idents, name)
+	}
+
+	var deepName string
+	switch types.Unalias(t).(type) {
+	case *types.Pointer:
+		deepName = "*" + name
+	case *types.Slice, *types.Map:
+		deepName = name + "[]"
+	case *types.Chan:
+		deepName = "<-" + name
+	}
+	if deepName != "" && !annotation.TypeIsDeepDefaultNilable(t) && a.inferredNilable(key, true /* isDeep */) {
+		idents = append(idents, deepName)
+	}
+	return idents
+}
+
+// inferredNilable returns true if the site of the key is inferred nilable, but not annotated so.
+func (a *annotator) inferredNilable(key annotation.Key, isDeep bool) bool {
+	explained, ok := a.inferredMap.Determined(key, isDeep)
+	if !ok || !explained.Val() {
+		return false
+	}
+	_, annotated := explained.(inference.TrueBecauseAnnotation)
+	return !annotated
+}
+
+// annotate reports a diagnostic on the node with a suggested fix that annotates the identifiers as
+// nilable. The fix updates the existing nilable annotation in the doc comment if there is one,
+// otherwise it inserts a new annotation right above the node.
+func (a *annotator) annotate(node ast.Node, doc *ast.CommentGroup, idents []string) {
+	if len(idents) == 0 {
+		return
+	}
+
+	comment := annotation.FormatAnnotation(true /* nilable */, idents...)
+	edit := analysis.TextEdit{
+		Pos:     node.Pos(),
+		End:     node.Pos(),
+		NewText: []byte(comment + "\n" + strings.Repeat("\t", a.pass.Fset.Position(node.Pos()).Column-1)),
+	}
+	if doc != nil {
+		for _, c := range doc.List {
+			if nilable, existing, ok := annotation.ParseAnnotation(c.Text); ok && nilable {
+				comment = annotation.FormatAnnotation(true /* nilable */, append(existing, idents...)...)
+				edit = analysis.TextEdit{Pos: c.Pos(), End: c.End(), NewText: []byte(comment)}
+				break
+			}
+		}
+	}
+
+	message := fmt.Sprintf("inferred nilable: %s", strings.Join(idents, ", "))
+	a.pass.Report(analysis.Diagnostic{
+		Pos:     node.Pos(),
+		Message: message,
+		SuggestedFixes: []analysis.SuggestedFix{{
+			Message:   fmt.Sprintf("Annotate with `%s`", comment),
+			TextEdits: []analysis.TextEdit{edit},
+		}},
+	})
+}
+
+// siteName returns the name of the parameter or result in the annotations: its name if it is
+// named, otherwise its position (e.g., "result 0").
+func siteName(v *types.Var, format string, i int) (string, bool) {
+	if v.Name() == "" {
+		return fmt.Sprintf(format, i), true
+	}
+	return v.Name(), isNamed(v)
+}
+
+// isNamed returns true if the variable has a name that can be annotated, i.e., a name accepted by
+// the annotation parser (which, e.g., rejects blank names and names with underscores).
+func isNamed(v *types.Var) bool {
+	_, idents, ok := annotation.ParseAnnotation(annotation.FormatAnnotation(true /* nilable */, v.Name()))
+	return ok && len(idents) == 1 && idents[0] == v.Name()
+}
+
+// isExportedFunc returns true if the function, or the method and its receiver base type, are
+// exported.
+func isExportedFunc(f *types.Func) bool {
+	if !f.Exported() {
+		return false
+	}
+	recv := f.Signature().Recv()
+	if recv == nil {
+		return true
+	}
+	t := recv.Type()
+	if p, ok := t.(*types.Pointer); ok {
+		t = p.Elem()
+	}
+	named, ok := types.Unalias(t).(*types.Named)
+	return !ok || named.Obj().Exported()
+}
@@ -0,0 +1,40 @@
+//  Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestAnnotate(t *testing.T) {
+	t.Parallel()
+
+	testdata := analysistest.TestData()
+	analysistest.RunWithSuggestedFixes(t, testdata, Analyzer, "go.uber.org/annotate", "go.uber.org/annotate/annotated")
+}
+
+func TestIsFixFlag(t *testing.T) {
+	t.Parallel()
+
+	for _, arg := range []string{"-fix", "--fix=false"} {
+		require.True(t, isFixFlag(arg), arg)
+	}
+	for _, arg := range []string{"-fixes", "-diff", "./..."} {
+		require.False(t, isFixFlag(arg), arg)
+	}
+}
@@ -0,0 +1,55 @@
+//  Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// nilaway-annotate is a standalone tool that runs NilAway inference on the given packages, and
+// writes the inferred nilabilities back to the source code as `// nilable(...)` doc-comment
+// annotations (see Analyzer for details). This freezes the inferred contracts (e.g., on library
+// boundaries), such that later changes that flip them are reported as errors rather than silently
+// propagated. Running the tool again on the annotated code is a no-op.
+//
+// Usage:
+//
+//	nilaway-annotate [-diff] [-exported-only] [flags of nilaway] ./...
+//
+// With -diff, the changes are printed as unified diffs instead of being applied.
+package main
+
+import (
+	"flag"
+	"os"
+	"slices"
+	"strings"
+
+	"go.uber.org/nilaway/config"
+	"golang.org/x/tools/go/analysis/singlechecker"
+)
+
+func main() {
+	// Lift the flags from config.Analyzer to the top level, see cmd/nilaway for details.
+	config.Analyzer.Flags.VisitAll(func(f *flag.Flag) { flag.Var(f.Value, f.Name, f.Usage) })
+
+	// The singlechecker only applies the suggested fixes (or prints them as diffs with -diff) with
+	// -fix, which is what this tool is for, so we enable it unless explicitly specified.
+	if !slices.ContainsFunc(os.Args[1:], isFixFlag) {
+		os.Args = slices.Insert(os.Args, 1, "-fix")
+	}
+
+	singlechecker.Main(Analyzer)
+}
+
+// isFixFlag returns true if the command line argument is the -fix flag.
+func isFixFlag(arg string) bool {
+	name, _, _ := strings.Cut(strings.TrimPrefix(strings.TrimPrefix(arg, "-"), "-"), "=")
+	return name == "fix"
+}
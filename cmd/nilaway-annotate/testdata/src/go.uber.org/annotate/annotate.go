@@ -0,0 +1,62 @@
+//  Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package annotate tests the insertion and update of the annotations of the sites inferred nilable.
+package annotate
+
+var global *int // want "inferred nilable: global"
+
+var (
+	// other is documented.
+	other *int // want "inferred nilable: other"
+	safe  = new(int)
+)
+
+type A struct {
+	f *int
+}
+
+// Find looks up a value, the nilable result is annotated by its position.
+func Find(m map[string]*A, k string) *A { // want "inferred nilable: result 0"
+	return m[k]
+}
+
+// Named has a named result.
+func Named() (a *A, err error) { // want "inferred nilable: a"
+	return nil, nil
+}
+
+// nilable(a)
+func (a *A) Set(p *int, _ *int) { // want "inferred nilable: p"
+	// The existing annotation is updated.
+	a = &A{}
+	a.f = p
+}
+
+// Errors and slices are nilable by default, so they are not annotated.
+func defaults(b bool) ([]*int, error) {
+	if b {
+		return nil, nil
+	}
+	return []*int{new(int)}, nil
+}
+
+func caller() {
+	global = nil
+	other = nil
+	print(*safe)
+	var a A
+	a.Set(nil, nil)
+	defaults(true)
+}
@@ -0,0 +1,262 @@
+//  Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go/token"
+	"io"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"go.uber.org/nilaway/diagnostic"
+	"go.uber.org/nilaway/util/tokenhelper"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/checker"
+)
+
+// This file implements the baseline mode of the standalone checker, which allows adopting NilAway
+// on an existing code base without fixing all existing errors first: the errors at the time of
+// adoption are written to a baseline file, which is then used to suppress them in later runs such
+// that only new errors are reported.
+//
+// The errors are matched by the fingerprints of their nil flows (see [diagnostic.Flow.Fingerprint])
+// or notes (see [diagnostic.Note.Fingerprint]) rather than by their positions, such that the
+// matching survives unrelated edits to the code.
+
+// _baselineVersion is the version of the baseline file format.
+const _baselineVersion = 1
+
+// baseline is the on-disk format of a baseline file.
+type baseline struct {
+	Version int              `json:"version"`
+	Entries []*baselineEntry `json:"entries"`
+}
+
+// baselineEntry is a single known nil flow (or note) in the baseline. The file and function are recorded for
+// human readers (e.g., when pruning the baseline) only, the matching is done by the fingerprint.
+type baselineEntry struct {
+	Fingerprint string `json:"fingerprint"`
+	File        string `json:"file"`
+	Function    string `json:"function,omitempty"`
+	// Count is the number of errors sharing the fingerprint (e.g., multiple dereferences of the
+	// same nilable value in a function).
+	Count int `json:"count"`
+}
+
+// entries returns the baseline entries (each with a count of 1) a diagnostic consists of, i.e., one
+// for each of the reported nil flow and the similar ones grouped under it, or one for the note. It
+// returns nil for the other diagnostics (i.e., internal errors), which cannot be baselined.
+func entries(d diagnostic.Diagnostic) []*baselineEntry {
+	if d.Note != nil {
+		return []*baselineEntry{{
+			Fingerprint: d.Note.Fingerprint(),
+			File:        filepath.ToSlash(tokenhelper.RelToCwd(d.Note.Position.Filename)),
+			Count:       1,
+		}}
+	}
+	if d.Flow == nil {
+		return nil
+	}
+	var result []*baselineEntry
+	for _, f := range append([]*diagnostic.Flow{d.Flow}, d.Flow.Similar...) {
+		result = append(result, &baselineEntry{
+			Fingerprint: f.Fingerprint(),
+			File:        filepath.ToSlash(tokenhelper.RelToCwd(f.Position.Filename)),
+			Function:    f.Function,
+			Count:       1,
+		})
+	}
+	return result
+}
+
+// isBaselinable returns true if the diagnostic can be matched against a baseline, i.e., it is a
+// nil flow or a note.
+func isBaselinable(d diagnostic.Diagnostic) bool {
+	return d.Flow != nil || d.Note != nil
+}
+
+// diagnosticKey returns the key to deduplicate the diagnostics reported on the files that belong
+// to multiple packages (e.g., a package and its test variant).
+func diagnosticKey(d diagnostic.Diagnostic) string {
+	if d.Note != nil {
+		return fmt.Sprintf("%s:%s", d.Note.Position, d.Message)
+	}
+	return fmt.Sprintf("%s:%s", d.Flow.Position, d.Message)
+}
+
+// rootDiagnostics returns the deduplicated diagnostics with structured nil flows or notes of the
+// root actions in the graph that succeeded.
+func rootDiagnostics(roots []*checker.Action) []diagnostic.Diagnostic {
+	var diagnostics []diagnostic.Diagnostic
+	seen := make(map[string]bool)
+	for _, act := range roots {
+		if act.Err != nil {
+			continue
+		}
+		result, _ := act.Result.([]diagnostic.Diagnostic)
+		for _, d := range result {
+			if !isBaselinable(d) || seen[diagnosticKey(d)] {
+				continue
+			}
+			seen[diagnosticKey(d)] = true
+			diagnostics = append(diagnostics, d)
+		}
+	}
+	return diagnostics
+}
+
+// newBaseline creates a baseline containing all the given diagnostics.
+func newBaseline(diagnostics []diagnostic.Diagnostic) *baseline {
+	merged := make(map[string]*baselineEntry)
+	for _, d := range diagnostics {
+		for _, e := range entries(d) {
+			if m, ok := merged[e.Fingerprint]; ok {
+				m.Count++
+				continue
+			}
+			merged[e.Fingerprint] = e
+		}
+	}
+
+	// Sort the entries such that the file is stable across runs and friendly to diffs. Entries
+	// must be an array (not null) even if there are no entries.
+	b := &baseline{Version: _baselineVersion, Entries: append([]*baselineEntry{}, slices.Collect(maps.Values(merged))...)}
+	slices.SortFunc(b.Entries, func(x, y *baselineEntry) int {
+		return strings.Compare(x.File+"\x00"+x.Function+"\x00"+x.Fingerprint, y.File+"\x00"+y.Function+"\x00"+y.Fingerprint)
+	})
+	return b
+}
+
+// write writes the baseline in JSON format.
+func (b *baseline) write(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(b)
+}
+
+// writeBaselineFile writes the baseline to the given file.
+func writeBaselineFile(path string, b *baseline) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("create baseline file: %w", err)
+	}
+	if err := b.write(f); err != nil {
+		return errors.Join(fmt.Errorf("write baseline file %q: %w", path, err), f.Close())
+	}
+	return f.Close()
+}
+
+// loadBaselineFile reads the baseline from the given file, rejecting unknown keys and versions.
+func loadBaselineFile(path string) (*baseline, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("read baseline file: %w", err)
+	}
+	defer f.Close()
+
+	decoder := json.NewDecoder(f)
+	decoder.DisallowUnknownFields()
+	var b baseline
+	if err := decoder.Decode(&b); err != nil {
+		return nil, fmt.Errorf("parse baseline file %q: %w", path, err)
+	}
+	if b.Version != _baselineVersion {
+		return nil, fmt.Errorf("parse baseline file %q: unsupported version %d, expected %d", path, b.Version, _baselineVersion)
+	}
+	for i, e := range b.Entries {
+		if e == nil || e.Fingerprint == "" || e.Count <= 0 {
+			return nil, fmt.Errorf("parse baseline file %q: entry #%d must have a fingerprint and a positive count", path, i)
+		}
+	}
+	return &b, nil
+}
+
+// apply suppresses the diagnostics of the root actions that match the baseline, removing them from
+// both the reported diagnostics and the results of the actions. A diagnostic matches if all of its
+// nil flows (i.e., including the similar ones grouped under it), or its note, match entries in the
+// baseline, where each entry matches at most as many flows as its count. It returns the entries
+// (with the remaining counts) that no longer match any flow, which can be pruned from the baseline.
+func (b *baseline) apply(roots []*checker.Action) []*baselineEntry {
+	remaining := make(map[string]int)
+	for _, e := range b.Entries {
+		remaining[e.Fingerprint] += e.Count
+	}
+
+	suppressed := make(map[string]bool)
+	for _, d := range rootDiagnostics(roots) {
+		needed := make(map[string]int)
+		for _, e := range entries(d) {
+			needed[e.Fingerprint]++
+		}
+		if !allMatch(needed, remaining) {
+			continue
+		}
+		for fp, n := range needed {
+			remaining[fp] -= n
+		}
+		suppressed[diagnosticKey(d)] = true
+	}
+
+	for _, act := range roots {
+		if act.Err != nil {
+			continue
+		}
+		result, _ := act.Result.([]diagnostic.Diagnostic)
+		removed := make(map[analysisDiagnosticKey]bool)
+		act.Result = slices.DeleteFunc(result, func(d diagnostic.Diagnostic) bool {
+			if isBaselinable(d) && suppressed[diagnosticKey(d)] {
+				removed[analysisDiagnosticKey{d.Pos, d.Message}] = true
+				return true
+			}
+			return false
+		})
+		act.Diagnostics = slices.DeleteFunc(act.Diagnostics, func(d analysis.Diagnostic) bool {
+			return removed[analysisDiagnosticKey{d.Pos, d.Message}]
+		})
+	}
+
+	var stale []*baselineEntry
+	for _, e := range b.Entries {
+		if n := min(remaining[e.Fingerprint], e.Count); n > 0 {
+			remaining[e.Fingerprint] -= n
+			entry := *e
+			entry.Count = n
+			stale = append(stale, &entry)
+		}
+	}
+	return stale
+}
+
+// analysisDiagnosticKey identifies a reported diagnostic within a single action.
+type analysisDiagnosticKey struct {
+	pos     token.Pos
+	message string
+}
+
+// allMatch returns true if the remaining counts cover all the needed counts.
+func allMatch(needed, remaining map[string]int) bool {
+	for fp, n := range needed {
+		if remaining[fp] < n {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,160 @@
+//  Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/token"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/nilaway/config"
+	"go.uber.org/nilaway/diagnostic"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/checker"
+)
+
+func TestBaseline(t *testing.T) {
+	t.Parallel()
+
+	newDiagnostic := func(pos token.Pos, line int, function, repr string, similar ...*diagnostic.Flow) diagnostic.Diagnostic {
+		position := token.Position{Filename: "pkg/a.go", Line: line, Column: 2, Offset: line}
+		return diagnostic.Diagnostic{
+			Diagnostic: analysis.Diagnostic{Pos: pos, Message: repr},
+			Flow: &diagnostic.Flow{
+				Position:   position,
+				Function:   function,
+				NonnilPath: []diagnostic.FlowNode{{ConsumerPosition: position, ProducerRepr: repr, ConsumerRepr: "dereferenced"}},
+				Similar:    similar,
+			},
+		}
+	}
+	similar := &diagnostic.Flow{Position: token.Position{Filename: "pkg/a.go", Line: 9}, Function: "g"}
+	d1 := newDiagnostic(1, 3, "f", "result 0 of `f()`", similar)
+	d2 := newDiagnostic(2, 4, "f", "result 0 of `g()`")
+	// The same error in the same function as d2 (e.g., on another line) shares its fingerprint.
+	d3 := newDiagnostic(3, 5, "f", "result 0 of `g()`")
+	require.Equal(t, d2.Flow.Fingerprint(), d3.Flow.Fingerprint())
+
+	b := newBaseline([]diagnostic.Diagnostic{d1, d2, d3})
+	require.Len(t, b.Entries, 3)
+	counts := make(map[string]int)
+	for _, e := range b.Entries {
+		require.Equal(t, "pkg/a.go", e.File)
+		counts[e.Fingerprint] = e.Count
+	}
+	require.Equal(t, 2, counts[d2.Flow.Fingerprint()])
+	require.Equal(t, 1, counts[similar.Fingerprint()])
+
+	// The baseline survives a round trip through the file.
+	path := filepath.Join(t.TempDir(), "baseline.json")
+	require.NoError(t, writeBaselineFile(path, b))
+	loaded, err := loadBaselineFile(path)
+	require.NoError(t, err)
+	require.Equal(t, b, loaded)
+
+	// Now, d1 is still present (but moved by a few lines), one of d2 and d3 is fixed, and d4 is new.
+	d1 = newDiagnostic(1, 13, "f", "result 0 of `f()`", similar)
+	d4 := newDiagnostic(4, 16, "f", "result 0 of `h()`")
+	act := &checker.Action{
+		Result:      []diagnostic.Diagnostic{d1, d2, d4},
+		Diagnostics: []analysis.Diagnostic{d1.Diagnostic, d2.Diagnostic, d4.Diagnostic},
+	}
+	// The test variant of the package reports the same diagnostics.
+	testAct := &checker.Action{
+		Result:      []diagnostic.Diagnostic{d1, d2, d4},
+		Diagnostics: []analysis.Diagnostic{d1.Diagnostic, d2.Diagnostic, d4.Diagnostic},
+	}
+	stale := loaded.apply([]*checker.Action{act, testAct})
+	for _, a := range []*checker.Action{act, testAct} {
+		require.Equal(t, []diagnostic.Diagnostic{d4}, a.Result)
+		require.Equal(t, []analysis.Diagnostic{d4.Diagnostic}, a.Diagnostics)
+	}
+	require.Len(t, stale, 1)
+	require.Equal(t, d2.Flow.Fingerprint(), stale[0].Fingerprint)
+	require.Equal(t, 1, stale[0].Count)
+
+	// A grouped diagnostic is only suppressed if all its flows are in the baseline.
+	d5 := newDiagnostic(5, 3, "f", "result 0 of `f()`", similar, &diagnostic.Flow{Position: token.Position{Filename: "pkg/a.go", Line: 20}})
+	act = &checker.Action{Result: []diagnostic.Diagnostic{d5}, Diagnostics: []analysis.Diagnostic{d5.Diagnostic}}
+	loaded.apply([]*checker.Action{act})
+	require.Equal(t, []diagnostic.Diagnostic{d5}, act.Result)
+}
+
+func TestBaselineNotes(t *testing.T) {
+	t.Parallel()
+
+	newNote := func(pos token.Pos, line int) diagnostic.Diagnostic {
+		message := fmt.Sprintf("Function contract `contract(nonnil -> nonnil)` is violated by the return statement at pkg/a.go:%d:2", line+2)
+		return diagnostic.Diagnostic{
+			Diagnostic: analysis.Diagnostic{Pos: pos, Category: string(config.CategoryContractViolation), Message: message},
+			Note: &diagnostic.Note{
+				Position: token.Position{Filename: "pkg/a.go", Line: line, Column: 1, Offset: line},
+				Category: config.CategoryContractViolation,
+				Message:  message,
+			},
+		}
+	}
+	internal := diagnostic.Diagnostic{Diagnostic: analysis.Diagnostic{Pos: 1, Message: "INTERNAL ERROR(s)"}, Internal: true}
+
+	// Internal errors are not part of the baseline.
+	b := newBaseline([]diagnostic.Diagnostic{newNote(1, 3), internal})
+	require.Len(t, b.Entries, 1)
+	require.Equal(t, "pkg/a.go", b.Entries[0].File)
+
+	// The note is still suppressed after it moved by a few lines, while the internal error is not.
+	note := newNote(1, 13)
+	act := &checker.Action{
+		Result:      []diagnostic.Diagnostic{note, internal},
+		Diagnostics: []analysis.Diagnostic{note.Diagnostic, internal.Diagnostic},
+	}
+	require.Empty(t, b.apply([]*checker.Action{act}))
+	require.Equal(t, []diagnostic.Diagnostic{internal}, act.Result)
+	require.Equal(t, []analysis.Diagnostic{internal.Diagnostic}, act.Diagnostics)
+}
+
+func TestLoadBaselineFileErrors(t *testing.T) {
+	t.Parallel()
+
+	for name, content := range map[string]string{
+		"unknown key":     `{"version": 1, "entries": [], "unknown": true}`,
+		"unknown version": `{"version": 2, "entries": []}`,
+		"zero count":      `{"version": 1, "entries": [{"fingerprint": "abc", "file": "a.go", "count": 0}]}`,
+		"no fingerprint":  `{"version": 1, "entries": [{"file": "a.go", "count": 1}]}`,
+	} {
+		path := filepath.Join(t.TempDir(), "baseline.json")
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+		_, err := loadBaselineFile(path)
+		require.Error(t, err, name)
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, newBaseline(nil).write(&buf))
+	require.JSONEq(t, `{"version": 1, "entries": []}`, buf.String())
+}
+
+func TestIsBaselineFlag(t *testing.T) {
+	t.Parallel()
+
+	for _, arg := range []string{"-baseline", "--baseline=a.json", "-write-baseline", "-write-baseline=a.json"} {
+		require.True(t, isBaselineFlag(arg), arg)
+	}
+	for _, arg := range []string{"-baselines", "-json", "./..."} {
+		require.False(t, isBaselineFlag(arg), arg)
+	}
+}
@@ -0,0 +1,529 @@
+//  Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/gob"
+	"encoding/hex"
+	"errors"
+	"flag"
+	"fmt"
+	"go/token"
+	"go/types"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+
+	"go.uber.org/nilaway/accumulation"
+	"go.uber.org/nilaway/config"
+	"go.uber.org/nilaway/diagnostic"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/ctrlflow"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/packages"
+	"golang.org/x/tools/go/types/objectpath"
+)
+
+// This file implements the incremental analysis cache of the standalone checker, which stores the
+// per-package results of NilAway on disk such that the analysis of unchanged packages is skipped
+// in later runs.
+//
+// Each package is keyed by a hash of its sources, the keys of the packages it imports and the
+// effective configuration (see [analysisCache.envHash]). Since the keys of the imported packages
+// in turn cover their sources, a key identifies everything that the results of the package -- the
+// facts it exports (e.g., the inferred map) and its diagnostics -- are derived from. On a cache
+// hit, the NilAway analyzers replay the cached facts for the dependent packages and return the
+// cached diagnostics without doing any work, and on a miss they run as usual while the facts and
+// diagnostics are recorded for storing them after the run.
+
+// _cacheVersion is the version of the cache entry format, which is part of the keys.
+const _cacheVersion = 2
+
+// _uncachedAnalyzers are the analyzers that always run: the config analyzer for validating the
+// configuration, and the control flow analyzers whose facts (e.g., no-return functions) are
+// consumed by the control flow analyzers of the dependent packages.
+var _uncachedAnalyzers = []*analysis.Analyzer{config.Analyzer, inspect.Analyzer, ctrlflow.Analyzer}
+
+// cacheEntry is the on-disk format of the cached results of a package.
+type cacheEntry struct {
+	Facts       []cachedFact
+	Diagnostics []cachedDiagnostic
+}
+
+// cachedFact is a gob-encoded fact exported by an analyzer, which is a package fact if Object is
+// empty, or an object fact about the object with the path otherwise.
+type cachedFact struct {
+	Analyzer string
+	Type     string
+	Object   objectpath.Path
+	Data     []byte
+}
+
+// cachedDiagnostic is a diagnostic with the positions stored in a file set-independent form.
+type cachedDiagnostic struct {
+	Pos, End       token.Position
+	Category       string
+	Message        string
+	URL            string
+	SuggestedFixes []cachedFix
+	Related        []cachedRelated
+	Flow           *diagnostic.Flow
+	Note           *diagnostic.Note
+	Internal       bool
+}
+
+type cachedFix struct {
+	Message   string
+	TextEdits []cachedEdit
+}
+
+type cachedEdit struct {
+	Pos, End token.Position
+	NewText  []byte
+}
+
+type cachedRelated struct {
+	Pos, End token.Position
+	Message  string
+}
+
+// cachedPackage is the state of a single package in the cache during a run.
+type cachedPackage struct {
+	key string
+	// hit is the entry loaded from the cache, or nil on a cache miss.
+	hit *cacheEntry
+
+	mu sync.Mutex
+	// recorded is the entry being recorded on a cache miss.
+	recorded cacheEntry
+	// complete is true if the accumulation analyzer succeeded on a cache miss.
+	complete bool
+	// uncacheable is true if the results cannot be cached, e.g., because an analyzer failed.
+	uncacheable bool
+}
+
+// analysisCache is the incremental analysis cache for the packages of a single run.
+type analysisCache struct {
+	dir      string
+	packages map[*types.Package]*cachedPackage
+	fset     *token.FileSet
+
+	filesOnce sync.Once
+	files     map[string]*token.File
+}
+
+// newAnalysisCache computes the keys of the given packages and their dependencies and loads the
+// existing entries from the cache directory.
+func newAnalysisCache(dir string, pkgs []*packages.Package) (*analysisCache, error) {
+	env, err := envHash()
+	if err != nil {
+		return nil, err
+	}
+
+	c := &analysisCache{dir: dir, packages: make(map[*types.Package]*cachedPackage)}
+	keys := make(map[*packages.Package]string)
+	var keyErr error
+	// Visit the packages in post order such that the keys of the imported packages are computed
+	// before the ones of the importing packages.
+	packages.Visit(pkgs, nil, func(pkg *packages.Package) {
+		if keyErr != nil {
+			return
+		}
+		key, err := packageKey(env, pkg, keys)
+		if err != nil {
+			keyErr = err
+			return
+		}
+		keys[pkg] = key
+		if pkg.Types == nil {
+			return
+		}
+		if c.fset == nil {
+			c.fset = pkg.Fset
+		}
+		cp := &cachedPackage{key: key}
+		// Entries that cannot be read (e.g., because they do not exist) are cache misses.
+		if entry, err := c.load(key); err == nil {
+			cp.hit = entry
+		}
+		c.packages[pkg.Types] = cp
+	})
+	if keyErr != nil {
+		return nil, keyErr
+	}
+	return c, nil
+}
+
+// envHash returns the hash of the environment the results depend on other than the sources: the
+// NilAway binary, the Go version, the working directory (which the positions in the messages are
+// relative to) and the effective configuration, i.e., the config flags and the files they refer to.
+func envHash() (string, error) {
+	h := sha256.New()
+	fmt.Fprintf(h, "nilaway cache v%d\n%s\n", _cacheVersion, runtime.Version())
+
+	exe, err := os.Executable()
+	if err != nil {
+		return "", fmt.Errorf("locate executable: %w", err)
+	}
+	if err := hashFile(h, exe); err != nil {
+		return "", err
+	}
+	wd, err := os.Getwd()
+	if err != nil {
+		return "", fmt.Errorf("get working directory: %w", err)
+	}
+	fmt.Fprintf(h, "cwd %s\n", wd)
+
+	var flagErr error
+	config.Analyzer.Flags.VisitAll(func(f *flag.Flag) {
+		fmt.Fprintf(h, "flag %s=%s\n", f.Name, f.Value)
+		if f.Name == config.TrustedFuncModelsFlag && f.Value.String() != "" && flagErr == nil {
+			flagErr = hashFile(h, f.Value.String())
+		}
+		if f.Name == config.AnnotationStubsFlag && f.Value.String() != "" {
+			for _, name := range strings.Split(f.Value.String(), ",") {
+				if flagErr == nil {
+					flagErr = hashFile(h, name)
+				}
+			}
+		}
+	})
+	if flagErr != nil {
+		return "", flagErr
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// packageKey returns the key of the package, given the keys of the packages it imports.
+func packageKey(env string, pkg *packages.Package, keys map[*packages.Package]string) (string, error) {
+	h := sha256.New()
+	fmt.Fprintf(h, "env %s\npackage %s\n", env, pkg.ID)
+	for _, name := range pkg.CompiledGoFiles {
+		fmt.Fprintf(h, "file %s\n", name)
+		if err := hashFile(h, name); err != nil {
+			return "", err
+		}
+	}
+	paths := make([]string, 0, len(pkg.Imports))
+	for path := range pkg.Imports {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	for _, path := range paths {
+		fmt.Fprintf(h, "import %s %s\n", path, keys[pkg.Imports[path]])
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// hashFile writes the hash of the content of the file to the given hash.
+func hashFile(w io.Writer, name string) error {
+	f, err := os.Open(name)
+	if err != nil {
+		return fmt.Errorf("hash file: %w", err)
+	}
+	defer f.Close()
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return fmt.Errorf("hash file %q: %w", name, err)
+	}
+	_, err = fmt.Fprintf(w, "%x\n", h.Sum(nil))
+	return err
+}
+
+// path returns the path of the entry with the given key in the cache directory.
+func (c *analysisCache) path(key string) string {
+	return filepath.Join(c.dir, key[:2], key)
+}
+
+// load reads the entry with the given key from the cache directory.
+func (c *analysisCache) load(key string) (*cacheEntry, error) {
+	data, err := os.ReadFile(c.path(key))
+	if err != nil {
+		return nil, err
+	}
+	var entry cacheEntry
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&entry); err != nil {
+		return nil, fmt.Errorf("decode cache entry %q: %w", key, err)
+	}
+	return &entry, nil
+}
+
+// store writes the recorded entries of the packages that missed the cache to the cache directory.
+func (c *analysisCache) store() error {
+	var errs []error
+	for _, cp := range c.packages {
+		if cp.hit != nil || !cp.complete || cp.uncacheable {
+			continue
+		}
+		var buf bytes.Buffer
+		if err := gob.NewEncoder(&buf).Encode(&cp.recorded); err != nil {
+			errs = append(errs, fmt.Errorf("encode cache entry %q: %w", cp.key, err))
+			continue
+		}
+		errs = append(errs, writeFileAtomically(c.path(cp.key), buf.Bytes()))
+	}
+	return errors.Join(errs...)
+}
+
+// writeFileAtomically writes the file via a temporary file, such that concurrent runs never read
+// partially written files.
+func writeFileAtomically(name string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(name), 0o755); err != nil {
+		return fmt.Errorf("create cache directory: %w", err)
+	}
+	f, err := os.CreateTemp(filepath.Dir(name), filepath.Base(name)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("create cache entry: %w", err)
+	}
+	if _, err := f.Write(data); err != nil {
+		return errors.Join(fmt.Errorf("write cache entry: %w", err), f.Close(), os.Remove(f.Name()))
+	}
+	if err := f.Close(); err != nil {
+		return errors.Join(fmt.Errorf("write cache entry: %w", err), os.Remove(f.Name()))
+	}
+	return os.Rename(f.Name(), name)
+}
+
+// stats returns the numbers of packages that hit and missed the cache.
+func (c *analysisCache) stats() (hits, misses int) {
+	for _, cp := range c.packages {
+		if cp.hit != nil {
+			hits++
+		} else if cp.complete {
+			misses++
+		}
+	}
+	return hits, misses
+}
+
+// install wraps the run functions of the given analyzer (i.e., accumulation.Analyzer, whose
+// result is replaced by the cached one on cache hits) and all analyzers it (transitively) requires,
+// except for _uncachedAnalyzers, such that they replay the cached results on cache hits
+// and record the results on cache misses. It returns a function restoring the analyzers.
+func (c *analysisCache) install(root *analysis.Analyzer) (restore func()) {
+	originals := make(map[*analysis.Analyzer]func(*analysis.Pass) (any, error))
+	var visit func(a *analysis.Analyzer)
+	visit = func(a *analysis.Analyzer) {
+		if _, ok := originals[a]; ok || slices.Contains(_uncachedAnalyzers, a) {
+			return
+		}
+		originals[a] = a.Run
+		run := a.Run
+		a.Run = func(pass *analysis.Pass) (any, error) { return c.run(a, run, pass) }
+		for _, req := range a.Requires {
+			visit(req)
+		}
+	}
+	visit(root)
+
+	return func() {
+		for a, run := range originals {
+			a.Run = run
+		}
+	}
+}
+
+// run runs the analyzer on the pass via the cache.
+func (c *analysisCache) run(a *analysis.Analyzer, run func(*analysis.Pass) (any, error), pass *analysis.Pass) (any, error) {
+	cp, ok := c.packages[pass.Pkg]
+	if !ok {
+		return run(pass)
+	}
+
+	if cp.hit != nil {
+		if err := c.replayFacts(a, pass, cp.hit.Facts); err != nil {
+			return nil, err
+		}
+		if a == accumulation.Analyzer {
+			return &accumulation.Result{Diagnostics: c.decodeDiagnostics(cp.hit.Diagnostics)}, nil
+		}
+		// The results of the other analyzers are only consumed by the analyzers that are skipped
+		// as well, so they are left empty.
+		return reflect.Zero(a.ResultType).Interface(), nil
+	}
+
+	recording := *pass
+	recording.ExportPackageFact = func(fact analysis.Fact) {
+		pass.ExportPackageFact(fact)
+		cp.record(a, "", fact)
+	}
+	recording.ExportObjectFact = func(obj types.Object, fact analysis.Fact) {
+		pass.ExportObjectFact(obj, fact)
+		path, err := objectpath.For(obj)
+		if err != nil {
+			// The object is not addressable from other packages (e.g., a local type), so the fact
+			// is not visible to them either.
+			return
+		}
+		cp.record(a, path, fact)
+	}
+	result, err := run(&recording)
+
+	cp.mu.Lock()
+	defer cp.mu.Unlock()
+	if err != nil {
+		cp.uncacheable = true
+		return result, err
+	}
+	if a == accumulation.Analyzer {
+		res, _ := result.(*accumulation.Result)
+		if res == nil {
+			cp.uncacheable = true
+			return result, nil
+		}
+		for _, d := range res.Diagnostics {
+			// Internal errors should be retried.
+			if d.Internal {
+				cp.uncacheable = true
+			}
+			cp.recorded.Diagnostics = append(cp.recorded.Diagnostics, c.encodeDiagnostic(d))
+		}
+		cp.complete = true
+	}
+	return result, nil
+}
+
+// record records the fact exported by the analyzer on a cache miss.
+func (cp *cachedPackage) record(a *analysis.Analyzer, path objectpath.Path, fact analysis.Fact) {
+	cp.mu.Lock()
+	defer cp.mu.Unlock()
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(fact); err != nil {
+		cp.uncacheable = true
+		return
+	}
+	cp.recorded.Facts = append(cp.recorded.Facts, cachedFact{
+		Analyzer: a.Name,
+		Type:     reflect.TypeOf(fact).String(),
+		Object:   path,
+		Data:     buf.Bytes(),
+	})
+}
+
+// replayFacts exports the cached facts of the analyzer on a cache hit.
+func (c *analysisCache) replayFacts(a *analysis.Analyzer, pass *analysis.Pass, facts []cachedFact) error {
+	for _, f := range facts {
+		if f.Analyzer != a.Name {
+			continue
+		}
+		i := slices.IndexFunc(a.FactTypes, func(t analysis.Fact) bool { return reflect.TypeOf(t).String() == f.Type })
+		if i < 0 {
+			return fmt.Errorf("cached fact of unknown type %q for analyzer %q", f.Type, a.Name)
+		}
+		fact := reflect.New(reflect.TypeOf(a.FactTypes[i]).Elem()).Interface().(analysis.Fact)
+		if err := gob.NewDecoder(bytes.NewReader(f.Data)).Decode(fact); err != nil {
+			return fmt.Errorf("decode cached fact %q for analyzer %q: %w", f.Type, a.Name, err)
+		}
+		if f.Object == "" {
+			pass.ExportPackageFact(fact)
+			continue
+		}
+		obj, err := objectpath.Object(pass.Pkg, f.Object)
+		if err != nil {
+			return fmt.Errorf("resolve object %q of cached fact for analyzer %q: %w", f.Object, a.Name, err)
+		}
+		pass.ExportObjectFact(obj, fact)
+	}
+	return nil
+}
+
+// encodeDiagnostic converts the diagnostic to its cached form.
+func (c *analysisCache) encodeDiagnostic(d diagnostic.Diagnostic) cachedDiagnostic {
+	cd := cachedDiagnostic{
+		Pos:      c.position(d.Pos),
+		End:      c.position(d.End),
+		Category: d.Category,
+		Message:  d.Message,
+		URL:      d.URL,
+		Flow:     d.Flow,
+		Note:     d.Note,
+		Internal: d.Internal,
+	}
+	for _, fix := range d.SuggestedFixes {
+		cf := cachedFix{Message: fix.Message}
+		for _, edit := range fix.TextEdits {
+			cf.TextEdits = append(cf.TextEdits, cachedEdit{Pos: c.position(edit.Pos), End: c.position(edit.End), NewText: edit.NewText})
+		}
+		cd.SuggestedFixes = append(cd.SuggestedFixes, cf)
+	}
+	for _, r := range d.Related {
+		cd.Related = append(cd.Related, cachedRelated{Pos: c.position(r.Pos), End: c.position(r.End), Message: r.Message})
+	}
+	return cd
+}
+
+// decodeDiagnostics converts the cached diagnostics back to diagnostics.
+func (c *analysisCache) decodeDiagnostics(cached []cachedDiagnostic) []diagnostic.Diagnostic {
+	diagnostics := make([]diagnostic.Diagnostic, 0, len(cached))
+	for _, cd := range cached {
+		d := diagnostic.Diagnostic{
+			Diagnostic: analysis.Diagnostic{
+				Pos:      c.pos(cd.Pos),
+				End:      c.pos(cd.End),
+				Category: cd.Category,
+				Message:  cd.Message,
+				URL:      cd.URL,
+			},
+			Flow:     cd.Flow,
+			Note:     cd.Note,
+			Internal: cd.Internal,
+		}
+		for _, cf := range cd.SuggestedFixes {
+			fix := analysis.SuggestedFix{Message: cf.Message}
+			for _, edit := range cf.TextEdits {
+				fix.TextEdits = append(fix.TextEdits, analysis.TextEdit{Pos: c.pos(edit.Pos), End: c.pos(edit.End), NewText: edit.NewText})
+			}
+			d.SuggestedFixes = append(d.SuggestedFixes, fix)
+		}
+		for _, r := range cd.Related {
+			d.Related = append(d.Related, analysis.RelatedInformation{Pos: c.pos(r.Pos), End: c.pos(r.End), Message: r.Message})
+		}
+		diagnostics = append(diagnostics, d)
+	}
+	return diagnostics
+}
+
+// position converts the position to a file set-independent form, ignoring line directives.
+func (c *analysisCache) position(pos token.Pos) token.Position {
+	if !pos.IsValid() {
+		return token.Position{}
+	}
+	return c.fset.PositionFor(pos, false /* adjusted */)
+}
+
+// pos converts the file set-independent position back to a position in the file set, or
+// token.NoPos if the file is not in the file set.
+func (c *analysisCache) pos(position token.Position) token.Pos {
+	c.filesOnce.Do(func() {
+		c.files = make(map[string]*token.File)
+		c.fset.Iterate(func(f *token.File) bool {
+			c.files[f.Name()] = f
+			return true
+		})
+	})
+	f, ok := c.files[position.Filename]
+	if !ok || position.Offset > f.Size() {
+		return token.NoPos
+	}
+	return f.Pos(position.Offset)
+}
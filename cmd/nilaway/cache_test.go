@@ -0,0 +1,137 @@
+//  Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/nilaway"
+	"go.uber.org/nilaway/accumulation"
+	"go.uber.org/nilaway/diagnostic"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/checker"
+	"golang.org/x/tools/go/packages"
+)
+
+func TestAnalysisCache(t *testing.T) { //nolint:paralleltest
+	// We specifically do not set this test to be parallel since installing the cache modifies the
+	// global analyzers.
+	dir := t.TempDir()
+	writeFile := func(name, content string) {
+		require.NoError(t, os.MkdirAll(filepath.Dir(filepath.Join(dir, name)), 0o755))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644))
+	}
+	writeFile("go.mod", "module example.com/demo\n\ngo 1.21\n")
+	writeFile("upstream/upstream.go", `package upstream
+
+type T struct{ F *int }
+
+func Nilable() *T { return nil }
+`)
+	writeFile("downstream/downstream.go", `package downstream
+
+import "example.com/demo/upstream"
+
+func Use() int {
+	return *upstream.Nilable().F
+}
+`)
+
+	// run analyzes the packages with the cache, and returns the reported diagnostics (along with
+	// their positions and suggested fixes) and the cache statistics.
+	cacheDir := t.TempDir()
+	run := func() (reports []string, hits, misses int) {
+		pkgs, err := packages.Load(&packages.Config{Mode: packages.LoadAllSyntax, Dir: dir}, "./...")
+		require.NoError(t, err)
+		require.Zero(t, packages.PrintErrors(pkgs))
+
+		cache, err := newAnalysisCache(cacheDir, pkgs)
+		require.NoError(t, err)
+		restore := cache.install(accumulation.Analyzer)
+		graph, err := checker.Analyze([]*analysis.Analyzer{nilaway.Analyzer}, pkgs, nil)
+		restore()
+		require.NoError(t, err)
+		require.NoError(t, cache.store())
+
+		for _, act := range graph.Roots {
+			require.NoError(t, act.Err)
+			for _, d := range act.Result.([]diagnostic.Diagnostic) {
+				report := fmt.Sprintf("%s: %s", act.Package.Fset.Position(d.Pos), d.Message)
+				for _, r := range d.Related {
+					report += fmt.Sprintf("\n%s: %s", act.Package.Fset.Position(r.Pos), r.Message)
+				}
+				reports = append(reports, report)
+			}
+		}
+		hits, misses = cache.stats()
+		return reports, hits, misses
+	}
+
+	reports, hits, misses := run()
+	require.Len(t, reports, 1)
+	require.Contains(t, reports[0], "downstream.go:6:10: ")
+	require.Zero(t, hits)
+	require.Equal(t, 2, misses)
+
+	// All packages are cached in the second run, with the same diagnostics.
+	cached, hits, misses := run()
+	require.Equal(t, reports, cached)
+	require.Equal(t, 2, hits)
+	require.Zero(t, misses)
+
+	// Changing the downstream package only invalidates itself.
+	writeFile("downstream/downstream.go", `package downstream
+
+import "example.com/demo/upstream"
+
+func Use() int {
+	// Shift the line.
+	return *upstream.Nilable().F
+}
+`)
+	reports, hits, misses = run()
+	require.Len(t, reports, 1)
+	require.Contains(t, reports[0], "downstream.go:7:10: ")
+	require.Equal(t, 1, hits)
+	require.Equal(t, 1, misses)
+
+	// Changing the upstream package invalidates the downstream package as well, and the facts of
+	// the upstream package are recomputed: the nil panic is gone.
+	writeFile("upstream/upstream.go", `package upstream
+
+type T struct{ F *int }
+
+func Nilable() *T { return &T{F: new(int)} }
+`)
+	reports, hits, misses = run()
+	require.Empty(t, reports)
+	require.Zero(t, hits)
+	require.Equal(t, 2, misses)
+}
+
+func TestIsCacheDirFlag(t *testing.T) {
+	t.Parallel()
+
+	for _, arg := range []string{"-cache-dir", "--cache-dir=/tmp/nilaway"} {
+		require.True(t, isCacheDirFlag(arg), arg)
+	}
+	for _, arg := range []string{"-cache", "-baseline", "./..."} {
+		require.False(t, isCacheDirFlag(arg), arg)
+	}
+}
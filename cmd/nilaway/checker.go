@@ -0,0 +1,172 @@
+//  Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"go.uber.org/nilaway/accumulation"
+	"go.uber.org/nilaway/config"
+	"go.uber.org/nilaway/diagnostic"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/checker"
+	"golang.org/x/tools/go/packages"
+)
+
+// runChecker runs the analyzer on the packages matching the given patterns and post-processes the
+// diagnostics of all packages according to the driver modes the singlechecker does not support:
+// writing the inferred report (see report.go), writing or applying a baseline (see baseline.go),
+// SARIF output (see sarif.go), warnings that do not fail the run and the incremental analysis
+// cache (see cache.go). Otherwise, the diagnostics are
+// printed in plain text like the singlechecker does. It returns the exit code of the process.
+func runChecker(patterns []string) int {
+	if _sarif {
+		// SARIF locations must be resolvable file paths, and the messages must not contain ANSI codes.
+		for name, value := range map[string]string{config.PrintFullFilePathFlag: "true", config.PrettyPrintFlag: "false"} {
+			if err := config.Analyzer.Flags.Set(name, value); err != nil {
+				fmt.Fprintf(os.Stderr, "failed to set flag %q: %v\n", name, err)
+				return 1
+			}
+		}
+	}
+
+	pkgs, err := packages.Load(&packages.Config{Mode: packages.LoadAllSyntax, Tests: true}, patterns...)
+	if err == nil && len(pkgs) == 0 {
+		err = fmt.Errorf("%s matched no packages", strings.Join(patterns, " "))
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to load packages: %v\n", err)
+		return 1
+	}
+	if packages.PrintErrors(pkgs) > 0 {
+		return 1
+	}
+
+	if _cacheDir != "" && _inferredReport == "" {
+		// The cached results do not include the complete inferred maps, so the cache is bypassed
+		// for the inferred report.
+		cache, err := newAnalysisCache(_cacheDir, pkgs)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "failed to set up analysis cache: %v\n", err)
+			return 1
+		}
+		defer cache.install(accumulation.Analyzer)()
+		defer func() {
+			// Failing to store the results only affects the speed of later runs.
+			if err := cache.store(); err != nil {
+				fmt.Fprintf(os.Stderr, "failed to store analysis cache: %v\n", err)
+			}
+		}()
+	}
+
+	analyzers := []*analysis.Analyzer{Analyzer}
+	if _inferredReport != "" {
+		// The checker only keeps the results of the root actions, so the accumulation analyzer must
+		// be a root for its inferred maps to be available.
+		analyzers = append(analyzers, accumulation.Analyzer)
+	}
+	graph, err := checker.Analyze(analyzers, pkgs, nil)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to analyze packages: %v\n", err)
+		return 1
+	}
+	roots := slices.DeleteFunc(slices.Clone(graph.Roots), func(act *checker.Action) bool { return act.Analyzer != Analyzer })
+
+	exitCode := 0
+	for _, act := range roots {
+		if act.Err != nil {
+			exitCode = 1
+		}
+	}
+
+	if _inferredReport != "" {
+		if err := writeInferredReportFile(_inferredReport, newInferredReport(graph.Roots)); err != nil {
+			fmt.Fprintf(os.Stderr, "failed to write inferred report: %v\n", err)
+			return 1
+		}
+	}
+
+	if _writeBaseline != "" {
+		printErrors(roots)
+		b := newBaseline(rootDiagnostics(roots))
+		if err := writeBaselineFile(_writeBaseline, b); err != nil {
+			fmt.Fprintf(os.Stderr, "failed to write baseline: %v\n", err)
+			return 1
+		}
+		fmt.Fprintf(os.Stderr, "wrote %d baseline entries to %s\n", len(b.Entries), _writeBaseline)
+		return exitCode
+	}
+
+	if _baseline != "" {
+		b, err := loadBaselineFile(_baseline)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "failed to load baseline: %v\n", err)
+			return 1
+		}
+		if stale := b.apply(roots); len(stale) > 0 {
+			fmt.Fprintf(os.Stderr, "%d baseline entries in %s no longer match any error and can be pruned (e.g., by rewriting the baseline):\n", len(stale), _baseline)
+			for _, e := range stale {
+				fmt.Fprintf(os.Stderr, "\t%s: %s (function %q, unmatched count %d)\n", e.File, e.Fingerprint, e.Function, e.Count)
+			}
+		}
+	}
+
+	if _sarif {
+		printErrors(roots)
+		builder := newSARIFBuilder()
+		for _, act := range roots {
+			if act.Err == nil {
+				diagnostics, _ := act.Result.([]diagnostic.Diagnostic)
+				builder.add(act.Package.Fset, diagnostics)
+			}
+		}
+		if err := builder.write(os.Stdout); err != nil {
+			fmt.Fprintf(os.Stderr, "failed to write SARIF output: %v\n", err)
+			return 1
+		}
+		return exitCode
+	}
+
+	// Mimic the singlechecker: print the diagnostics (and errors) in plain text to stderr, and
+	// exit with code 3 if there are any diagnostics other than warnings.
+	for _, act := range roots {
+		diagnostics, _ := act.Result.([]diagnostic.Diagnostic)
+		if act.Err == nil && slices.ContainsFunc(diagnostics, isError) && exitCode == 0 {
+			exitCode = 3
+		}
+	}
+	if err := graph.PrintText(os.Stderr, -1); err != nil {
+		fmt.Fprintf(os.Stderr, "failed to print diagnostics: %v\n", err)
+		return 1
+	}
+	return exitCode
+}
+
+// isError returns true if the diagnostic is not a warning (see config.WarningCategoriesFlag).
+func isError(d diagnostic.Diagnostic) bool {
+	return d.Severity() != diagnostic.SeverityWarning
+}
+
+// printErrors prints the errors of the given actions to stderr.
+func printErrors(actions []*checker.Action) {
+	for _, act := range actions {
+		if act.Err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %v\n", act, act.Err)
+		}
+	}
+}
@@ -22,10 +22,12 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 
 	"go.uber.org/nilaway"
 	"go.uber.org/nilaway/config"
+	"go.uber.org/nilaway/diagnostic"
 	"go.uber.org/nilaway/util/analysishelper"
 	"golang.org/x/tools/go/analysis"
 	"golang.org/x/tools/go/analysis/singlechecker"
@@ -33,7 +35,7 @@ import (
 
 // Analyzer is identical to the one in nilaway.go, except that it overrides the run function for
 // extra filtering of errors, since the singlechecker does not support error suppression like other
-// popular linter drivers.
+// popular linter drivers. Its result only contains the diagnostics that survive the filtering.
 var Analyzer = &analysis.Analyzer{
 	Name:       nilaway.Analyzer.Name,
 	Doc:        nilaway.Analyzer.Doc,
@@ -48,6 +50,16 @@ var (
 	_includeErrorsInFiles string
 	// _excludeErrorsInFiles is a driver flag for specifying the list of file prefixes to not report errors.
 	_excludeErrorsInFiles string
+	// _sarif is a driver flag for printing the diagnostics in SARIF format.
+	_sarif bool
+	// _baseline is a driver flag for specifying the baseline file to suppress known errors.
+	_baseline string
+	// _writeBaseline is a driver flag for specifying the baseline file to write all errors to.
+	_writeBaseline string
+	// _inferredReport is a driver flag for specifying the file to write the inferred nilabilities to.
+	_inferredReport string
+	// _cacheDir is a driver flag for specifying the directory of the incremental analysis cache.
+	_cacheDir string
 )
 
 func run(p *analysis.Pass) (interface{}, error) {
@@ -69,26 +81,34 @@ func run(p *analysis.Pass) (interface{}, error) {
 		return nil, fmt.Errorf("parse file prefixes for error exclusion: %w", err)
 	}
 
-	// Override the report function to add error filtering logic.
-	report := pass.Report
-	pass.Report = func(d analysis.Diagnostic) {
+	keep := func(d analysis.Diagnostic) bool {
 		p := pass.Fset.File(d.Pos).Name()
 		for _, e := range excludes {
 			if strings.HasPrefix(p, e) {
-				return
+				return false
 			}
 		}
+		return slices.ContainsFunc(includes, func(i string) bool { return strings.HasPrefix(p, i) })
+	}
 
-		for _, i := range includes {
-			if strings.HasPrefix(p, i) {
-				report(d)
-				return
-			}
+	// Override the report function to add error filtering logic.
+	report := pass.Report
+	pass.Report = func(d analysis.Diagnostic) {
+		if keep(d) {
+			report(d)
 		}
 	}
 
 	// Delegate the real analysis run to the original nilaway analyzer.
-	return nilaway.Analyzer.Run(p)
+	result, err := nilaway.Analyzer.Run(p)
+	if err != nil {
+		return nil, err
+	}
+	// Apply the same filtering to the result such that other output formats (e.g., SARIF) built
+	// from it are consistent with the reported diagnostics.
+	return slices.DeleteFunc(result.([]diagnostic.Diagnostic), func(d diagnostic.Diagnostic) bool {
+		return !keep(d.Diagnostic)
+	}), nil
 }
 
 // parseFilePrefixes parses the comma-separated list of file prefixes, converts them to absolute
@@ -135,5 +155,64 @@ func main() {
 	flag.StringVar(&_includeErrorsInFiles, "include-errors-in-files", wd, "A comma-separated list of file prefixes to report errors, default is current working directory.")
 	flag.StringVar(&_excludeErrorsInFiles, "exclude-errors-in-files", "", "A comma-separated list of file prefixes to exclude from error reporting. This takes precedence over include-errors-in-files.")
 
+	// The singlechecker only supports plain text and JSON outputs of the diagnostics of each
+	// package, so we drive the analysis ourselves for SARIF output, baselines and inferred reports,
+	// which need the results of all packages, and for the analysis cache. Note that the other flags
+	// of the singlechecker (e.g., -json and -fix) are not available in these modes.
+	flag.BoolVar(&_sarif, "sarif", false, "Print the diagnostics in SARIF 2.1.0 format. This cannot be combined with other flags of the standalone checker (e.g., -json).")
+	flag.StringVar(&_baseline, "baseline", "", "A baseline file (written by -write-baseline) of known errors to suppress, such that only new errors are reported. Baseline entries that no longer match any error are listed for pruning. This cannot be combined with other flags of the standalone checker (e.g., -json).")
+	flag.StringVar(&_writeBaseline, "write-baseline", "", "Write all current errors to the given baseline file (see -baseline) instead of reporting them.")
+	flag.StringVar(&_cacheDir, "cache-dir", "", "A directory to cache the per-package analysis results in, such that unchanged packages are not analyzed again in later runs. This cannot be combined with other flags of the standalone checker (e.g., -json).")
+	flag.StringVar(&_inferredReport, "inferred-report", "", "Write the inferred nilabilities of all annotation sites (e.g., function parameters and results, struct fields and global variables) of the analyzed packages to the given file in JSON format, in addition to reporting the errors.")
+	// Similarly, the singlechecker exits with a failure on any diagnostic in plain text mode, while
+	// warnings (see config.WarningCategoriesFlag) must not fail the run. Other modes of the
+	// singlechecker (e.g., -json, which always exits with success) are left to it.
+	warningsOnly := slices.ContainsFunc(os.Args[1:], isWarningCategoriesFlag) && !slices.ContainsFunc(os.Args[1:], isSinglecheckerModeFlag)
+	if slices.ContainsFunc(os.Args[1:], isSARIFFlag) || slices.ContainsFunc(os.Args[1:], isBaselineFlag) ||
+		slices.ContainsFunc(os.Args[1:], isInferredReportFlag) || slices.ContainsFunc(os.Args[1:], isCacheDirFlag) || warningsOnly {
+		flag.Parse()
+		warnings := flag.Lookup(config.WarningCategoriesFlag).Value.String() != ""
+		if _sarif || _baseline != "" || _writeBaseline != "" || _inferredReport != "" || _cacheDir != "" || (warningsOnly && warnings) {
+			os.Exit(runChecker(flag.Args()))
+		}
+	}
+
 	singlechecker.Main(Analyzer)
 }
+
+// isSARIFFlag returns true if the command line argument enables the SARIF output.
+func isSARIFFlag(arg string) bool {
+	arg = strings.TrimPrefix(strings.TrimPrefix(arg, "-"), "-")
+	return arg == "sarif" || arg == "sarif=true"
+}
+
+// isBaselineFlag returns true if the command line argument is one of the baseline flags.
+func isBaselineFlag(arg string) bool {
+	name, _, _ := strings.Cut(strings.TrimPrefix(strings.TrimPrefix(arg, "-"), "-"), "=")
+	return name == "baseline" || name == "write-baseline"
+}
+
+// isInferredReportFlag returns true if the command line argument is the inferred report flag.
+func isInferredReportFlag(arg string) bool {
+	name, _, _ := strings.Cut(strings.TrimPrefix(strings.TrimPrefix(arg, "-"), "-"), "=")
+	return name == "inferred-report"
+}
+
+// isCacheDirFlag returns true if the command line argument is the cache directory flag.
+func isCacheDirFlag(arg string) bool {
+	name, _, _ := strings.Cut(strings.TrimPrefix(strings.TrimPrefix(arg, "-"), "-"), "=")
+	return name == "cache-dir"
+}
+
+// isWarningCategoriesFlag returns true if the command line argument is the warning categories flag.
+func isWarningCategoriesFlag(arg string) bool {
+	name, _, _ := strings.Cut(strings.TrimPrefix(strings.TrimPrefix(arg, "-"), "-"), "=")
+	return name == config.WarningCategoriesFlag
+}
+
+// isSinglecheckerModeFlag returns true if the command line argument is one of the flags selecting
+// the output modes of the singlechecker (i.e., -json, -fix and -diff).
+func isSinglecheckerModeFlag(arg string) bool {
+	name, _, _ := strings.Cut(strings.TrimPrefix(strings.TrimPrefix(arg, "-"), "-"), "=")
+	return name == "json" || name == "fix" || name == "diff"
+}
@@ -0,0 +1,101 @@
+//  Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"cmp"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+
+	"go.uber.org/nilaway/accumulation"
+	"go.uber.org/nilaway/inference"
+	"golang.org/x/tools/go/analysis/checker"
+)
+
+// This file implements the inferred report of the standalone checker, which dumps the inferred
+// nilabilities of all annotation sites (e.g., function parameters and results, struct fields and
+// global variables) of the analyzed packages, such that users can review what NilAway believes
+// about their code and diff the beliefs between releases to catch contract changes.
+
+// _inferredReportVersion is the version of the inferred report format.
+const _inferredReportVersion = 1
+
+// inferredReport is the on-disk format of an inferred report.
+type inferredReport struct {
+	Version  int              `json:"version"`
+	Packages []*packageReport `json:"packages"`
+}
+
+// packageReport contains the inferred nilabilities of the sites in a package.
+type packageReport struct {
+	Path  string                 `json:"path"`
+	Sites []inference.SiteReport `json:"sites"`
+}
+
+// newInferredReport creates the inferred report from the root actions of the accumulation analyzer
+// in the graph. The variants of a package (i.e., the package and its test variant) are merged into
+// one package report, where the values inferred without the test files take precedence.
+func newInferredReport(roots []*checker.Action) *inferredReport {
+	maps := make(map[string][]*inference.InferredMap)
+	for _, act := range roots {
+		if act.Analyzer != accumulation.Analyzer || act.Err != nil {
+			continue
+		}
+		result, _ := act.Result.(*accumulation.Result)
+		if result == nil || result.InferredMap == nil {
+			continue
+		}
+		path := act.Package.PkgPath
+		if act.Package.ID == path {
+			maps[path] = slices.Insert(maps[path], 0, result.InferredMap)
+		} else {
+			maps[path] = append(maps[path], result.InferredMap)
+		}
+	}
+
+	r := &inferredReport{Version: _inferredReportVersion, Packages: []*packageReport{}}
+	for path, m := range maps {
+		r.Packages = append(r.Packages, &packageReport{
+			Path: path,
+			// Sites must be an array (not null) even if there are no sites.
+			Sites: append([]inference.SiteReport{}, inference.Report(path, m...)...),
+		})
+	}
+	slices.SortFunc(r.Packages, func(x, y *packageReport) int { return cmp.Compare(x.Path, y.Path) })
+	return r
+}
+
+// write writes the inferred report in JSON format.
+func (r *inferredReport) write(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(r)
+}
+
+// writeInferredReportFile writes the inferred report to the given file.
+func writeInferredReportFile(path string, r *inferredReport) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("create inferred report file: %w", err)
+	}
+	if err := r.write(f); err != nil {
+		return errors.Join(fmt.Errorf("write inferred report file %q: %w", path, err), f.Close())
+	}
+	return f.Close()
+}
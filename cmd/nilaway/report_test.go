@@ -0,0 +1,42 @@
+//  Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestInferredReport(t *testing.T) {
+	t.Parallel()
+
+	// An empty report still has a (non-null) packages array.
+	var buf bytes.Buffer
+	require.NoError(t, newInferredReport(nil).write(&buf))
+	require.JSONEq(t, `{"version": 1, "packages": []}`, buf.String())
+}
+
+func TestIsInferredReportFlag(t *testing.T) {
+	t.Parallel()
+
+	for _, arg := range []string{"-inferred-report", "--inferred-report=a.json"} {
+		require.True(t, isInferredReportFlag(arg), arg)
+	}
+	for _, arg := range []string{"-inferred-reports", "-baseline", "./..."} {
+		require.False(t, isInferredReportFlag(arg), arg)
+	}
+}
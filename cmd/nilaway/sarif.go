@@ -0,0 +1,266 @@
+//  Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"go/token"
+	"io"
+	"path/filepath"
+
+	"go.uber.org/nilaway/diagnostic"
+)
+
+// This file implements the SARIF 2.1.0 output mode of the standalone checker. Only the subset of
+// the SARIF format needed for NilAway is modeled here, see the [specification] for details.
+//
+// [specification]: https://docs.oasis-open.org/sarif/sarif/v2.1.0/sarif-v2.1.0.html
+
+const (
+	_sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+	_sarifVersion = "2.1.0"
+	// _sarifSrcRoot is the base ID for relative artifact URIs, which are relative to the current
+	// working directory (i.e., the root of the analyzed source tree).
+	_sarifSrcRoot = "%SRCROOT%"
+	// _sarifRuleID is the ID of the only rule NilAway reports.
+	_sarifRuleID = "nilaway"
+)
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID               string       `json:"id"`
+	ShortDescription sarifMessage `json:"shortDescription"`
+}
+
+type sarifResult struct {
+	RuleID           string                 `json:"ruleId"`
+	Level            string                 `json:"level"`
+	Message          sarifMessage           `json:"message"`
+	Locations        []sarifLocation        `json:"locations,omitempty"`
+	CodeFlows        []sarifCodeFlow        `json:"codeFlows,omitempty"`
+	RelatedLocations []sarifLocation        `json:"relatedLocations,omitempty"`
+	Properties       *sarifResultProperties `json:"properties,omitempty"`
+}
+
+// sarifResultProperties is the property bag attached to each result.
+type sarifResultProperties struct {
+	// Category is the category of the nil flow or note (see config.Category).
+	Category string `json:"category,omitempty"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifLocation struct {
+	ID               *int                   `json:"id,omitempty"`
+	PhysicalLocation *sarifPhysicalLocation `json:"physicalLocation,omitempty"`
+	Message          *sarifMessage          `json:"message,omitempty"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           sarifRegion           `json:"region"`
+}
+
+type sarifArtifactLocation struct {
+	URI       string `json:"uri"`
+	URIBaseID string `json:"uriBaseId,omitempty"`
+}
+
+type sarifRegion struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn,omitempty"`
+}
+
+type sarifCodeFlow struct {
+	ThreadFlows []sarifThreadFlow `json:"threadFlows"`
+}
+
+type sarifThreadFlow struct {
+	Locations []sarifThreadFlowLocation `json:"locations"`
+}
+
+type sarifThreadFlowLocation struct {
+	Location   sarifLocation    `json:"location"`
+	Kinds      []string         `json:"kinds,omitempty"`
+	Properties *sarifProperties `json:"properties,omitempty"`
+}
+
+// sarifProperties is the property bag attached to each step of a thread flow, recording the
+// producer and consumer of the step separately (the location of the step is the consumer's).
+type sarifProperties struct {
+	Producer         string `json:"producer,omitempty"`
+	ProducerKind     string `json:"producerKind,omitempty"`
+	ProducerLocation string `json:"producerLocation,omitempty"`
+	Consumer         string `json:"consumer,omitempty"`
+	ConsumerKind     string `json:"consumerKind,omitempty"`
+}
+
+// sarifBuilder accumulates SARIF results from diagnostics of multiple packages. Duplicate results
+// (e.g., from a package and its test variant) are dropped.
+type sarifBuilder struct {
+	results []sarifResult
+	seen    map[string]bool
+}
+
+func newSARIFBuilder() *sarifBuilder {
+	return &sarifBuilder{seen: make(map[string]bool)}
+}
+
+// add converts the diagnostics to SARIF results. The file set is only used for diagnostics that
+// carry neither a structured nil flow nor a note.
+func (b *sarifBuilder) add(fset *token.FileSet, diagnostics []diagnostic.Diagnostic) {
+	for _, d := range diagnostics {
+		position := fset.Position(d.Pos)
+		switch {
+		case d.Flow != nil:
+			position = d.Flow.Position
+		case d.Note != nil:
+			position = d.Note.Position
+		}
+		key := fmt.Sprintf("%s:%s", position, d.Message)
+		if b.seen[key] {
+			continue
+		}
+		b.seen[key] = true
+		b.results = append(b.results, newSARIFResult(position, d))
+	}
+}
+
+// write writes the accumulated results as a SARIF log.
+func (b *sarifBuilder) write(w io.Writer) error {
+	log := sarifLog{
+		Schema:  _sarifSchema,
+		Version: _sarifVersion,
+		Runs: []sarifRun{{
+			Tool: sarifTool{Driver: sarifDriver{
+				Name:           Analyzer.Name,
+				InformationURI: "https://github.com/uber-go/nilaway",
+				Rules: []sarifRule{{
+					ID:               _sarifRuleID,
+					ShortDescription: sarifMessage{Text: "Potential nil panic"},
+				}},
+			}},
+			// Results must be an array (not null) even if there are no results.
+			Results: append([]sarifResult{}, b.results...),
+		}},
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(log)
+}
+
+// newSARIFResult converts a single diagnostic, reported at the given position, to a SARIF result.
+// The nil and non-nil paths of the nil flow become a single thread flow with one location per
+// step, and the grouped similar conflicts become related locations.
+func newSARIFResult(position token.Position, d diagnostic.Diagnostic) sarifResult {
+	result := sarifResult{
+		RuleID:  _sarifRuleID,
+		Level:   "error",
+		Message: sarifMessage{Text: d.Message},
+	}
+	if loc := newSARIFPhysicalLocation(position); loc != nil {
+		result.Locations = []sarifLocation{{PhysicalLocation: loc}}
+	}
+	if d.Severity() == diagnostic.SeverityWarning {
+		result.Level = "warning"
+	}
+	if d.Note != nil {
+		result.Properties = &sarifResultProperties{Category: string(d.Note.Category)}
+	}
+	if d.Flow == nil {
+		return result
+	}
+	if d.Flow.Category != "" {
+		result.Properties = &sarifResultProperties{Category: string(d.Flow.Category)}
+	}
+
+	var steps []sarifThreadFlowLocation
+	for _, n := range d.Flow.Nodes() {
+		step := sarifThreadFlowLocation{
+			Location: sarifLocation{
+				PhysicalLocation: newSARIFPhysicalLocation(n.ConsumerPosition),
+				Message:          &sarifMessage{Text: n.Reason()},
+			},
+			Properties: &sarifProperties{
+				Producer:     n.ProducerRepr,
+				ProducerKind: n.ProducerKind,
+				Consumer:     n.ConsumerRepr,
+				ConsumerKind: n.ConsumerKind,
+			},
+		}
+		if n.ProducerPosition.IsValid() {
+			step.Properties.ProducerLocation = n.ProducerPosition.String()
+		}
+		steps = append(steps, step)
+	}
+	if len(steps) > 0 {
+		// The last step is where the nil panic would occur.
+		steps[len(steps)-1].Kinds = []string{"danger"}
+		result.CodeFlows = []sarifCodeFlow{{ThreadFlows: []sarifThreadFlow{{Locations: steps}}}}
+	}
+
+	for i, s := range d.Flow.Similar {
+		id := i + 1
+		result.RelatedLocations = append(result.RelatedLocations, sarifLocation{
+			ID:               &id,
+			PhysicalLocation: newSARIFPhysicalLocation(s.DereferencePosition()),
+			Message:          &sarifMessage{Text: "Same nil source could also cause potential nil panic here"},
+		})
+	}
+	return result
+}
+
+// newSARIFPhysicalLocation converts a position to a SARIF physical location, or returns nil if
+// the position is invalid. Relative file names (i.e., relative to the current working directory)
+// are kept relative to the source root, and absolute ones are converted to file URIs.
+func newSARIFPhysicalLocation(position token.Position) *sarifPhysicalLocation {
+	if !position.IsValid() {
+		return nil
+	}
+	artifact := sarifArtifactLocation{URI: filepath.ToSlash(position.Filename), URIBaseID: _sarifSrcRoot}
+	if filepath.IsAbs(position.Filename) {
+		artifact = sarifArtifactLocation{URI: "file://" + filepath.ToSlash(position.Filename)}
+	}
+	return &sarifPhysicalLocation{
+		ArtifactLocation: artifact,
+		Region: sarifRegion{
+			StartLine:   position.Line,
+			StartColumn: position.Column,
+		},
+	}
+}
@@ -0,0 +1,162 @@
+//  Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"go/token"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/nilaway/config"
+	"go.uber.org/nilaway/diagnostic"
+	"golang.org/x/tools/go/analysis"
+)
+
+func TestNewSARIFResult(t *testing.T) {
+	t.Parallel()
+
+	pos := func(file string, line, col int) token.Position {
+		return token.Position{Filename: file, Line: line, Column: col, Offset: 1}
+	}
+	d := diagnostic.Diagnostic{
+		Diagnostic: analysis.Diagnostic{Message: "Potential nil panic detected."},
+		Flow: &diagnostic.Flow{
+			Position: pos("pkg/a.go", 14, 9),
+			NilPath: []diagnostic.FlowNode{{
+				ProducerPosition: pos("pkg/a.go", 5, 30),
+				ConsumerPosition: pos("pkg/a.go", 5, 30),
+				ProducerRepr:     "literal `nil`",
+				ConsumerRepr:     "returned from `f()` in position 0",
+			}},
+			NonnilPath: []diagnostic.FlowNode{{
+				ConsumerPosition: pos("pkg/a.go", 14, 9),
+				ProducerRepr:     "result 0 of `f()`",
+				ConsumerRepr:     "dereferenced",
+			}},
+			Similar:  []*diagnostic.Flow{{Position: pos("/abs/b.go", 3, 2)}},
+			Category: config.CategoryDereference,
+			Severity: diagnostic.SeverityError,
+		},
+	}
+
+	result := newSARIFResult(d.Flow.Position, d)
+	require.Equal(t, "Potential nil panic detected.", result.Message.Text)
+	require.Equal(t, "error", result.Level)
+	require.Equal(t, &sarifResultProperties{Category: "dereference"}, result.Properties)
+	require.Len(t, result.Locations, 1)
+	require.Equal(t, sarifArtifactLocation{URI: "pkg/a.go", URIBaseID: _sarifSrcRoot}, result.Locations[0].PhysicalLocation.ArtifactLocation)
+	require.Equal(t, sarifRegion{StartLine: 14, StartColumn: 9}, result.Locations[0].PhysicalLocation.Region)
+
+	// The nil path and non-nil path are merged into a single thread flow in program order.
+	require.Len(t, result.CodeFlows, 1)
+	require.Len(t, result.CodeFlows[0].ThreadFlows, 1)
+	steps := result.CodeFlows[0].ThreadFlows[0].Locations
+	require.Len(t, steps, 2)
+	require.Equal(t, "literal `nil` returned from `f()` in position 0", steps[0].Location.Message.Text)
+	require.Equal(t, 5, steps[0].Location.PhysicalLocation.Region.StartLine)
+	require.Equal(t, "pkg/a.go:5:30", steps[0].Properties.ProducerLocation)
+	require.Empty(t, steps[0].Kinds)
+	require.Equal(t, "result 0 of `f()` dereferenced", steps[1].Location.Message.Text)
+	require.Empty(t, steps[1].Properties.ProducerLocation)
+	require.Equal(t, []string{"danger"}, steps[1].Kinds)
+
+	// Similar conflicts become related locations.
+	require.Len(t, result.RelatedLocations, 1)
+	require.Equal(t, 1, *result.RelatedLocations[0].ID)
+	require.Equal(t, sarifArtifactLocation{URI: "file:///abs/b.go"}, result.RelatedLocations[0].PhysicalLocation.ArtifactLocation)
+
+	// Warnings are reported with the corresponding level.
+	d.Flow.Severity = diagnostic.SeverityWarning
+	require.Equal(t, "warning", newSARIFResult(d.Flow.Position, d).Level)
+
+	// Notes carry their category and severity without any code flows.
+	note := diagnostic.Diagnostic{
+		Diagnostic: analysis.Diagnostic{Message: "warning: Skipped the analysis of function `f()`"},
+		Note: &diagnostic.Note{
+			Position: pos("pkg/a.go", 3, 1),
+			Category: config.CategorySkippedFunction,
+			Severity: diagnostic.SeverityWarning,
+		},
+	}
+	result = newSARIFResult(note.Note.Position, note)
+	require.Equal(t, "warning", result.Level)
+	require.Equal(t, &sarifResultProperties{Category: "skipped-function"}, result.Properties)
+	require.Empty(t, result.CodeFlows)
+}
+
+func TestSARIFBuilder(t *testing.T) {
+	t.Parallel()
+
+	fset := token.NewFileSet()
+	file := fset.AddFile("internal.go", -1, 100)
+	file.SetLines([]int{0, 50})
+
+	// Diagnostics without a nil flow (e.g., internal errors) are located via the file set, and
+	// duplicates are dropped.
+	d := diagnostic.Diagnostic{Diagnostic: analysis.Diagnostic{Pos: file.Pos(51), Message: "INTERNAL ERROR"}, Internal: true}
+	b := newSARIFBuilder()
+	b.add(fset, []diagnostic.Diagnostic{d, d})
+
+	var buf bytes.Buffer
+	require.NoError(t, b.write(&buf))
+	var log sarifLog
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &log))
+	require.Equal(t, _sarifVersion, log.Version)
+	require.Len(t, log.Runs, 1)
+	require.Len(t, log.Runs[0].Results, 1)
+	result := log.Runs[0].Results[0]
+	require.Empty(t, result.CodeFlows)
+	require.Equal(t, sarifRegion{StartLine: 2, StartColumn: 2}, result.Locations[0].PhysicalLocation.Region)
+
+	// An empty run still has a (non-null) results array.
+	buf.Reset()
+	require.NoError(t, newSARIFBuilder().write(&buf))
+	require.Contains(t, buf.String(), `"results": []`)
+}
+
+func TestIsWarningCategoriesFlag(t *testing.T) {
+	t.Parallel()
+
+	for _, arg := range []string{"-warning-categories=return", "--warning-categories", "-warning-categories"} {
+		require.True(t, isWarningCategoriesFlag(arg), arg)
+	}
+	for _, arg := range []string{"-disable-categories=return", "./..."} {
+		require.False(t, isWarningCategoriesFlag(arg), arg)
+	}
+}
+
+func TestIsSinglecheckerModeFlag(t *testing.T) {
+	t.Parallel()
+
+	for _, arg := range []string{"-json", "--fix", "-diff=true"} {
+		require.True(t, isSinglecheckerModeFlag(arg), arg)
+	}
+	for _, arg := range []string{"-sarif", "-warning-categories=return", "./..."} {
+		require.False(t, isSinglecheckerModeFlag(arg), arg)
+	}
+}
+
+func TestIsSARIFFlag(t *testing.T) {
+	t.Parallel()
+
+	for _, arg := range []string{"-sarif", "--sarif", "-sarif=true"} {
+		require.True(t, isSARIFFlag(arg), arg)
+	}
+	for _, arg := range []string{"-sarif=false", "-json", "./..."} {
+		require.False(t, isSARIFFlag(arg), arg)
+	}
+}
@@ -0,0 +1,145 @@
+//  Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"strings"
+	"sync"
+)
+
+// StubDirective is the directive that, followed by an import path and written as a comment before
+// the package clause of a stub file (see AnnotationStubsFlag), declares the package the stub file
+// annotates (e.g., "//nilaway:stub github.com/foo/bar").
+const StubDirective = "//nilaway:stub"
+
+// AnnotationStub is a stub file loaded from AnnotationStubsFlag. It declares the nilabilities of
+// the functions, methods, struct fields and global variables of a package outside the analysis
+// scope with the usual `// nilable(...)` and `// nonnil(...)` annotations, using Go syntax for the
+// declarations (e.g., `func Find(name string) *Item` without a body). The stubs are shared among
+// all analyzed packages (see loadAnnotationStubsOnce), so they must not be modified.
+type AnnotationStub struct {
+	// PkgPath is the import path of the annotated package.
+	PkgPath string
+	// File is the syntax tree of the stub file. Note that its positions belong to Fset, so they
+	// cannot be resolved with the file set of an analysis pass.
+	File *ast.File
+	// Fset is the file set of File.
+	Fset *token.FileSet
+}
+
+// _loadedStubs caches the stubs loaded for each value of AnnotationStubsFlag (see
+// loadAnnotationStubsOnce).
+var _loadedStubs sync.Map // map[string]func() ([]*AnnotationStub, error)
+
+// loadAnnotationStubsOnce is like LoadAnnotationStubs for the comma-separated list of paths of
+// AnnotationStubsFlag, but reads and parses the stub files only once per process rather than
+// once for each analyzed package.
+func loadAnnotationStubsOnce(paths string) ([]*AnnotationStub, error) {
+	load, _ := _loadedStubs.LoadOrStore(paths, sync.OnceValues(func() ([]*AnnotationStub, error) {
+		return LoadAnnotationStubs(strings.Split(paths, ","))
+	}))
+	return load.(func() ([]*AnnotationStub, error))()
+}
+
+// LoadAnnotationStubs reads and validates the stub files at the given paths.
+func LoadAnnotationStubs(paths []string) ([]*AnnotationStub, error) {
+	fset := token.NewFileSet()
+	stubs := make([]*AnnotationStub, 0, len(paths))
+	for _, path := range paths {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("read annotation stub file: %w", err)
+		}
+		stub, err := parseAnnotationStub(fset, path, content)
+		if err != nil {
+			return nil, fmt.Errorf("parse annotation stub file %q: %w", path, err)
+		}
+		stubs = append(stubs, stub)
+	}
+	return stubs, nil
+}
+
+// parseAnnotationStub parses a single stub file, rejecting the declarations that cannot be mapped
+// to annotation sites so that mistakes in the file are reported rather than silently ignored.
+func parseAnnotationStub(fset *token.FileSet, name string, content []byte) (*AnnotationStub, error) {
+	file, err := parser.ParseFile(fset, name, content, parser.ParseComments)
+	if err != nil {
+		return nil, err
+	}
+
+	pkgPath, err := stubPkgPath(file)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, decl := range file.Decls {
+		switch decl := decl.(type) {
+		case *ast.FuncDecl:
+			if decl.Recv != nil && StubRecvTypeName(decl.Recv) == "" {
+				return nil, fmt.Errorf("%s: unsupported receiver of method %q", fset.Position(decl.Pos()), decl.Name.Name)
+			}
+		case *ast.GenDecl:
+			switch decl.Tok {
+			case token.IMPORT, token.VAR, token.TYPE:
+			default:
+				return nil, fmt.Errorf("%s: unsupported %s declaration", fset.Position(decl.Pos()), decl.Tok)
+			}
+		}
+	}
+	return &AnnotationStub{PkgPath: pkgPath, File: file, Fset: fset}, nil
+}
+
+// stubPkgPath returns the import path declared by the StubDirective of the stub file.
+func stubPkgPath(file *ast.File) (string, error) {
+	for _, group := range file.Comments {
+		if group.Pos() > file.Name.Pos() {
+			break
+		}
+		for _, comment := range group.List {
+			path, ok := strings.CutPrefix(strings.TrimSpace(comment.Text), StubDirective+" ")
+			if path = strings.TrimSpace(path); ok && path != "" {
+				return path, nil
+			}
+		}
+	}
+	return "", fmt.Errorf("missing %q directive with the import path before the package clause", StubDirective)
+}
+
+// StubRecvTypeName returns the name of the (possibly pointer or generic) receiver type of a method
+// declared in a stub file, or "" if the receiver is malformed.
+func StubRecvTypeName(recv *ast.FieldList) string {
+	if recv == nil || len(recv.List) != 1 {
+		return ""
+	}
+	typ := recv.List[0].Type
+	if star, ok := typ.(*ast.StarExpr); ok {
+		typ = star.X
+	}
+	switch t := typ.(type) {
+	case *ast.IndexExpr:
+		typ = t.X
+	case *ast.IndexListExpr:
+		typ = t.X
+	}
+	if ident, ok := typ.(*ast.Ident); ok {
+		return ident.Name
+	}
+	return ""
+}
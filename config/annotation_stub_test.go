@@ -0,0 +1,170 @@
+//  Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"go/ast"
+	"go/token"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseAnnotationStub(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		content  string
+		wantPath string
+		wantErr  string
+	}{
+		{
+			name: "valid",
+			content: `//nilaway:stub example.com/foo
+package foo
+
+import "io"
+
+// nilable(result 0)
+func Open(name string) io.Reader
+
+// nilable(r)
+func (r *Reader[T]) Read() T
+
+// nilable(Next)
+type Node struct{ Next *Node }
+
+// nilable(Default)
+var Default *Node
+`,
+			wantPath: "example.com/foo",
+		},
+		{
+			name:     "directive in doc string",
+			content:  "// Package foo is stubbed.\n//\n//nilaway:stub  example.com/foo \npackage foo\n",
+			wantPath: "example.com/foo",
+		},
+		{
+			name:    "missing directive",
+			content: "package foo\n",
+			wantErr: "missing \"//nilaway:stub\" directive",
+		},
+		{
+			name:    "directive without path",
+			content: "//nilaway:stub\npackage foo\n",
+			wantErr: "missing \"//nilaway:stub\" directive",
+		},
+		{
+			name:    "directive after package clause",
+			content: "package foo\n\n//nilaway:stub example.com/foo\n",
+			wantErr: "missing \"//nilaway:stub\" directive",
+		},
+		{
+			name:    "syntax error",
+			content: "//nilaway:stub example.com/foo\npackage foo\n\nfunc (\n",
+			wantErr: "expected",
+		},
+		{
+			name:    "const declaration",
+			content: "//nilaway:stub example.com/foo\npackage foo\n\nconst C = 1\n",
+			wantErr: "unsupported const declaration",
+		},
+		{
+			name:    "unsupported receiver",
+			content: "//nilaway:stub example.com/foo\npackage foo\n\nfunc (f foo.Bar) M()\n",
+			wantErr: "unsupported receiver of method \"M\"",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			stub, err := parseAnnotationStub(token.NewFileSet(), "stub.nilaway", []byte(tt.content))
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.wantPath, stub.PkgPath)
+			require.NotNil(t, stub.File)
+		})
+	}
+}
+
+func TestStubRecvTypeName(t *testing.T) {
+	t.Parallel()
+
+	recv := func(typ ast.Expr) *ast.FieldList {
+		return &ast.FieldList{List: []*ast.Field{{Type: typ}}}
+	}
+	ident := ast.NewIdent("T")
+	require.Equal(t, "T", StubRecvTypeName(recv(ident)))
+	require.Equal(t, "T", StubRecvTypeName(recv(&ast.StarExpr{X: ident})))
+	require.Equal(t, "T", StubRecvTypeName(recv(&ast.StarExpr{X: &ast.IndexExpr{X: ident, Index: ast.NewIdent("E")}})))
+	require.Equal(t, "T", StubRecvTypeName(recv(&ast.IndexListExpr{X: ident, Indices: []ast.Expr{ast.NewIdent("K"), ast.NewIdent("V")}})))
+	require.Empty(t, StubRecvTypeName(recv(&ast.SelectorExpr{X: ast.NewIdent("foo"), Sel: ident})))
+	require.Empty(t, StubRecvTypeName(nil))
+}
+
+func TestLoadAnnotationStubs(t *testing.T) {
+	t.Parallel()
+
+	_, err := LoadAnnotationStubs([]string{filepath.Join(t.TempDir(), "nonexistent.nilaway")})
+	require.ErrorContains(t, err, "read annotation stub file")
+
+	dir := t.TempDir()
+	valid := filepath.Join(dir, "valid.nilaway")
+	require.NoError(t, os.WriteFile(valid, []byte("//nilaway:stub example.com/foo\npackage foo\n"), 0o600))
+	invalid := filepath.Join(dir, "invalid.nilaway")
+	require.NoError(t, os.WriteFile(invalid, []byte("package foo\n"), 0o600))
+
+	stubs, err := LoadAnnotationStubs([]string{valid})
+	require.NoError(t, err)
+	require.Len(t, stubs, 1)
+	require.Equal(t, "example.com/foo", stubs[0].PkgPath)
+
+	_, err = LoadAnnotationStubs([]string{valid, invalid})
+	require.ErrorContains(t, err, invalid)
+}
+
+func TestLoadAnnotationStubsOnce(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "stub.nilaway")
+	require.NoError(t, os.WriteFile(path, []byte("//nilaway:stub example.com/foo\npackage foo\n"), 0o600))
+
+	stubs, err := loadAnnotationStubsOnce(path)
+	require.NoError(t, err)
+	require.Len(t, stubs, 1)
+
+	// The stub file is not read again for the same flag value.
+	require.NoError(t, os.Remove(path))
+	cached, err := loadAnnotationStubsOnce(path)
+	require.NoError(t, err)
+	require.Same(t, stubs[0], cached[0])
+
+	// The errors are cached as well.
+	missing := filepath.Join(dir, "missing.nilaway")
+	_, err = loadAnnotationStubsOnce(missing)
+	require.ErrorContains(t, err, "read annotation stub file")
+	require.NoError(t, os.WriteFile(missing, []byte("//nilaway:stub example.com/bar\npackage bar\n"), 0o600))
+	_, err = loadAnnotationStubsOnce(missing)
+	require.ErrorContains(t, err, "read annotation stub file")
+}
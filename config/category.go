@@ -0,0 +1,109 @@
+//  Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"fmt"
+	"slices"
+)
+
+// Category is the stable, user-facing code of a class of diagnostics. Each reported nil flow is
+// categorized by the most specific consumer along the flow, and each note (e.g., a skipped
+// function) has a category of its own, such that users can disable or downgrade the classes of
+// errors they care less about (see DisableCategoriesFlag and WarningCategoriesFlag). The codes are
+// part of NilAway's output and configuration, so they must never be renamed.
+type Category string
+
+const (
+	// CategoryDereference is for pointer dereferences and field accesses.
+	CategoryDereference Category = "dereference"
+	// CategoryMethodReceiver is for method calls on receivers that must be nonnil.
+	CategoryMethodReceiver Category = "method-receiver"
+	// CategoryMapAccess is for reads from and writes to maps.
+	CategoryMapAccess Category = "map-access"
+	// CategorySliceAccess is for indexing slices.
+	CategorySliceAccess Category = "slice-access"
+	// CategoryTypeAssertion is for single-value type assertions on interfaces.
+	CategoryTypeAssertion Category = "type-assertion"
+	// CategoryClosedChannel is for sends on channels that may be closed.
+	CategoryClosedChannel Category = "closed-channel"
+	// CategoryErrorReturn is for violations of the error-return contract, i.e., non-error results
+	// returned nil alongside a nil error, or nil errors whose nilability is unknown.
+	CategoryErrorReturn Category = "error-return"
+	// CategoryReturn is for results (or their fields) that must be nonnil.
+	CategoryReturn Category = "return"
+	// CategoryArgument is for arguments (or their fields) passed to nonnil parameters.
+	CategoryArgument Category = "argument"
+	// CategoryInterfaceContract is for implementations violating the nilability of the interface
+	// methods they implement.
+	CategoryInterfaceContract Category = "interface-contract"
+	// CategoryFieldAssign is for assignments to nonnil struct fields.
+	CategoryFieldAssign Category = "field-assign"
+	// CategoryGlobalAssign is for assignments to nonnil global variables.
+	CategoryGlobalAssign Category = "global-assign"
+	// CategoryDeepNilability is for the nilability of the elements of pointers, slices, arrays,
+	// maps and channels (i.e., "deep" nilability) and for sends on channels.
+	CategoryDeepNilability Category = "deep-nilability"
+	// CategoryFieldEscape is for nilable fields of structs escaping the function.
+	CategoryFieldEscape Category = "field-escape"
+	// CategoryOther is for generic consumers that do not fall into any of the categories above.
+	CategoryOther Category = "other"
+
+	// CategorySkippedFunction is for the functions skipped by the analysis (e.g., because they are
+	// too large), which are reported as notes rather than nil flows.
+	CategorySkippedFunction Category = "skipped-function"
+	// CategoryInvalidContract is for the handwritten function contracts that are malformed or do
+	// not match the signatures of their functions, and are hence ignored by the analysis.
+	CategoryInvalidContract Category = "invalid-contract"
+	// CategoryContractViolation is for the handwritten function contracts that are violated by the
+	// bodies of their functions.
+	CategoryContractViolation Category = "contract-violation"
+	// CategoryInvalidStub is for the declarations in annotation stub files that do not match any
+	// object of the stubbed packages, and are hence ignored by the analysis.
+	CategoryInvalidStub Category = "invalid-stub"
+)
+
+// Categories lists all the categories in a stable order.
+var Categories = []Category{
+	CategoryDereference,
+	CategoryMethodReceiver,
+	CategoryMapAccess,
+	CategorySliceAccess,
+	CategoryTypeAssertion,
+	CategoryClosedChannel,
+	CategoryErrorReturn,
+	CategoryReturn,
+	CategoryArgument,
+	CategoryInterfaceContract,
+	CategoryFieldAssign,
+	CategoryGlobalAssign,
+	CategoryDeepNilability,
+	CategoryFieldEscape,
+	CategoryOther,
+	CategorySkippedFunction,
+	CategoryInvalidContract,
+	CategoryContractViolation,
+	CategoryInvalidStub,
+}
+
+// CheckCategories returns an error if any of the given codes is not a known category.
+func CheckCategories(codes []string) error {
+	for _, c := range codes {
+		if !slices.Contains(Categories, Category(c)) {
+			return fmt.Errorf("unknown diagnostic category %q, must be one of %v", c, Categories)
+		}
+	}
+	return nil
+}
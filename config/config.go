@@ -17,10 +17,12 @@ package config
 
 import (
 	"flag"
+	"fmt"
 	"go/ast"
 	"go/types"
 	"os"
 	"reflect"
+	"slices"
 	"strings"
 
 	"go.uber.org/nilaway/util/asthelper"
@@ -48,18 +50,41 @@ type Config struct {
 	GroupErrorMessages bool
 	// ExperimentalStructInitEnable indicates whether experimental struct initialization is enabled.
 	ExperimentalStructInitEnable bool
-	// ExperimentalAnonymousFuncEnable indicates whether experimental anonymous function support is enabled.
+	// ExperimentalAnonymousFuncEnable indicates whether anonymous function support is enabled.
 	ExperimentalAnonymousFuncEnable bool
 	// PrintFullFilePath incidates whether to print full filenames in the output.
 	PrintFullFilePath bool
 	// ExcludeTestFiles indicates whether to exclude diagnostics that involve test files.
 	ExcludeTestFiles bool
+	// TrustedModels maps each action to the user-declared trusted function, method, and global
+	// variable models with that action, which are merged with the built-in ones in the hook
+	// package. The models are grouped once when loaded, since they are consulted for every call.
+	TrustedModels map[TrustedAction][]*TrustedModel
+	// AnnotationStubs is the list of user-provided stub files declaring the nilabilities of the
+	// objects in packages outside the analysis scope.
+	AnnotationStubs []*AnnotationStub
+	// DisabledCategories is the list of diagnostic categories (see Category) that are
+	// not reported.
+	DisabledCategories []string
+	// WarningCategories is the list of diagnostic categories (see Category) that are
+	// reported as warnings rather than errors.
+	WarningCategories []string
+	// MaxFuncSizeInCFGBlocks is the maximum size of the functions, in number of CFG blocks, that
+	// are fully analyzed. Larger functions are only summarized by their return statements. A
+	// non-positive value means no limit.
+	MaxFuncSizeInCFGBlocks int
+	// StableRoundLimit is the number of rounds without changes in the collected triggers after
+	// which the backpropagation of a function halts.
+	StableRoundLimit int
 
 	// includePkgs is the list of packages to analyze.
 	includePkgs []string
 	// excludePkgs is the list of packages to exclude from analysis. Exclude list takes
 	// precedence over the include list.
 	excludePkgs []string
+	// strictPkgs is the list of package prefixes to run in strict (annotation-only) mode, where
+	// nilabilities are not inferred but determined by annotations and defaults only.
+	strictPkgs []string
 	// excludeFileDocStrings is the list of doc strings that, if they appear in the file doc
 	// string, will cause the file to be excluded from analysis. Examples include "@generated" and
 	// "Code generated by".
@@ -89,6 +114,16 @@ func (c *Config) IsPkgInScope(pkg *types.Package) bool {
 	return false
 }
 
+// IsPkgStrict returns true iff the passed package is configured to run in strict (annotation-only)
+// mode, i.e., it has one of the configured strict package prefixes. Note that packages can also opt
+// in via the StrictDirective (see inference.DetermineMode).
+func (c *Config) IsPkgStrict(pkg *types.Package) bool {
+	if pkg == nil {
+		return false
+	}
+	return slices.ContainsFunc(c.strictPkgs, func(prefix string) bool { return strings.HasPrefix(pkg.Path(), prefix) })
+}
+
 // IsFileInScope returns true iff we should analyze the file. It checks the docstring of the file
 // and returns false if any of the strings in ExcludeFileDocStrings appear in the file docstring.
 //
@@ -148,14 +183,36 @@ const (
 	ExcludeFileDocStringsFlag = "exclude-file-docstrings"
 	// ExperimentalStructInitEnableFlag is the flag name for the experimental struct init support.
 	ExperimentalStructInitEnableFlag = "experimental-struct-init"
-	// ExperimentalAnonymousFunctionFlag is the flag name for the experimental anonymous function support.
+	// ExperimentalAnonymousFunctionFlag is the flag name for the anonymous function support. It keeps
+	// its "experimental" name for compatibility with existing configurations.
 	ExperimentalAnonymousFunctionFlag = "experimental-anonymous-function"
 	// PrintFullFilePathFlag is the flag name for printing full filenames in output.
 	PrintFullFilePathFlag = "print-full-file-path"
 	// ExcludeTestFilesFlag is the flag name for excluding diagnostics involving test files.
 	ExcludeTestFilesFlag = "exclude-test-files"
+	// TrustedFuncModelsFlag is the flag name for the file declaring extra trusted function models.
+	TrustedFuncModelsFlag = "trusted-func-models"
+	// AnnotationStubsFlag is the flag name for the stub files annotating packages outside the
+	// analysis scope.
+	AnnotationStubsFlag = "annotation-stubs"
+	// StrictPkgsFlag is the flag name for the package prefixes to run in strict (annotation-only) mode.
+	StrictPkgsFlag = "strict-pkgs"
+	// DisableCategoriesFlag is the flag name for the diagnostic categories not to report.
+	DisableCategoriesFlag = "disable-categories"
+	// WarningCategoriesFlag is the flag name for the diagnostic categories to report as warnings.
+	WarningCategoriesFlag = "warning-categories"
+	// MaxFuncSizeFlag is the flag name for the maximum size of fully analyzed functions, in number
+	// of CFG blocks.
+	MaxFuncSizeFlag = "max-func-size"
+	// StableRoundLimitFlag is the flag name for the number of stable rounds after which the
+	// backpropagation of a function halts.
+	StableRoundLimitFlag = "stable-round-limit"
 )
 
+// StrictDirective is the directive that, when written as a comment before the package clause of
+// any file in a package, runs the package in strict (annotation-only) mode just like StrictPkgsFlag.
+const StrictDirective = "//nilaway:strict"
+
 // newFlagSet returns a flag set to be used in the nilaway config analyzer.
 func newFlagSet() flag.FlagSet {
 	fs := flag.NewFlagSet("nilaway_config", flag.ExitOnError)
@@ -168,9 +225,16 @@ func newFlagSet() flag.FlagSet {
 	_ = fs.String(ExcludePkgsFlag, "", "Comma-separated list of packages to exclude from analysis")
 	_ = fs.String(ExcludeFileDocStringsFlag, "", "Comma-separated list of docstrings to exclude from analysis")
 	_ = fs.Bool(ExperimentalStructInitEnableFlag, false, "Whether to enable experimental struct initialization support")
-	_ = fs.Bool(ExperimentalAnonymousFunctionFlag, false, "Whether to enable experimental anonymous function support")
+	_ = fs.Bool(ExperimentalAnonymousFunctionFlag, true, "Whether to enable anonymous function support")
 	_ = fs.Bool(PrintFullFilePathFlag, false, "Whether to show full filenames in output")
 	_ = fs.Bool(ExcludeTestFilesFlag, false, "Whether to exclude diagnostics involving test files")
+	_ = fs.String(TrustedFuncModelsFlag, "", "Path to a JSON file declaring extra trusted function models")
+	_ = fs.String(AnnotationStubsFlag, "", "Comma-separated list of stub files annotating the nilabilities of packages outside the analysis scope")
+	_ = fs.String(StrictPkgsFlag, "", "Comma-separated list of package prefixes to check against annotations only, without inference")
+	_ = fs.String(DisableCategoriesFlag, "", "Comma-separated list of diagnostic categories not to report")
+	_ = fs.String(WarningCategoriesFlag, "", "Comma-separated list of diagnostic categories to report as warnings rather than errors")
+	_ = fs.Int(MaxFuncSizeFlag, DefaultMaxFuncSizeInCFGBlocks, "Maximum number of CFG blocks of a function to be fully analyzed (non-positive for no limit); larger functions are only summarized by their return statements")
+	_ = fs.Int(StableRoundLimitFlag, DefaultStableRoundLimit, "Number of rounds without changes after which the backpropagation of a function halts")
 
 	return *fs
 }
@@ -178,8 +242,10 @@ func newFlagSet() flag.FlagSet {
 func run(pass *analysis.Pass) (any, error) {
 	// Set up default values for the config.
 	conf := &Config{
-		PrettyPrint:        defaultPrettyPrint(),
-		GroupErrorMessages: true,
+		PrettyPrint:            defaultPrettyPrint(),
+		GroupErrorMessages:     true,
+		MaxFuncSizeInCFGBlocks: DefaultMaxFuncSizeInCFGBlocks,
+		StableRoundLimit:       DefaultStableRoundLimit,
 		// If the user does not provide an include list, we give an empty package prefix to catch
 		// all packages.
 		includePkgs: []string{""},
@@ -210,9 +276,47 @@ func run(pass *analysis.Pass) (any, error) {
 	if exclude, ok := pass.Analyzer.Flags.Lookup(ExcludePkgsFlag).Value.(flag.Getter).Get().(string); ok && exclude != "" {
 		conf.excludePkgs = strings.Split(exclude, ",")
 	}
+	if strict, ok := pass.Analyzer.Flags.Lookup(StrictPkgsFlag).Value.(flag.Getter).Get().(string); ok && strict != "" {
+		conf.strictPkgs = strings.Split(strict, ",")
+	}
+	if disabled, ok := pass.Analyzer.Flags.Lookup(DisableCategoriesFlag).Value.(flag.Getter).Get().(string); ok && disabled != "" {
+		conf.DisabledCategories = strings.Split(disabled, ",")
+	}
+	if warning, ok := pass.Analyzer.Flags.Lookup(WarningCategoriesFlag).Value.(flag.Getter).Get().(string); ok && warning != "" {
+		conf.WarningCategories = strings.Split(warning, ",")
+	}
+	if err := CheckCategories(slices.Concat(conf.DisabledCategories, conf.WarningCategories)); err != nil {
+		return nil, fmt.Errorf("invalid diagnostic category configuration: %w", err)
+	}
+	if maxFuncSize, ok := pass.Analyzer.Flags.Lookup(MaxFuncSizeFlag).Value.(flag.Getter).Get().(int); ok {
+		conf.MaxFuncSizeInCFGBlocks = maxFuncSize
+	}
+	if limit, ok := pass.Analyzer.Flags.Lookup(StableRoundLimitFlag).Value.(flag.Getter).Get().(int); ok {
+		if limit < 1 {
+			return nil, fmt.Errorf("invalid value %d for flag %q: must be positive", limit, StableRoundLimitFlag)
+		}
+		conf.StableRoundLimit = limit
+	}
 	if docstrings, ok := pass.Analyzer.Flags.Lookup(ExcludeFileDocStringsFlag).Value.(flag.Getter).Get().(string); ok && docstrings != "" {
 		conf.excludeFileDocStrings = strings.Split(docstrings, ",")
 	}
+	if path, ok := pass.Analyzer.Flags.Lookup(TrustedFuncModelsFlag).Value.(flag.Getter).Get().(string); ok && path != "" {
+		models, err := LoadTrustedModels(path)
+		if err != nil {
+			return nil, err
+		}
+		conf.TrustedModels = make(map[TrustedAction][]*TrustedModel)
+		for _, m := range models {
+			conf.TrustedModels[m.Action] = append(conf.TrustedModels[m.Action], m)
+		}
+	}
+	if paths, ok := pass.Analyzer.Flags.Lookup(AnnotationStubsFlag).Value.(flag.Getter).Get().(string); ok && paths != "" {
+		stubs, err := loadAnnotationStubsOnce(paths)
+		if err != nil {
+			return nil, err
+		}
+		conf.AnnotationStubs = stubs
+	}
 
 	return conf, nil
 }
@@ -16,17 +16,23 @@ package config
 
 // This file hosts non-user-configurable parameters --- these are for development and testing purposes only.
 
-// StableRoundLimit is the number of rounds in backpropagation algorithm after which, if there is no change
-// in the collected triggers, the algorithm halts. It is possible to carefully craft known false negative for any value
-// of StableRoundLimit (check test loopflow.go/longRotNilLoop). Setting this value too low may result in false negatives
-// going undetected, while setting it too high may lead to longer analysis times without significant precision gains.
-// In practice, a value of StableRoundLimit >= 2 has shown to provide sound analysis, capturing most false negatives.
-// After experimentation, we observed that using StableRoundLimit = 5 with NilAway yields similar analysis time compared
-// to lower values, making it a good compromise for precise results.
-const StableRoundLimit = 5
+// DefaultStableRoundLimit is the default number of rounds in backpropagation algorithm after which, if there is no
+// change in the collected triggers, the algorithm halts (see StableRoundLimitFlag). It is possible to carefully craft
+// known false negative for any value of the limit (check test loopflow.go/longRotNilLoop). Setting this value too low
+// may result in false negatives going undetected, while setting it too high may lead to longer analysis times without
+// significant precision gains. In practice, a value >= 2 has shown to provide sound analysis, capturing most false
+// negatives. After experimentation, we observed that using a limit of 5 with NilAway yields similar analysis time
+// compared to lower values, making it a good compromise for precise results.
+const DefaultStableRoundLimit = 5
+
+// DefaultMaxFuncSizeInCFGBlocks is the default limit on the size of the functions, in number of CFG blocks, that are
+// fully analyzed (see MaxFuncSizeFlag). The limit is based on the number of CFG blocks, which provides a better
+// measure of function complexity than token/byte count.
+const DefaultMaxFuncSizeInCFGBlocks = 500
 
 // NilAwayNoInferString is the string that may be inserted into the docstring for a package to prevent
-// NilAway from inferring the annotations for that package - this is useful for unit tests
+// NilAway from inferring the annotations for that package - this is useful for unit tests. Users
+// should use the StrictPkgsFlag or the StrictDirective instead.
 const NilAwayNoInferString = "<nilaway no inference>"
 
 const uberPkgPathPrefix = "go.uber.org"
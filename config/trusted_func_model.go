@@ -0,0 +1,173 @@
+//  Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+)
+
+// TrustedKind is the kind of entity a user-declared trusted model matches. It mirrors the kinds of
+// the built-in trusted signatures in the hook package.
+type TrustedKind string
+
+const (
+	// TrustedKindFunc matches a top-level function, where the enclosing regex is matched against
+	// the package path (e.g., "github.com/stretchr/testify/assert").
+	TrustedKindFunc TrustedKind = "func"
+	// TrustedKindMethod matches a method, where the enclosing regex is matched against
+	// "<pkg path>.<type name>" (e.g., "go.uber.org/zap.Logger").
+	TrustedKindMethod TrustedKind = "method"
+	// TrustedKindVar matches a package-level variable, where the enclosing regex is matched
+	// against the package path (e.g., "os").
+	TrustedKindVar TrustedKind = "var"
+)
+
+// TrustedAction is the effect a user-declared trusted model has on the analysis.
+type TrustedAction string
+
+const (
+	// TrustedActionNonnilReturn models a function whose (first) result is never nil.
+	TrustedActionNonnilReturn TrustedAction = "nonnil-return"
+	// TrustedActionSplitOnNil models a function that only returns normally if argument `arg` is
+	// nil (e.g., `assert.Nil(t, x)`).
+	TrustedActionSplitOnNil TrustedAction = "split-on-nil"
+	// TrustedActionSplitOnNonnil models a function that only returns normally if argument `arg`
+	// is nonnil (e.g., `assert.NotNil(t, x)`).
+	TrustedActionSplitOnNonnil TrustedAction = "split-on-nonnil"
+	// TrustedActionSplitOnTrue models a function that only returns normally if the boolean
+	// argument `arg` is true (e.g., `assert.True(t, ok)`).
+	TrustedActionSplitOnTrue TrustedAction = "split-on-true"
+	// TrustedActionSplitOnFalse models a function that only returns normally if the boolean
+	// argument `arg` is false (e.g., `assert.False(t, ok)`).
+	TrustedActionSplitOnFalse TrustedAction = "split-on-false"
+	// TrustedActionNoReturn models a function that never returns (e.g., `log.Fatal`).
+	TrustedActionNoReturn TrustedAction = "no-return"
+	// TrustedActionErrorReturnNonnilArg models a function whose nil error return guarantees that
+	// the pointee of the `&x` argument at index `arg` is nonnil (e.g., `json.Unmarshal(b, &x)`).
+	TrustedActionErrorReturnNonnilArg TrustedAction = "error-return-nonnil-arg"
+	// TrustedActionSyncCall models a function that always runs the function literals passed to it
+	// synchronously, before it returns (e.g., a worker pool's `Run`).
+	TrustedActionSyncCall TrustedAction = "sync-call"
+	// TrustedActionNonnilGlobal models a package-level variable that is never nil.
+	TrustedActionNonnilGlobal TrustedAction = "nonnil-global"
+)
+
+// TrustedModel is a single user-declared model of a function, method, or package-level variable,
+// loaded from the file given by TrustedFuncModelsFlag. It carries the same information as the
+// built-in trusted signatures in the hook package, plus the action to apply on a match.
+type TrustedModel struct {
+	// Kind is the kind of the modeled entity.
+	Kind TrustedKind `json:"kind"`
+	// Enclosing is the regex matched against the enclosing package path (or "<pkg path>.<type
+	// name>" for methods).
+	Enclosing string `json:"enclosing"`
+	// Name is the regex matched against the name of the function, method, or variable.
+	Name string `json:"name"`
+	// Action is the effect of the model.
+	Action TrustedAction `json:"action"`
+	// Arg is the index of the argument the action applies to. It is only meaningful for the
+	// split-on-* and error-return-nonnil-arg actions.
+	Arg int `json:"arg,omitempty"`
+
+	// EnclosingRegex and NameRegex are the compiled forms of Enclosing and Name.
+	EnclosingRegex *regexp.Regexp `json:"-"`
+	NameRegex      *regexp.Regexp `json:"-"`
+}
+
+// trustedModelFile is the on-disk format of the file given by TrustedFuncModelsFlag.
+type trustedModelFile struct {
+	Models []*TrustedModel `json:"models"`
+}
+
+// LoadTrustedModels reads, validates, and compiles the trusted models in the given JSON file.
+func LoadTrustedModels(path string) ([]*TrustedModel, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read trusted models file: %w", err)
+	}
+	models, err := parseTrustedModels(content)
+	if err != nil {
+		return nil, fmt.Errorf("parse trusted models file %q: %w", path, err)
+	}
+	return models, nil
+}
+
+// parseTrustedModels decodes the trusted models from the given JSON content, rejecting unknown
+// keys so that typos in the file are reported rather than silently ignored.
+func parseTrustedModels(content []byte) ([]*TrustedModel, error) {
+	decoder := json.NewDecoder(bytes.NewReader(content))
+	decoder.DisallowUnknownFields()
+	var file trustedModelFile
+	if err := decoder.Decode(&file); err != nil {
+		return nil, err
+	}
+
+	for i, m := range file.Models {
+		if m == nil {
+			return nil, fmt.Errorf("model #%d: empty entry", i)
+		}
+		if err := m.compile(); err != nil {
+			return nil, fmt.Errorf("model #%d (%s %q): %w", i, m.Kind, m.Name, err)
+		}
+	}
+	return file.Models, nil
+}
+
+// compile validates the model and compiles its regexes.
+func (m *TrustedModel) compile() error {
+	switch m.Kind {
+	case TrustedKindFunc, TrustedKindMethod:
+		if m.Action == TrustedActionNonnilGlobal {
+			return fmt.Errorf("action %q is only valid for kind %q", m.Action, TrustedKindVar)
+		}
+	case TrustedKindVar:
+		if m.Action != TrustedActionNonnilGlobal {
+			return fmt.Errorf("kind %q only supports action %q", m.Kind, TrustedActionNonnilGlobal)
+		}
+	default:
+		return fmt.Errorf("unknown kind %q, expected one of %q, %q, %q",
+			m.Kind, TrustedKindFunc, TrustedKindMethod, TrustedKindVar)
+	}
+
+	switch m.Action {
+	case TrustedActionNonnilReturn, TrustedActionNoReturn, TrustedActionSyncCall, TrustedActionNonnilGlobal:
+	case TrustedActionSplitOnNil, TrustedActionSplitOnNonnil, TrustedActionSplitOnTrue,
+		TrustedActionSplitOnFalse, TrustedActionErrorReturnNonnilArg:
+		if m.Arg < 0 {
+			return fmt.Errorf("negative argument index %d", m.Arg)
+		}
+	case "":
+		return errors.New("missing action")
+	default:
+		return fmt.Errorf("unknown action %q", m.Action)
+	}
+
+	if m.Enclosing == "" || m.Name == "" {
+		return errors.New("both enclosing and name regexes must be provided")
+	}
+	var err error
+	if m.EnclosingRegex, err = regexp.Compile(m.Enclosing); err != nil {
+		return fmt.Errorf("invalid enclosing regex: %w", err)
+	}
+	if m.NameRegex, err = regexp.Compile(m.Name); err != nil {
+		return fmt.Errorf("invalid name regex: %w", err)
+	}
+	return nil
+}
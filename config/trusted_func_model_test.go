@@ -0,0 +1,130 @@
+//  Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTrustedModels(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{
+			name:    "valid",
+			content: `{"models": [{"kind": "func", "enclosing": "^foo$", "name": "^Bar$", "action": "split-on-nil", "arg": 1}]}`,
+		},
+		{
+			name:    "valid sync call",
+			content: `{"models": [{"kind": "method", "enclosing": "^foo.Pool$", "name": "^Run$", "action": "sync-call"}]}`,
+		},
+		{
+			name:    "empty",
+			content: `{"models": []}`,
+		},
+		{
+			name:    "malformed JSON",
+			content: `{"models": [`,
+			wantErr: "unexpected EOF",
+		},
+		{
+			name:    "unknown field",
+			content: `{"models": [{"kind": "func", "enclosing": "^foo$", "name": "^Bar$", "action": "no-return", "argIndex": 1}]}`,
+			wantErr: "unknown field",
+		},
+		{
+			name:    "unknown kind",
+			content: `{"models": [{"kind": "struct", "enclosing": "^foo$", "name": "^Bar$", "action": "no-return"}]}`,
+			wantErr: `model #0 (struct "^Bar$"): unknown kind "struct"`,
+		},
+		{
+			name:    "unknown action",
+			content: `{"models": [{"kind": "func", "enclosing": "^foo$", "name": "^Bar$", "action": "panic"}]}`,
+			wantErr: `unknown action "panic"`,
+		},
+		{
+			name:    "missing action",
+			content: `{"models": [{"kind": "func", "enclosing": "^foo$", "name": "^Bar$"}]}`,
+			wantErr: "missing action",
+		},
+		{
+			name:    "global action on function",
+			content: `{"models": [{"kind": "func", "enclosing": "^foo$", "name": "^Bar$", "action": "nonnil-global"}]}`,
+			wantErr: `only valid for kind "var"`,
+		},
+		{
+			name:    "function action on global",
+			content: `{"models": [{"kind": "var", "enclosing": "^foo$", "name": "^Bar$", "action": "nonnil-return"}]}`,
+			wantErr: `kind "var" only supports action "nonnil-global"`,
+		},
+		{
+			name:    "negative argument index",
+			content: `{"models": [{"kind": "func", "enclosing": "^foo$", "name": "^Bar$", "action": "split-on-nonnil", "arg": -1}]}`,
+			wantErr: "negative argument index -1",
+		},
+		{
+			name:    "missing regex",
+			content: `{"models": [{"kind": "func", "name": "^Bar$", "action": "no-return"}]}`,
+			wantErr: "both enclosing and name regexes must be provided",
+		},
+		{
+			name:    "invalid regex",
+			content: `{"models": [{"kind": "func", "enclosing": "^foo$", "name": "^Bar($", "action": "no-return"}]}`,
+			wantErr: "invalid name regex",
+		},
+		{
+			name:    "second entry invalid",
+			content: `{"models": [{"kind": "func", "enclosing": "^foo$", "name": "^Bar$", "action": "no-return"}, null]}`,
+			wantErr: "model #1: empty entry",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			models, err := parseTrustedModels([]byte(tt.content))
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			for _, m := range models {
+				require.NotNil(t, m.EnclosingRegex)
+				require.NotNil(t, m.NameRegex)
+			}
+		})
+	}
+}
+
+func TestLoadTrustedModels(t *testing.T) {
+	t.Parallel()
+
+	_, err := LoadTrustedModels(filepath.Join(t.TempDir(), "nonexistent.json"))
+	require.ErrorContains(t, err, "read trusted models file")
+
+	path := filepath.Join(t.TempDir(), "models.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"models": [{"kind": "method"}]}`), 0o600))
+	_, err = LoadTrustedModels(path)
+	require.ErrorContains(t, err, path)
+}
@@ -15,14 +15,13 @@
 package diagnostic
 
 import (
-	"fmt"
 	"go/ast"
 	"go/token"
-	"strings"
 
 	"go.uber.org/nilaway/config"
 	"go.uber.org/nilaway/util/analysishelper"
 	"go.uber.org/nilaway/util/tokenhelper"
+	"golang.org/x/tools/go/analysis"
 )
 
 type conflict struct {
@@ -32,37 +31,18 @@ type conflict struct {
 	flow nilFlow
 	// similarConflicts stores other conflicts that are similar to this one.
 	similarConflicts []*conflict
-}
-
-func (c *conflict) String() string {
-	// build string for similar conflicts (i.e., conflicts with the same nil path)
-	similarConflictsString := ""
-	if len(c.similarConflicts) > 0 {
-		similarPos := make([]string, len(c.similarConflicts))
-		for i, s := range c.similarConflicts {
-			similarPos[i] = fmt.Sprintf("\"%s\"", s.flow.nonnilPath[len(s.flow.nonnilPath)-1].consumerPosition.String())
-		}
-
-		posString := strings.Join(similarPos[:len(similarPos)-1], ", ")
-		if len(similarPos) > 1 {
-			posString = posString + ", and "
-		}
-		posString = posString + similarPos[len(similarPos)-1]
-
-		similarConflictsString = fmt.Sprintf("\n\n(Same nil source could also cause potential nil panic(s) at %d "+
-			"other place(s): %s.)", len(c.similarConflicts), posString)
-	}
-
-	return fmt.Sprintf("Potential nil panic detected. Observed nil flow from "+
-		"source to dereference point: %s%s\n", c.flow.String(), similarConflictsString)
+	// fixes stores the candidate fixes for the conflict, if any (see [Engine.suggestedFixes]).
+	fixes []analysis.SuggestedFix
 }
 
 func (c *conflict) addSimilarConflict(conflict conflict) {
 	c.similarConflicts = append(c.similarConflicts, &conflict)
 }
 
-// groupConflicts groups conflicts with the same nil path together and update conflicts list.
+// groupConflicts groups conflicts with the same nil path (and severity) together and update
+// conflicts list.
 func groupConflicts(allConflicts []conflict, pass *analysishelper.EnhancedPass) []conflict {
+	conf := pass.ResultOf[config.Analyzer].(*config.Config)
 	conflictsMap := make(map[string]int)  // key: nil path string, value: index in `allConflicts`
 	indicesToIgnore := make(map[int]bool) // indices of conflicts to be ignored from `allConflicts`, since they are grouped with other conflicts
 
@@ -93,29 +73,15 @@ func groupConflicts(allConflicts []conflict, pass *analysishelper.EnhancedPass)
 				// ```
 				// Here, the two error messages are exactly the same, but they should not be grouped together as they are
 				// from different functions. To handle such cases, we prepend the enclosing function name to the key.
-				conf := pass.ResultOf[config.Analyzer].(*config.Config)
-				for _, file := range pass.Files {
-					fileName := tokenhelper.RelToCwd(pass.Fset.Position(file.FileStart).Filename)
-					// Check if the file is in scope and the conflict position is in the same file
-					if !conf.IsFileInScope(file) || fileName != c.position.Filename {
-						continue
-					}
-					for _, decl := range file.Decls {
-						// Check if the conflict position falls within the function's position range. If so, update the key to
-						// include the function name, and end the traversal.
-						if fd, ok := decl.(*ast.FuncDecl); ok {
-							functionStart := pass.Fset.Position(fd.Pos()).Offset
-							functionEnd := pass.Fset.Position(fd.End()).Offset
-							if c.position.Offset >= functionStart && c.position.Offset <= functionEnd {
-								key = fd.Name.Name + ":" + key
-								break
-							}
-						}
-					}
+				if fd := enclosingFuncDecl(pass, c.position); fd != nil {
+					key = fd.Name.Name + ":" + key
 				}
 			}
 		}
 
+		// Errors must not be hidden under warnings and vice versa.
+		key = string(severity(conf, c.flow.category())) + ";" + key
+
 		if existingConflictIndex, ok := conflictsMap[key]; ok {
 			// Grouping condition satisfied. Add new conflict to `similarConflicts` in `existingConflict`, and update groupedConflicts map
 			allConflicts[existingConflictIndex].addSimilarConflict(c)
@@ -134,3 +100,59 @@ func groupConflicts(allConflicts []conflict, pass *analysishelper.EnhancedPass)
 	}
 	return groupedConflicts
 }
+
+// enclosingFuncDecl returns the function declaration in the current package that encloses the
+// given (package-independent) position, or nil if the position is not within any function
+// declaration in the files in scope.
+func enclosingFuncDecl(pass *analysishelper.EnhancedPass, position token.Position) *ast.FuncDecl {
+	conf := pass.ResultOf[config.Analyzer].(*config.Config)
+	for _, file := range pass.Files {
+		fileName := tokenhelper.RelToCwd(pass.Fset.Position(file.FileStart).Filename)
+		// Check if the file is in scope and the position is in the same file
+		if !conf.IsFileInScope(file) || fileName != position.Filename {
+			continue
+		}
+		for _, decl := range file.Decls {
+			// Check if the position falls within the function's position range.
+			if fd, ok := decl.(*ast.FuncDecl); ok {
+				functionStart := pass.Fset.Position(fd.Pos()).Offset
+				functionEnd := pass.Fset.Position(fd.End()).Offset
+				if position.Offset >= functionStart && position.Offset <= functionEnd {
+					return fd
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// funcDeclName returns the name of the function declaration, qualified by the receiver type name
+// for methods (e.g., "T.m"). It returns an empty string for a nil declaration.
+func funcDeclName(fd *ast.FuncDecl) string {
+	if fd == nil {
+		return ""
+	}
+	if fd.Recv == nil || len(fd.Recv.List) == 0 {
+		return fd.Name.Name
+	}
+	recv := fd.Recv.List[0].Type
+	for {
+		switch t := recv.(type) {
+		case *ast.StarExpr:
+			recv = t.X
+			continue
+		case *ast.IndexExpr:
+			recv = t.X
+			continue
+		case *ast.IndexListExpr:
+			recv = t.X
+			continue
+		case *ast.ParenExpr:
+			recv = t.X
+			continue
+		case *ast.Ident:
+			return t.Name + "." + fd.Name.Name
+		}
+		return fd.Name.Name
+	}
+}
@@ -43,12 +43,23 @@ type fileInfo struct {
 type Engine struct {
 	pass      *analysishelper.EnhancedPass
 	conflicts []conflict
+	// notes are the diagnostics that do not describe nil flows (e.g., the functions skipped by the
+	// analysis), which are reported separately.
+	notes []note
 	// files maps the file name (modulo the possible build-system prefix) to the token.File object
 	// for faster lookup when converting correct upstream position back to local token.Pos for
 	// reporting purposes.
 	files map[string]fileInfo
 }
 
+// note is a diagnostic that does not describe a nil flow, e.g., a function skipped by the analysis
+// (because it is too large) or an invalid function contract.
+type note struct {
+	pos      token.Pos
+	category config.Category
+	message  string
+}
+
 // NewEngine creates a new diagnostic engine.
 func NewEngine(pass *analysishelper.EnhancedPass) *Engine {
 	// Iterate all files within the Fset (which includes upstream and current-package files), and
@@ -87,8 +98,10 @@ func NewEngine(pass *analysishelper.EnhancedPass) *Engine {
 // controls whether the conflicts with the same nil flow -- the part in the complete nil flow going
 // from a nilable source point to the conflict point -- are grouped together (under the first
 // diagnostic) for concise reporting. The returned slice of diagnostics are sorted by file names
-// and then offsets in the file.
-func (e *Engine) Diagnostics(grouping bool) []analysis.Diagnostic {
+// and then offsets in the file, and each of them carries the structured nil flow it describes.
+// The diagnostics that do not describe nil flows (see AddSkippedFunction, AddInvalidContract and
+// AddContractViolation) follow in the order they were added.
+func (e *Engine) Diagnostics(grouping bool) []Diagnostic {
 	// First sort the conflicts by position such that similar conflicts are grouped under the
 	// first diagnostic.
 	slices.SortFunc(e.conflicts, func(a, b conflict) int {
@@ -98,10 +111,16 @@ func (e *Engine) Diagnostics(grouping bool) []analysis.Diagnostic {
 		return cmp.Compare(a.position.Offset, b.position.Offset)
 	})
 
-	conflicts := e.conflicts
+	conf := e.pass.ResultOf[config.Analyzer].(*config.Config)
+
+	// Drop the conflicts of the disabled categories before grouping, such that they do not hide
+	// the similar conflicts of other categories.
+	conflicts := slices.DeleteFunc(slices.Clone(e.conflicts), func(c conflict) bool {
+		return slices.Contains(conf.DisabledCategories, string(c.flow.category()))
+	})
 	if grouping {
 		// Group conflicts with the same nil path together for concise reporting.
-		conflicts = groupConflicts(e.conflicts, e.pass)
+		conflicts = groupConflicts(conflicts, e.pass)
 	}
 
 	// Build diagnostics from conflicts. Apply cross-package nolint suppressions here as well.
@@ -111,9 +130,7 @@ func (e *Engine) Diagnostics(grouping bool) []analysis.Diagnostic {
 	}
 	nolintRanges := nolintResult.Res
 
-	conf := e.pass.ResultOf[config.Analyzer].(*config.Config)
-
-	diagnostics := make([]analysis.Diagnostic, 0, len(conflicts))
+	diagnostics := make([]Diagnostic, 0, len(conflicts))
 	for _, c := range conflicts {
 		if slices.ContainsFunc(nolintRanges, func(r Range) bool {
 			return c.position.Filename == r.Filename && c.position.Line >= r.From && c.position.Line <= r.To
@@ -123,14 +140,104 @@ func (e *Engine) Diagnostics(grouping bool) []analysis.Diagnostic {
 		if conf.ExcludeTestFiles && involvesTestFile(c) {
 			continue
 		}
-		diagnostics = append(diagnostics, analysis.Diagnostic{
-			Pos:     e.toPos(c.position),
-			Message: c.String(),
+		flow := c.export(severity(conf, c.flow.category()))
+		e.setFunctions(flow)
+		diagnostics = append(diagnostics, Diagnostic{
+			Diagnostic: analysis.Diagnostic{
+				Pos:      e.toPos(c.position),
+				Category: string(flow.Category),
+				Message:  flow.Message(conf.PrettyPrint),
+				// Only the fixes of the reported conflict are attached: the grouped similar
+				// conflicts are reported at other places.
+				SuggestedFixes: c.fixes,
+			},
+			Flow: flow,
+		})
+	}
+
+	for _, n := range e.notes {
+		position := e.pass.Fset.Position(n.pos)
+		position.Filename = tokenhelper.RelToCwd(position.Filename)
+		if slices.ContainsFunc(nolintRanges, func(r Range) bool {
+			return position.Filename == r.Filename && position.Line >= r.From && position.Line <= r.To
+		}) {
+			continue
+		}
+		if conf.ExcludeTestFiles && strings.HasSuffix(position.Filename, "_test.go") {
+			continue
+		}
+		if slices.Contains(conf.DisabledCategories, string(n.category)) {
+			continue
+		}
+		nt := &Note{Position: position, Category: n.category, Severity: severity(conf, n.category), Message: n.message}
+		message := n.message
+		if nt.Severity == SeverityWarning {
+			message = "warning: " + message
+		}
+		diagnostics = append(diagnostics, Diagnostic{
+			Diagnostic: analysis.Diagnostic{
+				Pos:      n.pos,
+				Category: string(n.category),
+				Message:  message,
+			},
+			Note: nt,
 		})
 	}
 	return diagnostics
 }
 
+// AddSkippedFunction adds a function at the given position that is skipped by the analysis for
+// the given reason (e.g., because it is too large or the analysis of it failed). It is reported
+// as a separate diagnostic at the function, such that it can be suppressed like any other
+// diagnostic (e.g., via nolint comments) without affecting the analysis of the other functions.
+func (e *Engine) AddSkippedFunction(pos token.Pos, name string, reason error) {
+	e.notes = append(e.notes, note{
+		pos:      pos,
+		category: config.CategorySkippedFunction,
+		message:  fmt.Sprintf("Skipped the analysis of function `%s()`, nil flows within it are not checked: %v", name, reason),
+	})
+}
+
+// AddInvalidContract adds a handwritten function contract at the given position that is ignored by
+// the analysis since it is malformed or does not match the signature of its function. Similar to
+// the skipped functions, it is reported as a separate diagnostic at the contract.
+func (e *Engine) AddInvalidContract(pos token.Pos, contract string, reason string) {
+	e.notes = append(e.notes, note{
+		pos:      pos,
+		category: config.CategoryInvalidContract,
+		message:  fmt.Sprintf("Ignored invalid function contract `%s`: %s", contract, reason),
+	})
+}
+
+// AddContractViolation adds a handwritten function contract at the given position that is violated
+// by the return statement at retPos, where reason describes the violating path. Unlike the invalid
+// contracts, the violated contract is still respected by the analysis; it is reported such that
+// the contract (or the function) can be fixed.
+func (e *Engine) AddContractViolation(pos token.Pos, contract string, retPos token.Pos, reason string) {
+	at := "a return statement"
+	if retPos.IsValid() {
+		position := e.pass.Fset.Position(retPos)
+		position.Filename = tokenhelper.RelToCwd(position.Filename)
+		at = "the return statement at " + position.String()
+	}
+	e.notes = append(e.notes, note{
+		pos:      pos,
+		category: config.CategoryContractViolation,
+		message:  fmt.Sprintf("Function contract `%s` is violated by %s: %s", contract, at, reason),
+	})
+}
+
+// AddInvalidStub adds a declaration in an annotation stub file that is ignored by the analysis since
+// it does not match any object of the stubbed package. The stub files are not part of the analyzed
+// sources, so it is reported at the given position of the import of the stubbed package instead.
+func (e *Engine) AddInvalidStub(pos token.Pos, reason string) {
+	e.notes = append(e.notes, note{
+		pos:      pos,
+		category: config.CategoryInvalidStub,
+		message:  "Ignored annotation stub declaration at " + reason,
+	})
+}
+
 // AddSingleAssertionConflict adds a new single assertion conflict to the engine.
 func (e *Engine) AddSingleAssertionConflict(trigger annotation.FullTrigger) {
 	producer, consumer := trigger.Prestrings(e.pass)
@@ -143,6 +250,7 @@ func (e *Engine) AddSingleAssertionConflict(trigger annotation.FullTrigger) {
 	e.conflicts = append(e.conflicts, conflict{
 		position: position,
 		flow:     flow,
+		fixes:    e.suggestedFixes(trigger),
 	})
 }
 
@@ -199,6 +307,15 @@ func (e *Engine) AddOverconstraintConflict(nilReason, nonnilReason inference.Exp
 	})
 }
 
+// setFunctions sets the names of the functions enclosing the report positions of the nil flow and
+// the similar conflicts grouped under it.
+func (e *Engine) setFunctions(flow *Flow) {
+	flow.Function = funcDeclName(enclosingFuncDecl(e.pass, flow.Position))
+	for _, s := range flow.Similar {
+		e.setFunctions(s)
+	}
+}
+
 // _fakeFileMaxLines is the maximum number of lines that the archive importer will add to a (fake)
 // file when it imports a package. See [the importer code] for more details. We use this to create
 // more fake files when necessary (see [primitivizer.sitePos]).
@@ -0,0 +1,430 @@
+//  Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diagnostic
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"strings"
+
+	"go.uber.org/nilaway/annotation"
+	"go.uber.org/nilaway/util/asthelper"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ast/astutil"
+)
+
+// suggestedFixes computes candidate fixes for a single assertion conflict. Only a few common
+// patterns are recognized, and each fix is only suggested when the code around the conflict has
+// the exact shape the fix expects, so the returned slice is empty for most conflicts:
+//
+//   - a dereference of a map read lacking the comma-ok guard, where the map read is assigned via
+//     `v := m[k]`, is rewritten to `v, ok := m[k]` followed by an early return if `!ok`;
+//   - a dereference of a nilable function result assigned via `v := f()` gets an
+//     `if v == nil { return ... }` guard right after the assignment;
+//   - a nilable value flowing into an annotatable site (a function result or parameter, a struct
+//     field or a global variable) declared in the current package gets a `// nilable(...)`
+//     annotation inserted on the declaration.
+//
+// The early returns use the zero values of the results of the enclosing function.
+func (e *Engine) suggestedFixes(trigger annotation.FullTrigger) []analysis.SuggestedFix {
+	var fixes []analysis.SuggestedFix
+	switch trigger.Consumer.Annotation.(type) {
+	case *annotation.PtrLoad, *annotation.FldAccess:
+		ident, ok := ast.Unparen(trigger.Consumer.Expr).(*ast.Ident)
+		if !ok {
+			break
+		}
+		switch producer := trigger.Producer.Annotation.(type) {
+		case *annotation.GuardMissing:
+			if fix, ok := e.commaOkFix(ident); ok {
+				fixes = append(fixes, fix)
+			}
+		case *annotation.FuncReturn:
+			if key, ok := producer.Ann.(*annotation.RetAnnotationKey); ok {
+				if fix, ok := e.nilGuardFix(key, ident); ok {
+					fixes = append(fixes, fix)
+				}
+			}
+		}
+	case *annotation.UseAsReturn, *annotation.ArgPass, *annotation.FldAssign, *annotation.GlobalVarAssign:
+		if fix, ok := e.annotationFix(trigger.Consumer.Annotation.UnderlyingSite()); ok {
+			fixes = append(fixes, fix)
+		}
+	}
+	return fixes
+}
+
+// commaOkFix rewrites the assignment `v := m[k]` to `v, ok := m[k]` followed by an early return
+// if `!ok`, where `v` is the variable dereferenced by the consumer.
+func (e *Engine) commaOkFix(consumer *ast.Ident) (analysis.SuggestedFix, bool) {
+	// Only `v := m[k]` is rewritten: for `v = m[k]` we would have to declare `ok` separately
+	// without shadowing `v`.
+	assign := e.lastAssignment(consumer, func(s *ast.AssignStmt, _ int) bool {
+		if s.Tok != token.DEFINE || len(s.Lhs) != 1 {
+			return false
+		}
+		index, ok := ast.Unparen(s.Rhs[0]).(*ast.IndexExpr)
+		if !ok {
+			return false
+		}
+		_, ok = e.pass.TypesInfo.TypeOf(index.X).Underlying().(*types.Map)
+		return ok
+	})
+	if assign == nil {
+		return analysis.SuggestedFix{}, false
+	}
+	path := e.enclosingPath(assign.Pos(), assign.End())
+	ret, ok := e.zeroReturn(path)
+	if !ok || !e.guards(path, consumer) {
+		return analysis.SuggestedFix{}, false
+	}
+
+	lhs := assign.Lhs[0]
+	okName := e.freshName(assign.Pos(), "ok")
+	return analysis.SuggestedFix{
+		Message: fmt.Sprintf("Check the presence of the key with `%s, %s := ...` and return early if absent", consumer.Name, okName),
+		TextEdits: []analysis.TextEdit{
+			{Pos: lhs.End(), End: lhs.End(), NewText: []byte(", " + okName)},
+			{
+				Pos:     assign.End(),
+				End:     assign.End(),
+				NewText: []byte(guardText(e.indentation(assign.Pos()), "!"+okName, ret)),
+			},
+		},
+	}, true
+}
+
+// nilGuardFix inserts an `if v == nil { return ... }` guard right after the assignment
+// `v := f()` (or `v = f()`), where `f` is the function referred to by the given result key and
+// `v` is the variable dereferenced by the consumer.
+func (e *Engine) nilGuardFix(key *annotation.RetAnnotationKey, consumer *ast.Ident) (analysis.SuggestedFix, bool) {
+	numResults := key.FuncDecl.Type().(*types.Signature).Results().Len()
+	assign := e.lastAssignment(consumer, func(s *ast.AssignStmt, lhsIndex int) bool {
+		if len(s.Lhs) > 1 && (len(s.Lhs) != numResults || lhsIndex != key.RetNum) {
+			return false
+		}
+		call, ok := ast.Unparen(s.Rhs[0]).(*ast.CallExpr)
+		return ok && e.calledFunc(call) == key.FuncDecl
+	})
+	if assign == nil {
+		return analysis.SuggestedFix{}, false
+	}
+
+	path := e.enclosingPath(assign.Pos(), assign.End())
+	ret, ok := e.zeroReturn(path)
+	if !ok || !e.guards(path, consumer) {
+		return analysis.SuggestedFix{}, false
+	}
+	return analysis.SuggestedFix{
+		Message: fmt.Sprintf("Return early if `%s` is nil", consumer.Name),
+		TextEdits: []analysis.TextEdit{{
+			Pos:     assign.End(),
+			End:     assign.End(),
+			NewText: []byte(guardText(e.indentation(assign.Pos()), consumer.Name+" == nil", ret)),
+		}},
+	}, true
+}
+
+// annotationFix inserts a `// nilable(...)` annotation on the declaration of the given site, if
+// the site is declared in the current package and can be annotated.
+func (e *Engine) annotationFix(site annotation.Key) (analysis.SuggestedFix, bool) {
+	var obj types.Object
+	// name is the name of the site in the annotation, e.g., "x" for `// nilable(x)`.
+	var name string
+	switch key := site.(type) {
+	case *annotation.RetAnnotationKey:
+		obj = key.FuncDecl
+		name = fmt.Sprintf("result %d", key.RetNum)
+		if n := key.FuncDecl.Type().(*types.Signature).Results().At(key.RetNum).Name(); n != "" {
+			name = n
+		}
+	case *annotation.ParamAnnotationKey:
+		obj = key.FuncDecl
+		name = fmt.Sprintf("param %d", key.ParamNum)
+		if n := key.ParamName().Name(); n != "" {
+			name = n
+		}
+	case *annotation.FieldAnnotationKey:
+		obj, name = key.FieldDecl, key.FieldDecl.Name()
+	case *annotation.GlobalVarAnnotationKey:
+		obj, name = key.VarDecl, key.VarDecl.Name()
+	default:
+		return analysis.SuggestedFix{}, false
+	}
+	if obj.Pkg() != e.pass.Pkg || name == "_" {
+		return analysis.SuggestedFix{}, false
+	}
+
+	// Find the declaration the annotation should be attached to, following the placement rules in
+	// [annotation.newObservedMap].
+	path := e.enclosingPath(obj.Pos(), obj.Pos())
+	var decl ast.Node
+	for i, n := range path {
+		switch n := n.(type) {
+		case *ast.FuncDecl:
+			decl = n
+		case *ast.TypeSpec:
+			// Only fields of struct types declared by the type spec can be annotated, i.e., the
+			// path must be [Ident, Field, FieldList, StructType, TypeSpec, ...].
+			if _, ok := obj.(*types.Var); !ok || i != 4 {
+				break
+			}
+			if _, ok := n.Type.(*ast.StructType); !ok {
+				break
+			}
+			decl = specDecl(n, path[i+1])
+		case *ast.ValueSpec:
+			if i == 1 {
+				decl = specDecl(n, path[i+1])
+			}
+		case *ast.FuncLit, *ast.BlockStmt:
+			// Local declarations cannot be annotated.
+			return analysis.SuggestedFix{}, false
+		default:
+			continue
+		}
+		break
+	}
+	if decl == nil {
+		return analysis.SuggestedFix{}, false
+	}
+
+	comment := fmt.Sprintf("// nilable(%s)", name)
+	return analysis.SuggestedFix{
+		Message: fmt.Sprintf("Annotate `%s` as nilable with `%s`", name, comment),
+		TextEdits: []analysis.TextEdit{{
+			Pos:     decl.Pos(),
+			End:     decl.Pos(),
+			NewText: []byte(comment + "\n" + e.indentation(decl.Pos())),
+		}},
+	}, true
+}
+
+// specDecl returns the node whose doc comment holds the annotations of the given spec: the parent
+// declaration if it only has a single spec, otherwise the spec itself.
+func specDecl(spec ast.Spec, parent ast.Node) ast.Node {
+	if g, ok := parent.(*ast.GenDecl); ok && len(g.Specs) == 1 {
+		return g
+	}
+	return spec
+}
+
+// lastAssignment returns the last assignment to the variable referred to by the consumer that
+// precedes the consumer in the enclosing function and is accepted by the given predicate, or nil
+// if not found. Only assignments with a single right-hand side expression are considered, and the
+// predicate is also given the index of the variable on the left-hand side.
+func (e *Engine) lastAssignment(consumer *ast.Ident, accept func(s *ast.AssignStmt, lhsIndex int) bool) *ast.AssignStmt {
+	obj, ok := e.pass.TypesInfo.Uses[consumer].(*types.Var)
+	if !ok {
+		return nil
+	}
+	body := enclosingFuncBody(e.enclosingPath(consumer.Pos(), consumer.End()))
+	if body == nil {
+		return nil
+	}
+
+	var assign *ast.AssignStmt
+	ast.Inspect(body, func(n ast.Node) bool {
+		if n == nil || n.Pos() >= consumer.Pos() {
+			return false
+		}
+		// Do not descend into nested function literals since they may run at any point in time.
+		if _, ok := n.(*ast.FuncLit); ok {
+			return false
+		}
+		s, ok := n.(*ast.AssignStmt)
+		if !ok || len(s.Rhs) != 1 || s.End() > consumer.Pos() {
+			return true
+		}
+		for i, lhs := range s.Lhs {
+			if ident, ok := lhs.(*ast.Ident); ok && e.pass.TypesInfo.ObjectOf(ident) == obj && accept(s, i) {
+				assign = s
+			}
+		}
+		return true
+	})
+	return assign
+}
+
+// enclosingPath returns the path of AST nodes enclosing the given interval in the current package,
+// from the innermost node to the file. It returns nil if the interval is not in the current package.
+func (e *Engine) enclosingPath(start, end token.Pos) []ast.Node {
+	for _, file := range e.pass.Files {
+		if file.FileStart <= start && end <= file.FileEnd {
+			path, _ := astutil.PathEnclosingInterval(file, start, end)
+			return path
+		}
+	}
+	return nil
+}
+
+// enclosingFuncBody returns the body of the innermost function in the path, or nil if not found.
+func enclosingFuncBody(path []ast.Node) *ast.BlockStmt {
+	for _, n := range path {
+		switch n := n.(type) {
+		case *ast.FuncDecl:
+			return n.Body
+		case *ast.FuncLit:
+			return n.Body
+		}
+	}
+	return nil
+}
+
+// guards returns true if a statement inserted right after path[0] (a statement) is executed before
+// the consumer is reached, i.e., path[0] is directly in a statement list that also contains the
+// consumer.
+func (e *Engine) guards(path []ast.Node, consumer ast.Node) bool {
+	if len(path) < 2 || consumer.Pos() < path[0].End() {
+		return false
+	}
+	switch parent := path[1].(type) {
+	case *ast.BlockStmt, *ast.CaseClause, *ast.CommClause:
+		return consumer.End() <= parent.End()
+	}
+	return false
+}
+
+// calledFunc returns the function or method statically called by the call expression, if any.
+func (e *Engine) calledFunc(call *ast.CallExpr) *types.Func {
+	ident := asthelper.FuncIdentFromCallExpr(call)
+	if ident == nil {
+		return nil
+	}
+	if fn, ok := e.pass.TypesInfo.Uses[ident].(*types.Func); ok {
+		return fn.Origin()
+	}
+	return nil
+}
+
+// zeroReturn returns the return statement with the zero values of the results of the innermost
+// function enclosing the path. It returns false if any of the zero values cannot be written out
+// in the file (e.g., the result type is from a package that is not imported by the file).
+func (e *Engine) zeroReturn(path []ast.Node) (string, bool) {
+	var sig *types.Signature
+	var file *ast.File
+	for _, n := range path {
+		switch n := n.(type) {
+		case *ast.FuncDecl:
+			if sig == nil {
+				sig, _ = e.pass.TypesInfo.ObjectOf(n.Name).Type().(*types.Signature)
+			}
+		case *ast.FuncLit:
+			if sig == nil {
+				sig, _ = e.pass.TypesInfo.TypeOf(n).(*types.Signature)
+			}
+		case *ast.File:
+			file = n
+		}
+	}
+	if sig == nil || file == nil {
+		return "", false
+	}
+
+	// Package names are written out as imported by the file. If a type refers to a package that
+	// is not imported (or only imported with a blank or dot import), no fix is suggested.
+	names := make(map[*types.Package]string)
+	for _, spec := range file.Imports {
+		if pkgName := e.pass.TypesInfo.PkgNameOf(spec); pkgName != nil && pkgName.Name() != "_" && pkgName.Name() != "." {
+			names[pkgName.Imported()] = pkgName.Name()
+		}
+	}
+	qualified := true
+	qualifier := func(pkg *types.Package) string {
+		if pkg == e.pass.Pkg {
+			return ""
+		}
+		name, ok := names[pkg]
+		qualified = qualified && ok
+		return name
+	}
+
+	zeros := make([]string, 0, sig.Results().Len())
+	for v := range sig.Results().Variables() {
+		zero, ok := zeroValue(v.Type(), qualifier)
+		if !ok {
+			return "", false
+		}
+		zeros = append(zeros, zero)
+	}
+	if !qualified {
+		return "", false
+	}
+	if len(zeros) == 0 {
+		return "return", true
+	}
+	return "return " + strings.Join(zeros, ", "), true
+}
+
+// zeroValue returns the zero value of the given type as it would be written in source code.
+func zeroValue(t types.Type, qualifier types.Qualifier) (string, bool) {
+	if _, ok := t.(*types.TypeParam); ok {
+		return "*new(" + types.TypeString(t, qualifier) + ")", true
+	}
+	switch u := t.Underlying().(type) {
+	case *types.Basic:
+		switch {
+		case u.Info()&types.IsBoolean != 0:
+			return "false", true
+		case u.Info()&types.IsNumeric != 0:
+			return "0", true
+		case u.Info()&types.IsString != 0:
+			return `""`, true
+		case u.Kind() == types.UnsafePointer:
+			return "nil", true
+		}
+	case *types.Pointer, *types.Slice, *types.Map, *types.Chan, *types.Signature, *types.Interface:
+		return "nil", true
+	case *types.Struct, *types.Array:
+		return types.TypeString(t, qualifier) + "{}", true
+	}
+	return "", false
+}
+
+// freshName returns the given name if it does not clash with any object visible at the given
+// position (or declared later in the same scope), otherwise it appends a numeric suffix to it.
+func (e *Engine) freshName(pos token.Pos, name string) string {
+	scope := e.pass.Pkg.Scope().Innermost(pos)
+	if scope == nil {
+		return name
+	}
+	taken := func(n string) bool {
+		if scope.Lookup(n) != nil {
+			return true
+		}
+		_, obj := scope.LookupParent(n, pos)
+		return obj != nil
+	}
+	candidate := name
+	for i := 1; taken(candidate); i++ {
+		candidate = fmt.Sprintf("%s%d", name, i)
+	}
+	return candidate
+}
+
+// indentation returns the indentation of the line at the given position, assuming the code is
+// gofmt-ed (i.e., indented with tabs).
+func (e *Engine) indentation(pos token.Pos) string {
+	column := e.pass.Fset.Position(pos).Column
+	return strings.Repeat("\t", max(column-1, 0))
+}
+
+// guardText returns the text of an if statement with the given condition and body, to be inserted
+// right after a statement at the given indentation.
+func guardText(indent, cond, body string) string {
+	return "\n" + indent + "if " + cond + " {\n" + indent + "\t" + body + "\n" + indent + "}"
+}
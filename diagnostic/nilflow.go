@@ -17,9 +17,12 @@ package diagnostic
 import (
 	"fmt"
 	"go/token"
+	"reflect"
+	"slices"
 	"strings"
 
 	"go.uber.org/nilaway/annotation"
+	"go.uber.org/nilaway/config"
 )
 
 type nilFlow struct {
@@ -44,17 +47,41 @@ func (n *nilFlow) addNonNilPathNode(p annotation.Prestring, c annotation.Prestri
 	n.nonnilPath = append(n.nonnilPath, nodeObj)
 }
 
-// String converts a nilFlow to a string representation, where each entry is the flow of the form: `<pos>: <reason>`
-func (n *nilFlow) String() string {
-	var allNodes []node
-	allNodes = append(allNodes, n.nilPath...)
-	allNodes = append(allNodes, n.nonnilPath...)
+// _categoryPrecedence lists the categories from the most to the least specific ones. The contract
+// violations come first, followed by the points where nil panics would occur, and finally the
+// points the nil values merely flow through.
+var _categoryPrecedence = []config.Category{
+	config.CategoryErrorReturn,
+	config.CategoryInterfaceContract,
+	config.CategoryDeepNilability,
+	config.CategoryFieldEscape,
+	config.CategoryDereference,
+	config.CategoryMethodReceiver,
+	config.CategoryMapAccess,
+	config.CategorySliceAccess,
+	config.CategoryTypeAssertion,
+	config.CategoryClosedChannel,
+	config.CategoryReturn,
+	config.CategoryArgument,
+	config.CategoryFieldAssign,
+	config.CategoryGlobalAssign,
+	config.CategoryOther,
+}
 
-	var flow []string
-	for _, nodeObj := range allNodes {
-		flow = append(flow, nodeObj.String())
+// category returns the category of the flow, which is the most specific category of the consumers
+// in the flow (see _categoryPrecedence). For example, a nil value returned alongside a nil error
+// and later dereferenced is categorized as an error-return violation rather than a dereference.
+func (n *nilFlow) category() config.Category {
+	category := config.CategoryOther
+	for _, nodes := range [2][]node{n.nilPath, n.nonnilPath} {
+		for _, nd := range nodes {
+			if nd.consumerCategory != "" &&
+				slices.Index(_categoryPrecedence, nd.consumerCategory) < slices.Index(_categoryPrecedence, category) {
+				category = nd.consumerCategory
+			}
+		}
 	}
-	return "\n" + strings.Join(flow, "\n")
+	return category
 }
 
 // involvesTestFile returns true if any node position in the nil or non-nil path originates from
@@ -76,6 +103,11 @@ type node struct {
 	consumerPosition token.Position
 	producerRepr     string
 	consumerRepr     string
+	producerKind     string
+	consumerKind     string
+	// consumerCategory is the category of the consumer, or empty if there is no consumer (e.g., for
+	// annotated sites).
+	consumerCategory config.Category
 }
 
 // newNode creates a new node object from the given producer and consumer Prestrings.
@@ -88,28 +120,51 @@ func newNode(p annotation.Prestring, c annotation.Prestring) node {
 	if l, ok := p.(annotation.LocatedPrestring); ok {
 		nodeObj.producerPosition = l.Location
 		nodeObj.producerRepr = l.Contained.String()
+		nodeObj.producerKind = triggerKind(l.Contained)
 	} else if p != nil {
 		nodeObj.producerRepr = p.String()
+		nodeObj.producerKind = triggerKind(p)
 	}
 
 	// get consumer representation string
 	if l, ok := c.(annotation.LocatedPrestring); ok {
 		nodeObj.consumerPosition = l.Location
 		nodeObj.consumerRepr = l.Contained.String()
+		nodeObj.consumerKind = triggerKind(l.Contained)
 	} else if c != nil {
 		nodeObj.consumerRepr = c.String()
+		nodeObj.consumerKind = triggerKind(c)
+	}
+	if c != nil {
+		nodeObj.consumerCategory = annotation.ConsumerCategory(c)
 	}
 
 	return nodeObj
 }
 
+// triggerKind returns the kind of the trigger the given Prestring describes, which is the name of
+// the trigger type by convention (e.g., "PtrLoad" for annotation.PtrLoadPrestring). For other
+// descriptions (e.g., an inference.ExplainedBool for an annotated site), the type name is returned
+// as is.
+func triggerKind(p fmt.Stringer) string {
+	t := reflect.TypeOf(p)
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return strings.TrimSuffix(t.Name(), "Prestring")
+}
+
 func (n *node) String() string {
 	posStr := "<no pos info>"
-	reasonStr := ""
 	if n.consumerPosition.IsValid() {
 		posStr = n.consumerPosition.String()
 	}
+	return fmt.Sprintf("\t- %s: %s", posStr, n.reason())
+}
 
+// reason returns the producer and consumer representations joined together.
+func (n *node) reason() string {
+	reasonStr := ""
 	if len(n.producerRepr) > 0 {
 		reasonStr += n.producerRepr
 	}
@@ -119,8 +174,7 @@ func (n *node) String() string {
 		}
 		reasonStr += n.consumerRepr
 	}
-
-	return fmt.Sprintf("\t- %s: %s", posStr, reasonStr)
+	return reasonStr
 }
 
 func pathString(nodes []node) string {
@@ -0,0 +1,319 @@
+//  Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diagnostic
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"go/token"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"go.uber.org/nilaway/config"
+	"go.uber.org/nilaway/util/tokenhelper"
+	"golang.org/x/tools/go/analysis"
+)
+
+// Diagnostic bundles a diagnostic to be reported with the structured nil flow it was rendered
+// from, such that output formats other than plain text (e.g., SARIF) can be generated from data
+// rather than from the message string.
+type Diagnostic struct {
+	analysis.Diagnostic
+	// Flow is the structured nil flow of the diagnostic. It is nil for diagnostics that do not
+	// describe a nil flow (e.g., notes and internal errors).
+	Flow *Flow `json:"flow,omitempty"`
+	// Note is the structured note of the diagnostic, which is set only for the diagnostics that
+	// are neither nil flows nor internal errors (e.g., the functions skipped by the analysis).
+	Note *Note `json:"note,omitempty"`
+	// Internal is true for the diagnostics reporting internal errors (e.g., panics) of NilAway
+	// rather than problems in the analyzed code. They are not deterministic results of the code
+	// and hence must not be cached.
+	Internal bool `json:"internal,omitempty"`
+}
+
+// Severity returns the severity the diagnostic is reported with. Diagnostics that are neither nil
+// flows nor notes (e.g., internal errors) are always errors.
+func (d Diagnostic) Severity() Severity {
+	switch {
+	case d.Flow != nil:
+		return d.Flow.Severity
+	case d.Note != nil:
+		return d.Note.Severity
+	default:
+		return SeverityError
+	}
+}
+
+// Note is the exported, structured form of a diagnostic that does not describe a nil flow, e.g., a
+// function skipped by the analysis.
+type Note struct {
+	// Position is the (package-independent) position where the note is reported.
+	Position token.Position `json:"position"`
+	// Category is the category of the note (see [config.Category]).
+	Category config.Category `json:"category"`
+	// Severity is the severity the note is reported with.
+	Severity Severity `json:"severity,omitempty"`
+	// Message is the message of the note, without the severity prefix.
+	Message string `json:"message"`
+}
+
+// Fingerprint returns a stable identifier of the note, similar to [Flow.Fingerprint]. It is
+// computed from the report file, the category and the message, leaving out the line and column
+// numbers of the positions embedded in the message.
+func (n *Note) Fingerprint() string {
+	h := sha256.New()
+	fmt.Fprintf(h, "%s\n%s\n", filepath.ToSlash(tokenhelper.RelToCwd(n.Position.Filename)), n.Category)
+	fmt.Fprintf(h, "note:%s\n", _lineInfoPattern.ReplaceAllString(n.Message, "$1"))
+	return hex.EncodeToString(h.Sum(nil)[:16])
+}
+
+// Flow is the exported, structured form of a conflict.
+type Flow struct {
+	// Position is the (package-independent) position where the conflict is reported.
+	Position token.Position `json:"position"`
+	// NilPath is the flow from the nilable source to the point of conflict, in program order.
+	NilPath []FlowNode `json:"nilPath,omitempty"`
+	// NonnilPath is the flow from the point of conflict to the dereference point, in program order.
+	NonnilPath []FlowNode `json:"nonnilPath,omitempty"`
+	// Similar are the other conflicts sharing the same nil path, which are grouped under this one.
+	Similar []*Flow `json:"similar,omitempty"`
+	// Function is the name of the function declaration enclosing the report position (qualified by
+	// the receiver type name for methods, e.g., "T.m"), or empty if unknown.
+	Function string `json:"function,omitempty"`
+	// Category is the category of the nil flow (see [config.Category]).
+	Category config.Category `json:"category,omitempty"`
+	// Severity is the severity the nil flow is reported with.
+	Severity Severity `json:"severity,omitempty"`
+}
+
+// Severity is the severity of a reported diagnostic, which can be lowered for certain categories via
+// config.WarningCategoriesFlag.
+type Severity string
+
+const (
+	// SeverityError is the default severity.
+	SeverityError Severity = "error"
+	// SeverityWarning is the severity of the advisory nil flows, which should not fail a build.
+	SeverityWarning Severity = "warning"
+)
+
+// severity returns the severity the given category is reported with.
+func severity(conf *config.Config, category config.Category) Severity {
+	if slices.Contains(conf.WarningCategories, string(category)) {
+		return SeverityWarning
+	}
+	return SeverityError
+}
+
+// FlowNode is a single step in a nil flow, describing how a value is produced and then consumed.
+type FlowNode struct {
+	// ProducerPosition is the position of the producer, which may be invalid if it is unknown.
+	ProducerPosition token.Position `json:"producerPosition"`
+	// ConsumerPosition is the position of the consumer, which may be invalid if it is unknown.
+	ConsumerPosition token.Position `json:"consumerPosition"`
+	// ProducerRepr is the human-readable description of the producer.
+	ProducerRepr string `json:"producerRepr,omitempty"`
+	// ConsumerRepr is the human-readable description of the consumer.
+	ConsumerRepr string `json:"consumerRepr,omitempty"`
+	// ProducerKind is the kind of the producer trigger (e.g., "FuncReturn"), if known.
+	ProducerKind string `json:"producerKind,omitempty"`
+	// ConsumerKind is the kind of the consumer trigger (e.g., "PtrLoad"), if known.
+	ConsumerKind string `json:"consumerKind,omitempty"`
+}
+
+// Reason returns the human-readable description of the step, i.e., the producer and consumer
+// descriptions joined together.
+func (n FlowNode) Reason() string {
+	nd := node{producerRepr: n.ProducerRepr, consumerRepr: n.ConsumerRepr}
+	return nd.reason()
+}
+
+// Nodes returns all nodes of the nil flow in program order, i.e., the nil path followed by the
+// non-nil path.
+func (f *Flow) Nodes() []FlowNode {
+	nodes := make([]FlowNode, 0, len(f.NilPath)+len(f.NonnilPath))
+	nodes = append(nodes, f.NilPath...)
+	return append(nodes, f.NonnilPath...)
+}
+
+// DereferencePosition returns the position where the nil panic would occur, i.e., the consumer
+// position of the last node in the non-nil path. It falls back to the report position.
+func (f *Flow) DereferencePosition() token.Position {
+	if len(f.NonnilPath) == 0 {
+		return f.Position
+	}
+	return f.NonnilPath[len(f.NonnilPath)-1].ConsumerPosition
+}
+
+// _lineInfoPattern matches the line (and column) information of the Go file positions embedded in
+// the free-form descriptions of the nodes (e.g., "result 0 of `f()` at a/b.go:12:3"), where the
+// file name is captured without its directories.
+var _lineInfoPattern = regexp.MustCompile(`[^\s"]*?([^/\s"]+\.go):\d+(?::\d+)?`)
+
+// Fingerprint returns a stable identifier of the nil flow that survives unrelated edits to the
+// code, e.g., for matching diagnostics against a baseline of known ones. It is computed from the
+// report file, the enclosing function and the descriptions of all nodes, leaving out the line and
+// column numbers (and the directories of the node positions, which may be truncated for printing).
+// The similar conflicts grouped under this one have their own fingerprints.
+func (f *Flow) Fingerprint() string {
+	h := sha256.New()
+	fmt.Fprintf(h, "%s\n%s\n", filepath.ToSlash(tokenhelper.RelToCwd(f.Position.Filename)), f.Function)
+	for _, n := range f.NilPath {
+		fmt.Fprintf(h, "nil:%s\n", n.fingerprint())
+	}
+	for _, n := range f.NonnilPath {
+		fmt.Fprintf(h, "nonnil:%s\n", n.fingerprint())
+	}
+	return hex.EncodeToString(h.Sum(nil)[:16])
+}
+
+// fingerprint returns the line-insensitive description of the node used in [Flow.Fingerprint].
+func (n FlowNode) fingerprint() string {
+	return strings.Join([]string{
+		filepath.Base(n.ConsumerPosition.Filename),
+		n.ProducerKind,
+		n.ConsumerKind,
+		_lineInfoPattern.ReplaceAllString(n.Reason(), "$1"),
+	}, ";")
+}
+
+// Message renders the nil flow as a diagnostic message. If pretty is true, the message is
+// decorated with ANSI escape codes: positions come straight from the structured data, while code
+// references and nilabilities are highlighted within the free-form descriptions of the nodes.
+func (f *Flow) Message(pretty bool) string {
+	var b strings.Builder
+	switch {
+	case pretty && f.Severity == SeverityWarning:
+		b.WriteString(_warningPrefix)
+	case pretty:
+		b.WriteString(_errorPrefix)
+	case f.Severity == SeverityWarning:
+		b.WriteString("warning: ")
+	}
+	b.WriteString("Potential nil panic detected")
+	if f.Category != "" {
+		fmt.Fprintf(&b, " [%s]", f.Category)
+	}
+	b.WriteString(". Observed nil flow from source to dereference point: \n")
+
+	lines := make([]string, 0, len(f.NilPath)+len(f.NonnilPath))
+	for _, n := range f.Nodes() {
+		posStr := "<no pos info>"
+		if n.ConsumerPosition.IsValid() {
+			posStr = n.ConsumerPosition.String()
+		}
+		reason := n.Reason()
+		if pretty {
+			posStr = colored(_colorCyan, posStr)
+			reason = highlight(reason)
+		}
+		lines = append(lines, fmt.Sprintf("\t- %s: %s", posStr, reason))
+	}
+	b.WriteString(strings.Join(lines, "\n"))
+
+	// build string for similar conflicts (i.e., conflicts with the same nil path)
+	if len(f.Similar) > 0 {
+		similarPos := make([]string, len(f.Similar))
+		for i, s := range f.Similar {
+			similarPos[i] = fmt.Sprintf("\"%s\"", s.DereferencePosition().String())
+			if pretty {
+				similarPos[i] = colored(_colorCyan, s.DereferencePosition().String())
+			}
+		}
+
+		posString := strings.Join(similarPos[:len(similarPos)-1], ", ")
+		if len(similarPos) > 1 {
+			posString = posString + ", and "
+		}
+		posString = posString + similarPos[len(similarPos)-1]
+
+		fmt.Fprintf(&b, "\n\n(Same nil source could also cause potential nil panic(s) at %d "+
+			"other place(s): %s.)", len(f.Similar), posString)
+	}
+
+	b.WriteString("\n")
+	return b.String()
+}
+
+// ANSI escape codes used for pretty printing.
+const (
+	_colorRed     = 31
+	_colorYellow  = 33
+	_colorCyan    = 36
+	_colorMagenta = 95
+	_styleBold    = 1
+)
+
+var (
+	_errorPrefix          = colored(_colorRed, "error: ")
+	_warningPrefix        = colored(_colorYellow, "warning: ")
+	_codeReferencePattern = regexp.MustCompile("\\`(.*?)\\`")
+	_pathPattern          = regexp.MustCompile(`"(.*?)"`)
+	_nilabilityPattern    = regexp.MustCompile(`([\(|^\t](?i)(found\s|must\sbe\s)(nilable|nonnil)[\)]?)`)
+)
+
+func colored(code int, s string) string {
+	return fmt.Sprintf("\x1b[%dm%s\x1b[0m", code, s)
+}
+
+// highlight decorates free-form text with ANSI escape codes: nilabilities are printed in bold,
+// code references (enclosed in backticks) in magenta, and quoted paths in cyan.
+func highlight(text string) string {
+	text = _nilabilityPattern.ReplaceAllString(text, colored(_styleBold, "${1}"))
+	text = _codeReferencePattern.ReplaceAllString(text, colored(_colorMagenta, "`${1}`"))
+	return _pathPattern.ReplaceAllString(text, colored(_colorCyan, "${1}"))
+}
+
+// PrettyPrint pretty prints an unstructured message with colors. Diagnostics describing nil flows
+// should instead be rendered from their structured data via [Flow.Message].
+func PrettyPrint(msg string) string {
+	return _errorPrefix + highlight(msg)
+}
+
+// export converts the conflict to its exported form, reported with the given severity.
+func (c *conflict) export(severity Severity) *Flow {
+	f := &Flow{
+		Position:   c.position,
+		NilPath:    exportNodes(c.flow.nilPath),
+		NonnilPath: exportNodes(c.flow.nonnilPath),
+		Category:   c.flow.category(),
+		Severity:   severity,
+	}
+	for _, s := range c.similarConflicts {
+		f.Similar = append(f.Similar, s.export(severity))
+	}
+	return f
+}
+
+func exportNodes(nodes []node) []FlowNode {
+	if len(nodes) == 0 {
+		return nil
+	}
+	exported := make([]FlowNode, len(nodes))
+	for i, n := range nodes {
+		exported[i] = FlowNode{
+			ProducerPosition: n.producerPosition,
+			ConsumerPosition: n.consumerPosition,
+			ProducerRepr:     n.producerRepr,
+			ConsumerRepr:     n.consumerRepr,
+			ProducerKind:     n.producerKind,
+			ConsumerKind:     n.consumerKind,
+		}
+	}
+	return exported
+}
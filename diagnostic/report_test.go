@@ -0,0 +1,132 @@
+//  Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diagnostic
+
+import (
+	"go/token"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/nilaway/annotation"
+	"go.uber.org/nilaway/config"
+)
+
+func TestFlowMessage(t *testing.T) {
+	t.Parallel()
+
+	pos := func(line int) token.Position {
+		return token.Position{Filename: "pkg/a.go", Line: line, Column: 2, Offset: line}
+	}
+	c := conflict{
+		position: pos(3),
+		flow: nilFlow{
+			nilPath:    []node{{consumerPosition: pos(1), producerRepr: "literal `nil`", consumerRepr: "returned"}},
+			nonnilPath: []node{{consumerPosition: pos(3), producerRepr: "result 0 of `f()`", consumerRepr: "dereferenced"}},
+		},
+	}
+	c.addSimilarConflict(conflict{position: pos(5), flow: nilFlow{nonnilPath: []node{{consumerPosition: pos(5)}}}})
+	c.addSimilarConflict(conflict{position: pos(7), flow: nilFlow{nonnilPath: []node{{consumerPosition: pos(7)}}}})
+	flow := c.export(SeverityError)
+
+	require.Len(t, flow.Nodes(), 2)
+	require.Equal(t, pos(5), flow.Similar[0].DereferencePosition())
+	require.Equal(t, config.CategoryOther, flow.Category)
+	require.Equal(t, "Potential nil panic detected [other]. Observed nil flow from source to dereference point: \n"+
+		"\t- pkg/a.go:1:2: literal `nil` returned\n"+
+		"\t- pkg/a.go:3:2: result 0 of `f()` dereferenced\n\n"+
+		"(Same nil source could also cause potential nil panic(s) at 2 other place(s): \"pkg/a.go:5:2\", and \"pkg/a.go:7:2\".)\n",
+		flow.Message(false))
+
+	// The pretty-printed message is decorated from the structured data.
+	pretty := flow.Message(true)
+	require.Contains(t, pretty, _errorPrefix)
+	require.Contains(t, pretty, colored(_colorCyan, "pkg/a.go:1:2"))
+	require.Contains(t, pretty, colored(_colorMagenta, "`f()`"))
+	require.Contains(t, pretty, colored(_colorCyan, "pkg/a.go:7:2")+".)")
+
+	// Warnings are marked as such.
+	warning := c.export(SeverityWarning)
+	require.Equal(t, SeverityWarning, warning.Similar[0].Severity)
+	require.True(t, strings.HasPrefix(warning.Message(false), "warning: Potential nil panic detected [other]."))
+	require.True(t, strings.HasPrefix(warning.Message(true), _warningPrefix))
+}
+
+func TestFlowCategory(t *testing.T) {
+	t.Parallel()
+
+	flow := nilFlow{
+		nilPath: []node{
+			newNode(annotation.ConstNilPrestring{}, annotation.UseAsReturnPrestring{}),
+			newNode(annotation.FuncReturnPrestring{}, annotation.UseAsNonErrorRetDependentOnErrorRetNilabilityPrestring{}),
+		},
+		nonnilPath: []node{newNode(annotation.FuncReturnPrestring{}, annotation.PtrLoadPrestring{})},
+	}
+	// The error-return contract violation is more specific than the dereference.
+	require.Equal(t, config.CategoryErrorReturn, flow.category())
+
+	flow.nilPath = flow.nilPath[:1]
+	require.Equal(t, config.CategoryDereference, flow.category())
+
+	// Annotated sites do not have consumers.
+	flow.nonnilPath = []node{newNode(annotation.FuncReturnPrestring{}, nil)}
+	require.Equal(t, config.CategoryReturn, flow.category())
+	require.Equal(t, config.CategoryOther, (&nilFlow{}).category())
+}
+
+func TestTriggerKind(t *testing.T) {
+	t.Parallel()
+
+	n := newNode(
+		annotation.LocatedPrestring{Contained: annotation.FuncReturnPrestring{}, Location: token.Position{Filename: "a.go", Line: 1}},
+		annotation.PtrLoadPrestring{},
+	)
+	require.Equal(t, "FuncReturn", n.producerKind)
+	require.Equal(t, "PtrLoad", n.consumerKind)
+	require.Equal(t, "a.go", n.producerPosition.Filename)
+}
+
+func TestFlowFingerprint(t *testing.T) {
+	t.Parallel()
+
+	flow := func(shift int, function, callSite string) *Flow {
+		pos := func(line int) token.Position {
+			return token.Position{Filename: "pkg/a.go", Line: line + shift, Column: 2, Offset: 10 * (line + shift)}
+		}
+		return &Flow{
+			Position: pos(3),
+			Function: function,
+			NilPath:  []FlowNode{{ConsumerPosition: pos(1), ProducerRepr: "result 0 of `f()` at " + callSite, ConsumerRepr: "returned"}},
+			NonnilPath: []FlowNode{{
+				ConsumerPosition: pos(3),
+				ProducerRepr:     "result 0 of `g()`",
+				ConsumerRepr:     "dereferenced",
+				ConsumerKind:     "PtrLoad",
+			}},
+		}
+	}
+
+	base := flow(0, "T.m", "pkg/a.go:1:9")
+	require.Len(t, base.Fingerprint(), 32)
+	// Line shifts (including the ones in the descriptions) and truncated directories do not
+	// change the fingerprint.
+	require.Equal(t, base.Fingerprint(), flow(42, "T.m", "a/pkg/a.go:43:9").Fingerprint())
+	// The enclosing function and the file are part of the fingerprint.
+	require.NotEqual(t, base.Fingerprint(), flow(0, "T.n", "pkg/a.go:1:9").Fingerprint())
+	require.NotEqual(t, base.Fingerprint(), flow(0, "T.m", "pkg/b.go:1:9").Fingerprint())
+	other := flow(0, "T.m", "pkg/a.go:1:9")
+	other.Position.Filename = "pkg/b.go"
+	require.NotEqual(t, base.Fingerprint(), other.Fingerprint())
+}
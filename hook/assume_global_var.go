@@ -19,6 +19,7 @@ import (
 	"regexp"
 
 	"go.uber.org/nilaway/annotation"
+	"go.uber.org/nilaway/config"
 	"go.uber.org/nilaway/util/analysishelper"
 )
 
@@ -32,15 +33,24 @@ import (
 func AssumeGlobalVarNonnil(pass *analysishelper.EnhancedPass, sel *ast.SelectorExpr) *annotation.ProduceTrigger {
 	for i := range _assumeGlobalVarsNonnil {
 		if _assumeGlobalVarsNonnil[i].matchSel(pass, sel) {
-			return &annotation.ProduceTrigger{
-				Annotation: &annotation.TrustedFuncNonnil{ProduceTriggerNever: &annotation.ProduceTriggerNever{}},
-				Expr:       sel,
-			}
+			return nonnilGlobalProducer(sel)
+		}
+	}
+	for _, m := range configuredModels(pass, config.TrustedActionNonnilGlobal) {
+		if sig := configuredSig(m); sig.matchSel(pass, sel) {
+			return nonnilGlobalProducer(sel)
 		}
 	}
 	return nil
 }
 
+func nonnilGlobalProducer(sel *ast.SelectorExpr) *annotation.ProduceTrigger {
+	return &annotation.ProduceTrigger{
+		Annotation: &annotation.TrustedFuncNonnil{ProduceTriggerNever: &annotation.ProduceTriggerNever{}},
+		Expr:       sel,
+	}
+}
+
 // _assumeGlobalVarsNonnil is the set of standard-library global variables assumed to be non-nil.
 var _assumeGlobalVarsNonnil = []trustedSig{
 	// `os.Stdout`, `os.Stderr`, `os.Stdin`, and `os.Args` are documented to be non-nil.
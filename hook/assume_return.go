@@ -20,6 +20,7 @@ import (
 	"regexp"
 
 	"go.uber.org/nilaway/annotation"
+	"go.uber.org/nilaway/config"
 	"go.uber.org/nilaway/util/analysishelper"
 	"go.uber.org/nilaway/util/asthelper"
 	"go.uber.org/nilaway/util/typeshelper"
@@ -28,11 +29,15 @@ import (
 // AssumeReturn returns the producer for the return value of the given call expression, which would
 // have the assumed nilability. This is useful for modeling the return value of stdlib and 3rd party
 // functions that are not analyzed by NilAway. For example, "errors.New" is assumed to return a
-// nonnil value. If the given call expression does not match any known function, nil is returned.
+// nonnil value, and the getters of message-typed fields of protobuf messages are assumed to return
+// nilable values. If the given call expression does not match any known function, nil is returned.
 func AssumeReturn(pass *analysishelper.EnhancedPass, call *ast.CallExpr) *annotation.ProduceTrigger {
 	if trigger := matchTrustedFuncs(pass, call); trigger != nil {
 		return trigger
 	}
+	if trigger := assumeProtoGetterReturn(pass, call); trigger != nil {
+		return trigger
+	}
 	return AssumeReturnForErrorWrapperFunc(pass, call)
 }
 
@@ -42,6 +47,11 @@ func matchTrustedFuncs(pass *analysishelper.EnhancedPass, call *ast.CallExpr) *a
 			return act(call)
 		}
 	}
+	for _, m := range configuredModels(pass, config.TrustedActionNonnilReturn) {
+		if sig := configuredSig(m); sig.matchCall(pass, call) {
+			return nonnilProducer(call)
+		}
+	}
 	return nil
 }
 
@@ -19,6 +19,7 @@ import (
 	"go/token"
 	"regexp"
 
+	"go.uber.org/nilaway/config"
 	"go.uber.org/nilaway/util/analysishelper"
 )
 
@@ -44,6 +45,11 @@ func ErrorReturnNonnilArg(pass *analysishelper.EnhancedPass, call *ast.CallExpr)
 			return act.action(call, act.argIndex)
 		}
 	}
+	for _, m := range configuredModels(pass, config.TrustedActionErrorReturnNonnilArg) {
+		if sig := configuredSig(m); sig.matchCall(pass, call) {
+			return pointeeOfArg(call, m.Arg)
+		}
+	}
 	return nil
 }
 
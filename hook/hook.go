@@ -24,6 +24,7 @@ import (
 	"go/types"
 	"regexp"
 
+	"go.uber.org/nilaway/config"
 	"go.uber.org/nilaway/util/analysishelper"
 	"go.uber.org/nilaway/util/typeshelper"
 )
@@ -110,6 +111,33 @@ func (t *trustedSig) matchSel(pass *analysishelper.EnhancedPass, sel *ast.Select
 	return t.enclosingRegex.MatchString(varObj.Pkg().Path())
 }
 
+// _trustedKinds maps the kinds of user-declared trusted models to the kinds of trusted signatures.
+var _trustedKinds = map[config.TrustedKind]trustedKind{
+	config.TrustedKindMethod: _method,
+	config.TrustedKindFunc:   _func,
+	config.TrustedKindVar:    _var,
+}
+
+// configuredModels returns the user-declared trusted models (see config.TrustedFuncModelsFlag)
+// that have the given action. These are consulted after the built-in tables, so a user model can
+// only add knowledge, never override a built-in one.
+func configuredModels(pass *analysishelper.EnhancedPass, action config.TrustedAction) []*config.TrustedModel {
+	conf, ok := pass.ResultOf[config.Analyzer].(*config.Config)
+	if !ok {
+		return nil
+	}
+	return conf.TrustedModels[action]
+}
+
+// configuredSig returns the trusted signature of a user-declared trusted model.
+func configuredSig(m *config.TrustedModel) trustedSig {
+	return trustedSig{
+		kind:           _trustedKinds[m.Kind],
+		enclosingRegex: m.EnclosingRegex,
+		nameRegex:      m.NameRegex,
+	}
+}
+
 // newNilBinaryExpr creates a new binary expression "expr op nil".
 func newNilBinaryExpr(expr ast.Expr, op token.Token) *ast.BinaryExpr {
 	return &ast.BinaryExpr{
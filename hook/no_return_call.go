@@ -19,6 +19,7 @@ import (
 	"regexp"
 	"slices"
 
+	"go.uber.org/nilaway/config"
 	"go.uber.org/nilaway/util/analysishelper"
 )
 
@@ -31,7 +32,13 @@ import (
 //
 // `testing.TB.Fatal`-related: they are interface methods without implementations.
 func IsNoReturnCall(pass *analysishelper.EnhancedPass, call *ast.CallExpr) bool {
-	return slices.ContainsFunc(_terminatingCalls, func(sig trustedSig) bool { return sig.matchCall(pass, call) })
+	if slices.ContainsFunc(_terminatingCalls, func(sig trustedSig) bool { return sig.matchCall(pass, call) }) {
+		return true
+	}
+	return slices.ContainsFunc(configuredModels(pass, config.TrustedActionNoReturn), func(m *config.TrustedModel) bool {
+		sig := configuredSig(m)
+		return sig.matchCall(pass, call)
+	})
 }
 
 var _terminatingCalls = []trustedSig{
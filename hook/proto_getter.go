@@ -0,0 +1,110 @@
+//  Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hook
+
+import (
+	"go/ast"
+	"go/types"
+	"regexp"
+	"strings"
+
+	"go.uber.org/nilaway/annotation"
+	"go.uber.org/nilaway/util/analysishelper"
+	"go.uber.org/nilaway/util/typeshelper"
+)
+
+// _protoReflectPkgRegex matches the package path of `protoreflect`, whose `Message` type is returned
+// by the `ProtoReflect` method that all protobuf messages implement (i.e., `protoreflect.ProtoMessage`).
+var _protoReflectPkgRegex = regexp.MustCompile(`^(stubs/)?google\.golang\.org/protobuf/reflect/protoreflect$`)
+
+// IsProtoGetter returns true if the given selector expression refers to a getter of a protobuf
+// message, i.e., a `GetX()` method with a pointer receiver of a type implementing
+// `protoreflect.ProtoMessage`. The getters generated by protoc-gen-go are nil-safe: they can be
+// called on nil receivers, in which case they return the zero value of their result type. Since
+// the generated code is usually excluded from analysis (e.g., via `Code generated by` in the
+// docstrings), NilAway cannot infer this from their bodies and models them here instead.
+func IsProtoGetter(pass *analysishelper.EnhancedPass, sel *ast.SelectorExpr) bool {
+	return protoGetter(pass, sel) != nil
+}
+
+// assumeProtoGetterReturn returns the producer for the result of a call to a getter of a protobuf
+// message (see IsProtoGetter). Getters of message-typed fields return nil for unset fields, so they
+// are nilable. Getters of scalar, enum, bytes, repeated and map fields return values that are safe
+// to use even if they are zero (e.g., nil slices and maps can still be ranged over and read), so
+// they are nonnil. If the given call expression is not a call to such a getter (or the result is
+// of any other type, e.g., the interface of a oneof field), nil is returned.
+func assumeProtoGetterReturn(pass *analysishelper.EnhancedPass, call *ast.CallExpr) *annotation.ProduceTrigger {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return nil
+	}
+	getter := protoGetter(pass, sel)
+	if getter == nil {
+		return nil
+	}
+
+	result := getter.Signature().Results().At(0).Type()
+	if typeshelper.IsPointer(result) && isProtoMessage(result) {
+		return &annotation.ProduceTrigger{
+			Annotation: &annotation.TrustedFuncNilable{ProduceTriggerTautology: &annotation.ProduceTriggerTautology{}},
+			Expr:       call,
+		}
+	}
+	switch result.Underlying().(type) {
+	case *types.Basic, *types.Slice, *types.Map:
+		return nonnilProducer(call)
+	}
+	return nil
+}
+
+// protoGetter returns the getter of a protobuf message the given selector expression refers to
+// (see IsProtoGetter), or nil if it does not refer to one.
+func protoGetter(pass *analysishelper.EnhancedPass, sel *ast.SelectorExpr) *types.Func {
+	if !strings.HasPrefix(sel.Sel.Name, "Get") {
+		return nil
+	}
+	funcObj, ok := pass.TypesInfo.ObjectOf(sel.Sel).(*types.Func)
+	if !ok {
+		return nil
+	}
+	sig := funcObj.Signature()
+	if sig.Recv() == nil || !typeshelper.IsPointer(sig.Recv().Type()) ||
+		sig.Params().Len() != 0 || sig.Results().Len() != 1 {
+		return nil
+	}
+	if !isProtoMessage(sig.Recv().Type()) {
+		return nil
+	}
+	return funcObj
+}
+
+// isProtoMessage returns true if the given type implements `protoreflect.ProtoMessage`, i.e., it has
+// a `ProtoReflect()` method returning `protoreflect.Message`.
+func isProtoMessage(t types.Type) bool {
+	obj, _, _ := types.LookupFieldOrMethod(t, true /* addressable */, nil /* pkg */, "ProtoReflect")
+	method, ok := obj.(*types.Func)
+	if !ok {
+		return false
+	}
+	sig := method.Signature()
+	if sig.Params().Len() != 0 || sig.Results().Len() != 1 {
+		return false
+	}
+	named, ok := types.Unalias(sig.Results().At(0).Type()).(*types.Named)
+	if !ok || named.Obj().Pkg() == nil {
+		return false
+	}
+	return named.Obj().Name() == "Message" && _protoReflectPkgRegex.MatchString(named.Obj().Pkg().Path())
+}
@@ -21,6 +21,7 @@ import (
 	"go/types"
 	"regexp"
 
+	"go.uber.org/nilaway/config"
 	"go.uber.org/nilaway/util/analysishelper"
 	"go.uber.org/nilaway/util/typeshelper"
 )
@@ -36,6 +37,13 @@ func SplitBlockOn(pass *analysishelper.EnhancedPass, call *ast.CallExpr) ast.Exp
 			return act.action(pass, call, act.argIndex)
 		}
 	}
+	for _, c := range _configuredSplitBlockOn {
+		for _, m := range configuredModels(pass, c.trustedAction) {
+			if sig := configuredSig(m); sig.matchCall(pass, call) {
+				return c.action(pass, call, m.Arg)
+			}
+		}
+	}
 	return nil
 }
 
@@ -80,6 +88,19 @@ var negatedSelfExpr splitBlockOnAction = func(_ *analysishelper.EnhancedPass, ca
 	}
 }
 
+// _configuredSplitBlockOn lists the split-on-* actions of user-declared trusted models with their
+// corresponding actions. It is a slice rather than a map so that, if a call matches the models of
+// more than one action, the action that applies is deterministic (the first one listed here).
+var _configuredSplitBlockOn = []struct {
+	trustedAction config.TrustedAction
+	action        splitBlockOnAction
+}{
+	{trustedAction: config.TrustedActionSplitOnNil, action: nilBinaryExpr},
+	{trustedAction: config.TrustedActionSplitOnNonnil, action: nonnilBinaryExpr},
+	{trustedAction: config.TrustedActionSplitOnTrue, action: selfExpr},
+	{trustedAction: config.TrustedActionSplitOnFalse, action: negatedSelfExpr},
+}
+
 // The constant (enum) values below represent the possible values of an expected expression in a comparison
 // E.g., `Equal(1, len(s))`, where `1` is the expected expression and is assigned the value `_greaterThanZero`.
 // E.g., `Equal(nil, err)`, where `nil` is the expected expression and is assigned the value `_nil`.
@@ -0,0 +1,76 @@
+//  Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hook
+
+import (
+	"go/ast"
+	"regexp"
+	"slices"
+
+	"go.uber.org/nilaway/config"
+	"go.uber.org/nilaway/util/analysishelper"
+)
+
+// IsSyncCall returns whether the specific call expression definitely runs the function literals
+// passed to it synchronously, i.e., before the call returns. It is used to model the functions
+// declared by the users with the sync-call action, such that the assignments a function literal
+// makes to its captured variables (e.g., `pool.Run(func() { x = new(T) })`) are visible to the
+// enclosing function after the call.
+func IsSyncCall(pass *analysishelper.EnhancedPass, call *ast.CallExpr) bool {
+	return slices.ContainsFunc(configuredModels(pass, config.TrustedActionSyncCall), func(m *config.TrustedModel) bool {
+		sig := configuredSig(m)
+		return sig.matchCall(pass, call)
+	})
+}
+
+// IsMaybeSyncCall returns whether the specific call expression may run the function literals
+// passed to it synchronously, i.e., if at all, before the call returns. It is used to model
+// certain stdlib functions (e.g., `once.Do(func() { x = new(T) })` only runs the literal on the
+// first call, and `sort.Slice` does not run it for short slices), such that the assignments a
+// function literal makes to its captured variables are merged with the values of the variables
+// before the call.
+//
+// Note that the functions that run the literals asynchronously (e.g., `errgroup.Group.Go`), or
+// possibly after they return (e.g., `testing.T.Run` for parallel subtests), are not modeled.
+func IsMaybeSyncCall(pass *analysishelper.EnhancedPass, call *ast.CallExpr) bool {
+	return slices.ContainsFunc(_maybeSyncCalls, func(sig trustedSig) bool { return sig.matchCall(pass, call) })
+}
+
+var _maybeSyncCalls = []trustedSig{
+	// `sync.Once.Do`
+	{
+		kind:           _method,
+		enclosingRegex: regexp.MustCompile(`^sync.Once$`),
+		nameRegex:      regexp.MustCompile(`^Do$`),
+	},
+	// `sync.Map.Range`
+	{
+		kind:           _method,
+		enclosingRegex: regexp.MustCompile(`^sync.Map$`),
+		nameRegex:      regexp.MustCompile(`^Range$`),
+	},
+	// `sort.Slice` / `sort.SliceStable` / `sort.Search`
+	{
+		kind:           _func,
+		enclosingRegex: regexp.MustCompile(`^sort$`),
+		nameRegex:      regexp.MustCompile(`^(Slice|SliceStable|Search)$`),
+	},
+	// `slices.SortFunc` / `slices.SortStableFunc` / `slices.IndexFunc` / `slices.ContainsFunc` / `slices.DeleteFunc`
+	{
+		kind:           _func,
+		enclosingRegex: regexp.MustCompile(`^slices$`),
+		nameRegex:      regexp.MustCompile(`^(SortFunc|SortStableFunc|IndexFunc|ContainsFunc|DeleteFunc)$`),
+	},
+}
@@ -642,4 +642,14 @@ func GobRegister() {
 	gob.RegisterName(nextStr(), annotation.RecvPassPrestring{})
 	gob.RegisterName(nextStr(), annotation.MethodRecvDeepPrestring{})
 	gob.RegisterName(nextStr(), annotation.FldReturnPrestring{})
+	gob.RegisterName(nextStr(), annotation.TypeAssertPrestring{})
+	gob.RegisterName(nextStr(), annotation.TypeAssertResultPrestring{})
+	gob.RegisterName(nextStr(), annotation.ClosedChanRecvPrestring{})
+	gob.RegisterName(nextStr(), annotation.ChanClosePrestring{})
+	gob.RegisterName(nextStr(), annotation.ClosedChanSendPrestring{})
+	gob.RegisterName(nextStr(), annotation.FuncValueReturnPrestring{})
+	gob.RegisterName(nextStr(), annotation.UnresolvedFuncValueReturnPrestring{})
+	gob.RegisterName(nextStr(), annotation.UseAsFuncValueResultPrestring{})
+	gob.RegisterName(nextStr(), annotation.ClosureVarReturnPrestring{})
+	gob.RegisterName(nextStr(), annotation.UseAsClosureVarReturnPrestring{})
 }
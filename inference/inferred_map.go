@@ -86,6 +86,20 @@ func (i *InferredMap) StoreImplication(from primitiveSite, to primitiveSite, ass
 	i.mapping.Value(to).(*UndeterminedVal).Implicants.Store(from, assertion)
 }
 
+// Determined returns the explained nilability of the shallow (or deep) site of the given key if
+// it is determined.
+func (i *InferredMap) Determined(key annotation.Key, isDeep bool) (ExplainedBool, bool) {
+	val, ok := i.mapping.Load(i.primitive.site(key, isDeep))
+	if !ok {
+		return nil, false
+	}
+	determined, ok := val.(*DeterminedVal)
+	if !ok {
+		return nil, false
+	}
+	return determined.Bool, true
+}
+
 // Len returns the number of annotation sites currently stored in the map.
 func (i *InferredMap) Len() int {
 	return len(i.mapping.Pairs)
@@ -35,12 +35,17 @@ const (
 	FullInfer
 )
 
-// DetermineMode searches the files in this package for docstrings that indicate
-// inference should be entirely suppressed (returns NoInfer). By default, if no such
-// docstring is found, multi-package inference is used (returns FullInfer).
+// DetermineMode returns NoInfer if the package is configured to run in strict (annotation-only)
+// mode (see config.StrictPkgsFlag), or if the files in this package have the strict directive (see
+// config.StrictDirective) or docstrings (see config.NilAwayNoInferString) that indicate inference
+// should be entirely suppressed. By default, multi-package inference is used (returns FullInfer).
 func DetermineMode(pass *analysishelper.EnhancedPass) ModeOfInference {
+	conf := pass.ResultOf[config.Analyzer].(*config.Config)
+	if conf.IsPkgStrict(pass.Pkg) {
+		return NoInfer
+	}
 	for _, file := range pass.Files {
-		if asthelper.DocContains(file, config.NilAwayNoInferString) {
+		if asthelper.HasDirective(file, config.StrictDirective) || asthelper.DocContains(file, config.NilAwayNoInferString) {
 			return NoInfer
 		}
 	}
@@ -22,6 +22,7 @@ import (
 	"go.uber.org/nilaway/annotation"
 	"go.uber.org/nilaway/util/analysishelper"
 	"go.uber.org/nilaway/util/tokenhelper"
+	"go.uber.org/nilaway/util/typeshelper"
 	"golang.org/x/tools/go/types/objectpath"
 )
 
@@ -196,17 +197,23 @@ func (p *primitivizer) fullTrigger(trigger annotation.FullTrigger) primitiveFull
 
 // site returns the primitive version of the annotation site.
 func (p *primitivizer) site(key annotation.Key, isDeep bool) primitiveSite {
-	obj := key.Object()
+	// Fields and methods of instantiated generic types are distinct objects in go/types, but they
+	// must share the site of the generic declaration (which also has a valid object path). The
+	// nilability of such a site is hence inferred across all instantiations, but the triggers at
+	// the instantiations are only created for nilable type arguments (see
+	// analysishelper.EnhancedPass.ExprBarsNilness), such that, e.g., a nilable result of type `T`
+	// is only reported where `T` is instantiated with a pointer type.
+	obj := typeshelper.OriginObject(key.Object())
 	objPath := p.objectPath(obj)
 
 	pkgRepr := ""
-	if pkg := key.Object().Pkg(); pkg != nil {
+	if pkg := obj.Pkg(); pkg != nil {
 		pkgRepr = pkg.Path()
 	}
 
 	var position token.Position
 	// For upstream objects, we need to look up the local position cache for correct positions.
-	if key.Object().Pkg() != p.pass.Pkg {
+	if obj.Pkg() != p.pass.Pkg {
 		// Correct upstream information may not always be in the cache: we may not even have it
 		// since we skipped analysis for standard and 3rd party libraries.
 		if p, ok := p.upstreamObjPositions[pkgRepr+"."+string(objPath)]; ok {
@@ -218,14 +225,14 @@ func (p *primitivizer) site(key annotation.Key, isDeep bool) primitiveSite {
 	// their Object.Pos() and retrieve the position information. However, we must trim the possible
 	// build-system sandbox prefix from the filenames for cross-package references.
 	if !position.IsValid() {
-		position = p.toPosition(key.Object().Pos())
+		position = p.toPosition(obj.Pos())
 	}
 
 	return primitiveSite{
 		PkgPath:    pkgRepr,
 		Repr:       key.String(),
 		IsDeep:     isDeep,
-		Exported:   key.Object().Exported(),
+		Exported:   obj.Exported(),
 		ObjectPath: objPath,
 		Position:   position,
 	}
@@ -0,0 +1,172 @@
+//  Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package inference
+
+import (
+	"cmp"
+	"fmt"
+	"go/token"
+	"path/filepath"
+	"slices"
+
+	"go.uber.org/nilaway/annotation"
+	"go.uber.org/nilaway/util/tokenhelper"
+)
+
+// Nilability values of the sites in a SiteReport.
+const (
+	NilabilityNilable      = "nilable"
+	NilabilityNonnil       = "nonnil"
+	NilabilityUndetermined = "undetermined"
+)
+
+// SiteReport is the human-readable (and JSON-serializable) form of an annotation site and its
+// inferred nilability, which allows users to review what NilAway believes about their code (e.g.,
+// the contracts of their public APIs) and to diff the beliefs between releases.
+type SiteReport struct {
+	// Site is the description of the site, e.g., "Result 0 of function `foo`".
+	Site string `json:"site"`
+	// Position is the position of the site, relative to the current working directory if possible.
+	Position string `json:"position"`
+	// Deep indicates if the site describes the deep nilability (e.g., the elements of a slice) of
+	// the underlying entity, rather than the entity itself.
+	Deep bool `json:"deep,omitempty"`
+	// Exported indicates if the site is visible to other packages.
+	Exported bool `json:"exported,omitempty"`
+	// Nilability is one of NilabilityNilable, NilabilityNonnil, and NilabilityUndetermined.
+	Nilability string `json:"nilability"`
+	// Reasons is the chain of reasons for a determined nilability, starting from the constraint
+	// directly on the site and ending at the annotation or the definite nil production (or nonnil
+	// consumption) that caused it.
+	Reasons []ReasonReport `json:"reasons,omitempty"`
+	// Implicants are the sites that make this undetermined site nilable if they are nilable.
+	Implicants []string `json:"implicants,omitempty"`
+	// Implicates are the sites that make this undetermined site nonnil if they are nonnil.
+	Implicates []string `json:"implicates,omitempty"`
+}
+
+// ReasonReport is a single step in the reason chain of a determined site (see ExplainedBool).
+type ReasonReport struct {
+	// Kind is one of "annotation", "shallow constraint", and "deep constraint".
+	Kind     string `json:"kind"`
+	Position string `json:"position"`
+	Producer string `json:"producer,omitempty"`
+	Consumer string `json:"consumer,omitempty"`
+}
+
+// Report returns the reports of all sites in the given maps that belong to the given package,
+// sorted by their positions such that the report is stable across runs and friendly to diffs.
+// Sites present in multiple maps (e.g., the maps of a package and its test variant) are reported
+// with their values in the first map.
+func Report(pkgPath string, maps ...*InferredMap) []SiteReport {
+	var sites []primitiveSite
+	values := make(map[primitiveSite]InferredVal)
+	for _, m := range maps {
+		for _, p := range m.mapping.Pairs {
+			if _, ok := values[p.Key]; !ok && p.Key.PkgPath == pkgPath {
+				sites = append(sites, p.Key)
+				values[p.Key] = p.Value
+			}
+		}
+	}
+	slices.SortFunc(sites, func(a, b primitiveSite) int {
+		return cmp.Or(
+			cmp.Compare(a.Position.Filename, b.Position.Filename),
+			cmp.Compare(a.Position.Offset, b.Position.Offset),
+			cmp.Compare(a.Repr, b.Repr),
+			// Shallow sites go before deep ones.
+			cmp.Compare(btoi(a.IsDeep), btoi(b.IsDeep)),
+		)
+	})
+
+	reports := make([]SiteReport, 0, len(sites))
+	for _, site := range sites {
+		val := values[site]
+		r := SiteReport{
+			Site:     site.Repr,
+			Position: reportPosition(site.Position),
+			Deep:     site.IsDeep,
+			Exported: site.Exported,
+		}
+		switch v := val.(type) {
+		case *DeterminedVal:
+			r.Nilability = NilabilityNonnil
+			if v.Bool.Val() {
+				r.Nilability = NilabilityNilable
+			}
+			r.Reasons = reasonChain(v.Bool)
+		case *UndeterminedVal:
+			r.Nilability = NilabilityUndetermined
+			for _, p := range v.Implicants.Pairs {
+				r.Implicants = append(r.Implicants, p.Key.String())
+			}
+			for _, p := range v.Implicates.Pairs {
+				r.Implicates = append(r.Implicates, p.Key.String())
+			}
+		default:
+			panic(fmt.Sprintf("unrecognized inferred value type %T", val))
+		}
+		reports = append(reports, r)
+	}
+	return reports
+}
+
+// reasonChain flattens the explanation and its deeper reasons into a list of steps.
+func reasonChain(explained ExplainedBool) []ReasonReport {
+	var chain []ReasonReport
+	for ; explained != nil; explained = explained.DeeperReason() {
+		r := ReasonReport{Position: reportPosition(explained.Position())}
+		switch explained.(type) {
+		case TrueBecauseAnnotation, FalseBecauseAnnotation:
+			r.Kind = "annotation"
+		case TrueBecauseShallowConstraint, FalseBecauseShallowConstraint:
+			r.Kind = "shallow constraint"
+		case TrueBecauseDeepConstraint, FalseBecauseDeepConstraint:
+			r.Kind = "deep constraint"
+		default:
+			panic(fmt.Sprintf("unrecognized explained bool type %T", explained))
+		}
+		if producer, consumer := explained.TriggerReprs(); producer != nil && consumer != nil {
+			r.Producer, r.Consumer = unlocated(producer), unlocated(consumer)
+		}
+		chain = append(chain, r)
+	}
+	return chain
+}
+
+// unlocated returns the string representation of the prestring without its location, which is
+// already available in the position of the reason.
+func unlocated(s fmt.Stringer) string {
+	if l, ok := s.(annotation.LocatedPrestring); ok {
+		return l.Contained.String()
+	}
+	return s.String()
+}
+
+// reportPosition returns the string representation of the position with the file name relative
+// to the current working directory (if possible), such that the reports are portable.
+func reportPosition(pos token.Position) string {
+	if pos.Filename != "" {
+		pos.Filename = filepath.ToSlash(tokenhelper.RelToCwd(pos.Filename))
+	}
+	return pos.String()
+}
+
+func btoi(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
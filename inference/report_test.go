@@ -0,0 +1,79 @@
+//  Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package inference
+
+import (
+	"go/token"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/nilaway/annotation"
+)
+
+func TestReport(t *testing.T) {
+	t.Parallel()
+
+	pos := func(line int) token.Position {
+		return token.Position{Filename: "foo.go", Line: line, Column: 1, Offset: 10 * line}
+	}
+	param := primitiveSite{Position: pos(10), PkgPath: "pkg", Repr: "Param 0 of Function f", Exported: true}
+	ret := primitiveSite{Position: pos(9), PkgPath: "pkg", Repr: "Result 0 of Function g"}
+	field := primitiveSite{Position: pos(2), PkgPath: "pkg", Repr: "Field f"}
+	upstream := primitiveSite{Position: pos(1), PkgPath: "upstream", Repr: "Result 0 of Function h"}
+
+	m := newInferredMap(nil /* primitive */)
+	annotated := TrueBecauseAnnotation{AnnotationPos: pos(9)}
+	m.StoreDetermined(ret, annotated)
+	m.StoreDetermined(upstream, annotated)
+	m.StoreImplication(field, param, primitiveFullTrigger{})
+	m.StoreDetermined(field, TrueBecauseDeepConstraint{
+		InternalAssertion: primitiveFullTrigger{
+			Position:     pos(5),
+			ProducerRepr: annotation.LocatedPrestring{Contained: annotation.FuncReturnPrestring{FuncName: "g"}, Location: pos(5)},
+			ConsumerRepr: annotation.FldAssignPrestring{FieldName: "f"},
+		},
+		DeeperExplanation: annotated,
+	})
+
+	// The field is determined in the test variant as well, but the first map takes precedence.
+	testVariant := newInferredMap(nil /* primitive */)
+	testVariant.StoreDetermined(field, FalseBecauseAnnotation{AnnotationPos: pos(2)})
+
+	reports := Report("pkg", m, testVariant)
+	require.Equal(t, []SiteReport{
+		{
+			Site:       "Field f",
+			Position:   "foo.go:2:1",
+			Nilability: NilabilityNilable,
+			Reasons: []ReasonReport{
+				{Kind: "deep constraint", Position: "foo.go:5:1", Producer: "result 0 of `g()`", Consumer: "assigned into field `f`"},
+				{Kind: "annotation", Position: "foo.go:9:1"},
+			},
+		},
+		{
+			Site:       "Result 0 of Function g",
+			Position:   "foo.go:9:1",
+			Nilability: NilabilityNilable,
+			Reasons:    []ReasonReport{{Kind: "annotation", Position: "foo.go:9:1"}},
+		},
+		{
+			Site:       "Param 0 of Function f",
+			Position:   "foo.go:10:1",
+			Exported:   true,
+			Nilability: NilabilityUndetermined,
+			Implicants: []string{"Field f"},
+		},
+	}, reports)
+}
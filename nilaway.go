@@ -17,11 +17,12 @@
 package nilaway
 
 import (
-	"fmt"
-	"regexp"
+	"reflect"
+	"slices"
 
 	"go.uber.org/nilaway/accumulation"
 	"go.uber.org/nilaway/config"
+	"go.uber.org/nilaway/diagnostic"
 	"go.uber.org/nilaway/util/analysishelper"
 	"golang.org/x/tools/go/analysis"
 )
@@ -31,43 +32,39 @@ const _doc = "Run NilAway on this package to report any possible flows of nil va
 
 // Analyzer is the top-level instance of Analyzer - it coordinates the entire dataflow to report
 // nil flow errors in this package. It is needed here for nogo to recognize the package.
+//
+// The result of the analyzer is the list of reported diagnostics along with their structured nil
+// flows, which drivers can use to render the diagnostics in other formats (e.g., SARIF).
 var Analyzer = &analysis.Analyzer{
-	Name:      "nilaway",
-	Doc:       _doc,
-	Run:       run,
-	FactTypes: []analysis.Fact{},
-	Requires:  []*analysis.Analyzer{config.Analyzer, accumulation.Analyzer},
+	Name:       "nilaway",
+	Doc:        _doc,
+	Run:        run,
+	FactTypes:  []analysis.Fact{},
+	Requires:   []*analysis.Analyzer{config.Analyzer, accumulation.Analyzer},
+	ResultType: reflect.TypeOf(([]diagnostic.Diagnostic)(nil)),
 }
 
 func run(p *analysis.Pass) (interface{}, error) {
 	pass := analysishelper.NewEnhancedPass(p)
 	conf := pass.ResultOf[config.Analyzer].(*config.Config)
-	deferredErrors := pass.ResultOf[accumulation.Analyzer].([]analysis.Diagnostic)
-	for _, e := range deferredErrors {
-		if conf.PrettyPrint {
-			e.Message = PrettyPrintErrorMessage(e.Message)
+	// Clone the diagnostics since we may modify the messages, and the result of the accumulation
+	// analyzer should be kept intact.
+	deferredErrors := slices.Clone(pass.ResultOf[accumulation.Analyzer].(*accumulation.Result).Diagnostics)
+	for i := range deferredErrors {
+		// Diagnostics describing nil flows are already pretty printed (if enabled) from their
+		// structured data, so here we only need to handle the unstructured ones (e.g., internal
+		// errors).
+		if conf.PrettyPrint && deferredErrors[i].Flow == nil {
+			deferredErrors[i].Message = PrettyPrintErrorMessage(deferredErrors[i].Message)
 		}
-		pass.Report(e)
+		pass.Report(deferredErrors[i].Diagnostic)
 	}
 
-	return nil, nil
+	return deferredErrors, nil
 }
 
-var codeReferencePattern = regexp.MustCompile("\\`(.*?)\\`")
-var pathPattern = regexp.MustCompile(`"(.*?)"`)
-var nilabilityPattern = regexp.MustCompile(`([\(|^\t](?i)(found\s|must\sbe\s)(nilable|nonnil)[\)]?)`)
-
-// PrettyPrintErrorMessage is used in error reporting to post process and pretty print the output with colors.
+// PrettyPrintErrorMessage is used in error reporting to pretty print unstructured messages with
+// colors. See [diagnostic.PrettyPrint] for more details.
 func PrettyPrintErrorMessage(msg string) string {
-	// TODO: below string parsing should not be required after  is implemented
-	errorStr := fmt.Sprintf("\x1b[%dm%s\x1b[0m", 31, "error: ")      // red
-	codeStr := fmt.Sprintf("\u001B[%dm%s\u001B[0m", 95, "`${1}`")    // magenta
-	pathStr := fmt.Sprintf("\u001B[%dm%s\u001B[0m", 36, "${1}")      // cyan
-	nilabilityStr := fmt.Sprintf("\u001B[%dm%s\u001B[0m", 1, "${1}") // bold
-
-	msg = nilabilityPattern.ReplaceAllString(msg, nilabilityStr)
-	msg = codeReferencePattern.ReplaceAllString(msg, codeStr)
-	msg = pathPattern.ReplaceAllString(msg, pathStr)
-	msg = errorStr + msg
-	return msg
+	return diagnostic.PrettyPrint(msg)
 }
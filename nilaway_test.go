@@ -24,6 +24,8 @@ package nilaway
 import (
 	"fmt"
 	"os"
+	"path/filepath"
+	"strconv"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -64,11 +66,16 @@ func TestNilAway(t *testing.T) {
 		{name: "SimpleFlow", patterns: []string{"go.uber.org/simpleflow"}},
 		{name: "LoopFlow", patterns: []string{"go.uber.org/loopflow"}},
 		{name: "MethodImplementation", patterns: []string{"go.uber.org/methodimplementation", "go.uber.org/methodimplementation/mergedDependencies", "go.uber.org/methodimplementation/chainedDependencies", "go.uber.org/methodimplementation/multipackage", "go.uber.org/methodimplementation/embedding"}},
+		{name: "DeferStmt", patterns: []string{"go.uber.org/deferstmt"}},
+		{name: "TypeAssertion", patterns: []string{"go.uber.org/typeassertion"}},
+		{name: "ClosedChan", patterns: []string{"go.uber.org/closedchan"}},
+		{name: "FunctionValues", patterns: []string{"go.uber.org/functionvalues"}},
+		{name: "AnonymousFunction", patterns: []string{"go.uber.org/anonymousfunction"}},
 		{name: "NamedReturn", patterns: []string{"go.uber.org/namedreturn"}},
 		{name: "IgnoreGenerated", patterns: []string{"go.uber.org/ignoregenerated"}},
 		{name: "IgnorePackage", patterns: []string{"ignoredpkg1", "ignoredpkg2"}},
 		{name: "Receivers", patterns: []string{"go.uber.org/receivers", "go.uber.org/receivers/inference"}},
-		{name: "Generics", patterns: []string{"go.uber.org/generics"}},
+		{name: "Generics", patterns: []string{"go.uber.org/generics", "go.uber.org/generics/inference"}},
 		{name: "FunctionContracts", patterns: []string{"go.uber.org/functioncontracts", "go.uber.org/functioncontracts/inference"}},
 		{name: "Constants", patterns: []string{"go.uber.org/consts"}},
 		{name: "LoopRange", patterns: []string{"go.uber.org/looprange"}},
@@ -76,6 +83,7 @@ func TestNilAway(t *testing.T) {
 		{name: "NoLint", patterns: []string{"go.uber.org/nolint"}},
 		{name: "Templ", patterns: []string{"go.uber.org/templ"}},
 		{name: "CtrlflowIntrinsic", patterns: []string{"go.uber.org/zap"}},
+		{name: "ProtoGetter", patterns: []string{"go.uber.org/protogetter"}},
 	}
 
 	for _, tt := range tests {
@@ -129,18 +137,68 @@ func TestStructInitV2(t *testing.T) { //nolint:paralleltest
 	t.Skip("struct-init-v2 testdata is not wired")
 }
 
-func TestAnonymousFunction(t *testing.T) { //nolint:paralleltest
-	// We specifically do not set this test to be parallel since we need to enable the
-	// experimental support for anonymous function to test this feature.
-	err := config.Analyzer.Flags.Set(config.ExperimentalAnonymousFunctionFlag, "true")
+func TestAnonymousFunctionDisabled(t *testing.T) { //nolint:paralleltest
+	// We specifically do not set this test to be parallel since we need to disable the support
+	// for anonymous function (which is enabled by default) to test this feature.
+	err := config.Analyzer.Flags.Set(config.ExperimentalAnonymousFunctionFlag, "false")
+	require.NoError(t, err)
+	defer func() {
+		err := config.Analyzer.Flags.Set(config.ExperimentalAnonymousFunctionFlag, "true")
+		require.NoError(t, err)
+	}()
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, Analyzer, "go.uber.org/anonymousfunction/disabled")
+}
+
+func TestTrustedFuncModels(t *testing.T) { //nolint:paralleltest
+	// We specifically do not set this test to be parallel since the user-provided trusted models
+	// would otherwise apply to the other tests.
+	testdata := analysistest.TestData()
+	err := config.Analyzer.Flags.Set(config.TrustedFuncModelsFlag, filepath.Join(testdata, "src", "go.uber.org", "trustedfunc", "usermodel", "models.json"))
+	require.NoError(t, err)
+	defer func() {
+		err := config.Analyzer.Flags.Set(config.TrustedFuncModelsFlag, "")
+		require.NoError(t, err)
+	}()
+
+	analysistest.Run(t, testdata, Analyzer, "go.uber.org/trustedfunc/usermodel")
+}
+
+func TestAnnotationStubs(t *testing.T) { //nolint:paralleltest
+	// We specifically do not set this test to be parallel since the exclusion of the stubbed
+	// package and the stub files would otherwise apply to the other tests.
+	testdata := analysistest.TestData()
+	defaultExclude := config.Analyzer.Flags.Lookup(config.ExcludePkgsFlag).Value.String()
+	require.NoError(t, config.Analyzer.Flags.Set(config.ExcludePkgsFlag, "go.uber.org/annotationstub/external"))
+	require.NoError(t, config.Analyzer.Flags.Set(config.AnnotationStubsFlag, filepath.Join(testdata, "src", "go.uber.org", "annotationstub", "external.nilaway")))
+	defer func() {
+		require.NoError(t, config.Analyzer.Flags.Set(config.ExcludePkgsFlag, defaultExclude))
+		require.NoError(t, config.Analyzer.Flags.Set(config.AnnotationStubsFlag, ""))
+	}()
+
+	analysistest.Run(t, testdata, Analyzer, "go.uber.org/annotationstub", "go.uber.org/annotationstub/strict")
+}
+
+func TestMaxFuncSize(t *testing.T) { //nolint:paralleltest
+	// We specifically do not set this test to be parallel since the lowered function size limit
+	// would otherwise apply to the other tests.
+	err := config.Analyzer.Flags.Set(config.MaxFuncSizeFlag, "10")
 	require.NoError(t, err)
 	defer func() {
-		err := config.Analyzer.Flags.Set(config.ExperimentalAnonymousFunctionFlag, "false")
+		err := config.Analyzer.Flags.Set(config.MaxFuncSizeFlag, strconv.Itoa(config.DefaultMaxFuncSizeInCFGBlocks))
 		require.NoError(t, err)
 	}()
 
 	testdata := analysistest.TestData()
-	analysistest.Run(t, testdata, Analyzer, "go.uber.org/anonymousfunction")
+	analysistest.Run(t, testdata, Analyzer, "go.uber.org/skippedfunc", "go.uber.org/skippedfunc/summary")
+}
+
+func TestSuggestedFixes(t *testing.T) {
+	t.Parallel()
+
+	testdata := analysistest.TestData()
+	analysistest.RunWithSuggestedFixes(t, testdata, Analyzer, "go.uber.org/suggestedfix")
 }
 
 func TestPrettyPrint(t *testing.T) { //nolint:paralleltest
@@ -213,6 +271,40 @@ func TestExcludeTestFiles(t *testing.T) { //nolint:paralleltest
 	}()
 }
 
+func TestStrictPkgs(t *testing.T) { //nolint:paralleltest
+	// We specifically do not set this test to be parallel such that this test is run separately
+	// from the parallel tests. This makes it possible to set the strict-pkgs and grouping flags
+	// for testing without affecting the other tests.
+	defaultGrouping := config.Analyzer.Flags.Lookup(config.GroupErrorMessagesFlag).Value.String()
+	defer func() {
+		require.NoError(t, config.Analyzer.Flags.Set(config.StrictPkgsFlag, ""))
+		require.NoError(t, config.Analyzer.Flags.Set(config.GroupErrorMessagesFlag, defaultGrouping))
+	}()
+	require.NoError(t, config.Analyzer.Flags.Set(config.StrictPkgsFlag, "go.uber.org/strictmode/flag"))
+	require.NoError(t, config.Analyzer.Flags.Set(config.GroupErrorMessagesFlag, "true"))
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, Analyzer, "go.uber.org/strictmode/directive", "go.uber.org/strictmode/flag")
+}
+
+func TestCategories(t *testing.T) { //nolint:paralleltest
+	// We specifically do not set this test to be parallel such that this test is run separately
+	// from the parallel tests. This makes it possible to set the category and grouping flags for
+	// testing without affecting the other tests.
+	defaultGrouping := config.Analyzer.Flags.Lookup(config.GroupErrorMessagesFlag).Value.String()
+	defer func() {
+		require.NoError(t, config.Analyzer.Flags.Set(config.DisableCategoriesFlag, ""))
+		require.NoError(t, config.Analyzer.Flags.Set(config.WarningCategoriesFlag, ""))
+		require.NoError(t, config.Analyzer.Flags.Set(config.GroupErrorMessagesFlag, defaultGrouping))
+	}()
+	require.NoError(t, config.Analyzer.Flags.Set(config.DisableCategoriesFlag, "map-access"))
+	require.NoError(t, config.Analyzer.Flags.Set(config.WarningCategoriesFlag, "deep-nilability"))
+	require.NoError(t, config.Analyzer.Flags.Set(config.GroupErrorMessagesFlag, "true"))
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, Analyzer, "go.uber.org/categories")
+}
+
 func TestPrintFullFilePath(t *testing.T) { //nolint:paralleltest
 	// We specifically do not set this test to be parallel such that this test is run separately
 	// from the parallel tests. This makes it possible to set the print-full-file-path flag to true for
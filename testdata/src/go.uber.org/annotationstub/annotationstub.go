@@ -0,0 +1,51 @@
+//  Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package annotationstub tests the stub files declaring the nilabilities of the packages outside
+// the analysis scope (see external.nilaway).
+package annotationstub
+
+import "go.uber.org/annotationstub/external" //want "Ignored annotation stub declaration at .*external.nilaway:46:1: function \"Removed\" not found"
+
+func find() string {
+	return external.Find("a").Name //want "(?s)NILABLE because it is annotated as so.*result 0 of `Find\\(\\)` accessed field `Name`"
+}
+
+func mustFind() string {
+	// Objects without stubs keep the default nilabilities.
+	return external.MustFind("a").Name
+}
+
+func checkedFind() string {
+	if item := external.Find("a"); item != nil {
+		return item.Name
+	}
+	return ""
+}
+
+func lookup() string {
+	return external.Default.Lookup("a").Name //want "result 0 of `Lookup\\(\\)` accessed field `Name`"
+}
+
+func parent(item *external.Item) string {
+	return item.Parent.Name //want "field `Parent` accessed field `Name`"
+}
+
+func owner(item *external.Item) string {
+	return item.Owner.Name
+}
+
+func next(s external.Source) string {
+	return s.Next().Name //want "result 0 of `Next\\(\\)` accessed field `Name`"
+}
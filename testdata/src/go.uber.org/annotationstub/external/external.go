@@ -0,0 +1,70 @@
+//  Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package external is a package outside the analysis scope, whose nilabilities are declared by
+// the stub file external.nilaway.
+package external
+
+// Item is an item of a registry.
+type Item struct {
+	Name   string
+	Parent *Item
+	Owner  *Item
+}
+
+// Registry is a registry of items.
+type Registry struct {
+	items map[string]*Item
+}
+
+// Default is the default registry, which is not initialized.
+var Default *Registry
+
+// Fallback is the fallback item.
+var Fallback = &Item{}
+
+// Find returns the item with the given name from the default registry.
+func Find(name string) *Item {
+	if Default == nil {
+		return nil
+	}
+	return Default.items[name]
+}
+
+// MustFind returns the item with the given name, or the fallback item.
+func MustFind(name string) *Item {
+	if item := Find(name); item != nil {
+		return item
+	}
+	return Fallback
+}
+
+// Lookup returns the item with the given name.
+func (r *Registry) Lookup(name string) *Item {
+	return r.items[name]
+}
+
+// Describe returns the name of the given item.
+func Describe(item *Item, prefixes ...*string) string {
+	s := item.Name
+	for _, p := range prefixes {
+		s = *p + s
+	}
+	return s
+}
+
+// Source produces items.
+type Source interface {
+	Next() *Item
+}
@@ -0,0 +1,38 @@
+//  Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package strict tests the stub files in strict (annotation-only) mode, where the stubbed
+// nilabilities are checked directly at the uses.
+//
+//nilaway:strict
+package strict
+
+import "go.uber.org/annotationstub/external" //want "Ignored annotation stub declaration at .*external.nilaway:46:1: function \"Removed\" not found"
+
+func find() string {
+	return external.Find("a").Name //want "result 0 of `Find\\(\\)` accessed field `Name`"
+}
+
+func mustFind() string {
+	return external.MustFind("a").Name
+}
+
+func describe(item *external.Item) string {
+	return external.Describe(nil) + //want "passed as arg `item` to `Describe\\(\\)`"
+		external.Describe(item, nil) //want "passed as arg `prefixes` to `Describe\\(\\)`"
+}
+
+func defaultRegistry() *external.Registry {
+	return external.Default //want "returned from `defaultRegistry\\(\\)`"
+}
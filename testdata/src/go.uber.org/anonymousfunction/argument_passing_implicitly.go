@@ -48,8 +48,8 @@ func testNilFlowFromClosure() {
 		print(*t) //want "literal `nil`"
 	}()
 
-	// TODO we will report an error here after updating the return type of function literals to include variables from closure
-	print(*t)
+	// The assignment in the function literal above is visible after the call.
+	print(*t) //want "captured variable `t` updated by function literal"
 
 	// test nested anonymous functions
 
@@ -0,0 +1,194 @@
+//  Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// This package aims to test that the assignments of function literals to captured variables are
+// visible in the enclosing function after the calls that run the literals synchronously, and are
+// merged with the values before the calls that may not run the literals.
+package anonymousfunction
+
+import (
+	"sort"
+	"sync"
+
+	"stubs/golang.org/x/sync/errgroup"
+)
+
+type closureT struct {
+	f *int
+}
+
+func newClosureT() *closureT {
+	return &closureT{f: new(int)}
+}
+
+func testDirectInvocation() {
+	var x *closureT
+	func() {
+		x = newClosureT()
+	}()
+	print(x.f)
+
+	var y *closureT
+	init := func() {
+		y = newClosureT()
+	}
+	init()
+	print(y.f)
+
+	z := newClosureT()
+	func() {
+		z = nil
+	}()
+	print(z.f) //want "captured variable `z` updated by function literal"
+}
+
+func testConditionalAssignment(cond bool) {
+	var x *closureT
+	func() {
+		if cond {
+			x = newClosureT()
+		}
+	}()
+	print(x.f) //want "unassigned variable `x`"
+
+	y := newClosureT()
+	func() {
+		if cond {
+			y = newClosureT()
+		}
+	}()
+	print(y.f)
+}
+
+func testNestedFuncLit() {
+	var x *closureT
+	func() {
+		func() {
+			x = newClosureT()
+		}()
+	}()
+	print(x.f)
+
+	var y *closureT
+	func() {
+		go func() {
+			y = newClosureT()
+		}()
+	}()
+	print(y.f) //want "unassigned variable `y`"
+}
+
+var _once sync.Once
+
+func testOnceDo() {
+	var x *closureT
+	_once.Do(func() {
+		x = newClosureT()
+	})
+	// `_once.Do` does not run the function literal if it has been called before.
+	print(x.f) //want "unassigned variable `x`"
+
+	y := newClosureT()
+	_once.Do(func() {
+		y = nil
+	})
+	print(y.f) //want "captured variable `y` updated by function literal"
+}
+
+func testSortSlice(s []int) {
+	var x *int
+	sort.Slice(s, func(i, j int) bool {
+		if s[i] > 0 {
+			x = &s[i]
+		}
+		return s[i] < s[j]
+	})
+	print(*x) //want "unassigned variable `x`" "captured variable `x` updated by function literal"
+}
+
+func testErrGroup() {
+	var g errgroup.Group
+	var x *closureT
+	g.Go(func() error {
+		x = newClosureT()
+		return nil
+	})
+	if err := g.Wait(); err != nil {
+		return
+	}
+	// `g.Go` runs the function literal asynchronously, so it is not modeled.
+	print(x.f) //want "unassigned variable `x`"
+}
+
+func run(f func()) {
+	f()
+}
+
+func maybeRun(f func(), cond bool) {
+	if cond {
+		f()
+	}
+}
+
+var _stored func()
+
+func store(f func()) {
+	_stored = f
+}
+
+func testInPackageCaller() {
+	var x *closureT
+	run(func() {
+		x = newClosureT()
+	})
+	print(x.f)
+
+	var y *closureT
+	store(func() {
+		y = newClosureT()
+	})
+	print(y.f) //want "unassigned variable `y`"
+
+	var z *closureT
+	maybeRun(func() {
+		z = newClosureT()
+	}, true)
+	print(z.f) //want "unassigned variable `z`"
+
+	u := newClosureT()
+	maybeRun(func() {
+		u = newClosureT()
+	}, true)
+	print(u.f)
+
+	v := newClosureT()
+	maybeRun(func() {
+		v = nil
+	}, true)
+	print(v.f) //want "captured variable `v` updated by function literal"
+}
+
+func testAsyncCalls() {
+	var x *closureT
+	go func() {
+		x = newClosureT()
+	}()
+	print(x.f) //want "unassigned variable `x`"
+
+	var y *closureT
+	go run(func() {
+		y = newClosureT()
+	})
+	print(y.f) //want "unassigned variable `y`"
+}
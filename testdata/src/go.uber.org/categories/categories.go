@@ -0,0 +1,63 @@
+//  Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package categories tests the per-category configuration of the diagnostics: the "map-access"
+// category is disabled and the "deep-nilability" category is reported as warnings, while the other
+// categories are reported as errors as usual.
+package categories
+
+import "errors"
+
+var dummy bool
+
+func dereference() int {
+	var p *int
+	return *p //want "Potential nil panic detected \\[dereference\\]"
+}
+
+func errorReturn() (*int, error) {
+	var err error
+	if dummy {
+		err = errors.New("some error")
+	}
+	return nil, err
+}
+
+func useErrorReturn() {
+	if x, err := errorReturn(); err == nil {
+		// The nil value flows through the error-return contract violation to the dereference.
+		_ = *x //want "Potential nil panic detected \\[error-return\\]"
+	}
+}
+
+func mapAccess() {
+	var m map[string]int
+	// No error here since the category is disabled.
+	m["a"] = 1
+}
+
+// nonnil(<-c)
+func deepNilability(c chan *int) { //want "warning: Potential nil panic detected \\[deep-nilability\\]"
+	c <- nil
+}
+
+// nonnil(<-c)
+func grouping(c chan *int) { //want "warning: Potential nil panic detected \\[deep-nilability\\]"
+	var p *int
+	if dummy {
+		// The warnings are not grouped with the errors of the same nil source.
+		_ = *p //want "Potential nil panic detected \\[dereference\\]"
+	}
+	c <- p
+}
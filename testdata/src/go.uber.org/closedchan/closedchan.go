@@ -0,0 +1,103 @@
+//  Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package closedchan tests the handling of channels closed in the same function: receives on them
+// yield nil for nilable element types, and sends on them panic.
+package closedchan
+
+func recvAfterClose(ch chan *int) int {
+	close(ch)
+	v := <-ch
+	return *v //want "received from channel `ch` closed at line 20"
+}
+
+func recvDerefAfterClose(ch chan *int) int {
+	close(ch)
+	return *(<-ch) //want "received from channel `ch` closed at line"
+}
+
+func recvBeforeClose(ch chan *int) int {
+	v := <-ch
+	close(ch)
+	return *v
+}
+
+func recvAfterConditionalClose(ch chan *int, b bool) int {
+	if b {
+		close(ch)
+	}
+	v := <-ch
+	return *v //want "received from channel `ch` closed at line"
+}
+
+func recvAfterReassign(ch chan *int) int {
+	close(ch)
+	ch = make(chan *int, 1)
+	v := <-ch
+	return *v
+}
+
+func recvOkAfterClose(ch chan *int) int {
+	close(ch)
+	if v, ok := <-ch; ok {
+		return *v
+	}
+	return 0
+}
+
+func rangeAfterClose(ch chan *int) int {
+	close(ch)
+	s := 0
+	for v := range ch {
+		s += *v
+	}
+	return s
+}
+
+func recvNonNilableAfterClose(ch chan int) int {
+	close(ch)
+	return <-ch
+}
+
+func recvGuardedAfterClose(ch chan *int) int {
+	close(ch)
+	if v := <-ch; v != nil {
+		return *v
+	}
+	return 0
+}
+
+func sendAfterClose(ch chan *int) {
+	close(ch)
+	ch <- new(int) //want "channel `ch` closed at line .* sent to"
+}
+
+func sendAfterCloseInLoop(ch chan int, n int) {
+	for i := 0; i < n; i++ {
+		ch <- i //want "sent to"
+		if i == n/2 {
+			close(ch)
+		}
+	}
+}
+
+func sendBeforeClose(ch chan int) {
+	ch <- 1
+	close(ch)
+}
+
+func deferredClose(ch chan int) {
+	defer close(ch)
+	ch <- 1
+}
@@ -261,8 +261,6 @@ func TestAssignmentToNonPointerTypes(i int, v Value[V]) {
 }
 
 // Test generic functions with "ok" form
-// TODO: Note that currently we do not support generics in NilAway. Hence, the below cases are expected to not report
-//  errors since we have put a suppresion in place for generic rich-check-effect functions' error reporting.
 
 func GenericFunc[T any]() (*T, bool) {
 	var resp T
@@ -292,8 +290,7 @@ func TestGenericFunc(s string) {
 
 	case "conditionally unsafe":
 		if v, ok := GenericFunc[int](); !ok {
-			// TODO: This is a false negative since we do not support generics yet.
-			print(*v) // "dereferenced"
+			print(*v) //want "lacking guarding"
 		}
 
 	case "always safe":
@@ -305,8 +302,10 @@ func TestGenericFunc(s string) {
 
 	case "always unsafe":
 		if v, ok := GenericFuncAlwaysUnsafe[int](); ok {
-			// TODO: This is a false negative since we do not support generics yet.
-			print(*v) // "dereferenced"
+			// safe since `nil` is only returned along with `false`
+			print(*v)
 		}
+		v, _ := GenericFuncAlwaysUnsafe[int]()
+		print(*v) //want "lacking guarding"
 	}
 }
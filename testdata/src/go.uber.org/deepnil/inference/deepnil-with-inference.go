@@ -196,9 +196,7 @@ func testDeepLocalInterprocedural(i int) {
 	case 10:
 		a := &A{}
 		a.f = nil
-		// TODO: Error should be reported on the line below. It is currently not reported because of the suppression of
-		//  struct field assignment logic that we added until we add object sensitivity for precise handling (issue #339).
-		_ = *deepLocalReturn10(a)[0] // "deep read from result 0 of `deepLocalReturn10.*` dereferenced"
+		_ = *deepLocalReturn10(a)[0] //want "deep read from result 0 of `deepLocalReturn10.*` dereferenced"
 	}
 }
 
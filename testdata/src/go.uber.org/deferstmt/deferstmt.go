@@ -0,0 +1,240 @@
+//  Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package deferstmt tests the handling of deferred calls, which run at every exit of the function
+// after the named results are assigned, and of `recover`, which stops the panics of the function.
+package deferstmt
+
+func readNamedResultAfterNilReturn() (r *int) {
+	defer func() {
+		print(*r) //want "dereferenced"
+	}()
+	return nil
+}
+
+func readNamedResultAfterNonnilReturn() (r *int) {
+	defer func() {
+		print(*r)
+	}()
+	return new(int)
+}
+
+func readNamedResultOnSomePaths(b bool) (r *int) {
+	defer func() {
+		print(*r) //want "dereferenced"
+	}()
+	if b {
+		return new(int)
+	}
+	return nil
+}
+
+func readNamedResultAfterBareReturn() (r *int) {
+	defer func() {
+		print(*r) //want "dereferenced"
+	}()
+	return
+}
+
+func guardedNamedResult() (r *int) {
+	defer func() {
+		if r != nil {
+			print(*r)
+		}
+	}()
+	return nil
+}
+
+func lastDeferredRunsFirst() (r *int) {
+	defer func() {
+		print(*r)
+	}()
+	defer func() {
+		r = new(int)
+	}()
+	return nil
+}
+
+func firstDeferredRunsLast() (r *int) {
+	defer func() {
+		r = new(int)
+	}()
+	defer func() {
+		print(*r) //want "dereferenced"
+	}()
+	return nil
+}
+
+// The named results are consumed after the deferred function literals, which may modify them.
+func resetNamedResultInDefer() (r *int) {
+	defer func() {
+		r = nil
+	}()
+	return new(int)
+}
+
+func setNamedResultInDefer() (r *int) {
+	defer func() {
+		r = new(int)
+	}()
+	return nil
+}
+
+func callModifiedNamedResults() {
+	print(*resetNamedResultInDefer()) //want "literal `nil` returned .* via named return `r`"
+	print(*setNamedResultInDefer())
+}
+
+// The function literals deferred conditionally may not run, so they do not modify the results.
+func setNamedResultInConditionalDefer(b bool) (r *int) {
+	if b {
+		defer func() {
+			r = new(int)
+		}()
+	}
+	return nil
+}
+
+// The function literals deferred after an early return do not run at that return.
+func setNamedResultAfterEarlyReturn(b bool) (r *int) {
+	if b {
+		return nil
+	}
+	defer func() {
+		r = new(int)
+	}()
+	return nil
+}
+
+// The bodies of the function literals deferred conditionally are still checked.
+func derefInConditionalDefer(b bool) {
+	if b {
+		defer func() {
+			var p *int
+			print(*p) //want "dereferenced"
+		}()
+	}
+}
+
+func callConditionallyModifiedNamedResults() {
+	print(*setNamedResultInConditionalDefer(false)) //want "dereferenced"
+	print(*setNamedResultAfterEarlyReturn(true))    //want "dereferenced"
+}
+
+func clearErrorInDefer() (r *int, err error) {
+	defer func() {
+		err = nil
+	}()
+	return nil, errNotFound()
+}
+
+func keepErrorInDefer() (r *int, err error) {
+	defer func() {
+		print("")
+	}()
+	return nil, errNotFound()
+}
+
+func callModifiedErrorResults() {
+	if r, err := clearErrorInDefer(); err == nil {
+		print(*r) //want "dereferenced"
+	}
+	if r, err := keepErrorInDefer(); err == nil {
+		print(*r)
+	}
+}
+
+func errNotFound() error {
+	return &notFoundError{}
+}
+
+type notFoundError struct{}
+
+func (*notFoundError) Error() string {
+	return "not found"
+}
+
+// The returned expressions are evaluated only once, before the deferred function literals.
+func derefInReturnWithDefer(p *int) (r int) {
+	defer func() {
+		print(r)
+	}()
+	return *p //want "passed"
+}
+
+func callDerefInReturnWithDefer() {
+	derefInReturnWithDefer(nil)
+}
+
+type lock struct {
+	held bool
+}
+
+func (l *lock) unlock() {
+	l.held = false //want "used as receiver"
+}
+
+func deferredCallEvaluatedAtDefer() {
+	var l *lock
+	defer l.unlock()
+	print("")
+}
+
+func deferredCallWithArgs() {
+	var p *int
+	defer takesNonnil(p)
+	print("")
+}
+
+func takesNonnil(p *int) {
+	print(*p) //want "unassigned variable `p` passed"
+}
+
+func recoversFromPanics(p *int) (ok bool) {
+	defer func() {
+		if recover() != nil {
+			ok = false
+		}
+	}()
+	print(*p)
+	return true
+}
+
+func callRecoversFromPanics() {
+	recoversFromPanics(nil)
+}
+
+func recoversInNestedFuncLit(p *int) {
+	defer func() {
+		func() {
+			recover()
+		}()
+	}()
+	print(*p) //want "passed"
+}
+
+func callRecoversInNestedFuncLit() {
+	recoversInNestedFuncLit(nil)
+}
+
+func derefInsideRecoveringFuncLit(p *int) {
+	defer func() {
+		recover()
+		print(*p) //want "passed"
+	}()
+}
+
+func callDerefInsideRecoveringFuncLit() {
+	derefInsideRecoveringFuncLit(nil)
+}
@@ -644,9 +644,6 @@ func callTestErrorWrapper(i int) {
 }
 
 // The below test checks for error returning functions that are named anonymous functions.
-// Note that until we make anonymous function support mainstream, we resort to suppressing the errors, which means
-// we don't report false positives, but we also don't report true positives.
-// TODO: remove this test once we have support for anonymous functions since similar, but more comprehensive tests are in the testdata/src/go.uber.org/anonymousfunction directory.
 func testNamedAnonErrReturningFunc(i int) {
 	f1 := func() (*int, error) {
 		if dummy2 {
@@ -679,8 +676,7 @@ func testNamedAnonErrReturningFunc(i int) {
 
 	case 2:
 		if x2, err2 := f1(); err2 != nil {
-			// error expected, but a false negative for the reason explained above
-			_ = *x2
+			_ = *x2 //want "dereferenced"
 		}
 
 	case 3:
@@ -695,15 +691,12 @@ func testNamedAnonErrReturningFunc(i int) {
 		if err != nil {
 			return
 		}
-		// false negative: unsafe since f3() always returns a nil value
-		_ = *x
+		// unsafe since f3() always returns a nil value
+		_ = *x //want "dereferenced"
 	}
 }
 
 // The below test checks for error returning functions that are unnamed anonymous functions.
-// Note that until we make anonymous function support mainstream, we resort to suppressing the errors, which means
-// we don't report false positives, but we also don't report true positives.
-// TODO: remove this test once we have support for anonymous functions since similar, but more comprehensive tests are in the testdata/src/go.uber.org/anonymousfunction directory.
 func testUnnamedAnonErrReturningFunc(i int) {
 	switch i {
 	case 1:
@@ -725,8 +718,7 @@ func testUnnamedAnonErrReturningFunc(i int) {
 			}
 			return new(int), nil
 		}(); err2 != nil {
-			// error expected, but a false negative for the reason explained above
-			_ = *x2
+			_ = *x2 //want "dereferenced"
 		}
 
 	case 3:
@@ -751,14 +743,12 @@ func testUnnamedAnonErrReturningFunc(i int) {
 		if err != nil {
 			return
 		}
-		// false negative: unsafe since the anonymous function always returns a nil value
-		_ = *x
+		// unsafe since the anonymous function always returns a nil value
+		_ = *x //want "dereferenced"
 	}
 }
 
 // The below test checks for error returning functions that are type aliases.
-// Note that until we implement complete support for type aliases, we resort to suppressing the errors, which means
-// we don't report false positives, but we also don't report true positives.
 
 type MyErrRetFunc = func() (*int, error)
 
@@ -767,7 +757,8 @@ func callTypeAliasFunc(f MyErrRetFunc) {
 	if err != nil {
 		return
 	}
-	_ = *x
+	// unsafe since the functions passed in cases 3 and 6 below always return a nil value
+	_ = *x //want "returned from `namedRetPtrAndErrAlwaysUnsafe\\(\\)`" "returned from `__anonymousFunction.*`"
 }
 
 func namedRetPtrAndErr() (*int, error) {
@@ -797,7 +788,6 @@ func testTypeAliasErrReturningFunc(i int) {
 		callTypeAliasFunc(namedRetPtrAndErrAlwaysSafe)
 
 	case 3:
-		// TODO: this is a false negative since the function always returns a nil value.
 		callTypeAliasFunc(namedRetPtrAndErrAlwaysUnsafe)
 
 	case 4:
@@ -817,7 +807,6 @@ func testTypeAliasErrReturningFunc(i int) {
 		})
 
 	case 6:
-		// TODO: this is a false negative since the function always returns a nil value.
 		callTypeAliasFunc(func() (*int, error) {
 			return nil, nil
 		})
@@ -863,7 +852,8 @@ func functionValueAsParam(f func() (*int, error)) {
 	if err != nil {
 		return
 	}
-	_ = *v
+	// unsafe since the anonymous function passed in case 6 below always returns a nil value
+	_ = *v //want "dereferenced"
 }
 
 func testFunctionValue(i int) {
@@ -890,8 +880,8 @@ func testFunctionValue(i int) {
 		if err != nil {
 			return
 		}
-		// TODO: this is a false negative since the function always returns a nil value.
-		_ = *v
+		// unsafe since the anonymous function stored by newAAlwaysUnsafe always returns a nil value
+		_ = *v //want "dereferenced"
 
 	case 4:
 		functionValueAsParam(func() (*int, error) {
@@ -910,7 +900,6 @@ func testFunctionValue(i int) {
 		})
 
 	case 6:
-		// TODO: this is a false negative since the function always returns a nil value.
 		functionValueAsParam(func() (*int, error) {
 			return nil, nil
 		})
@@ -1028,8 +1017,6 @@ func TestAssignmentToNonPointerTypes(i int, v Value[V]) {
 }
 
 // Test generic error returning functions
-// TODO: Note that currently we do not support generics in NilAway. Hence, the below cases are expected to not report
-//  errors since we have put a suppresion in place for generic rich-check-effect functions' error reporting.
 
 func GenericFunc[T any]() (*T, error) {
 	var resp T
@@ -1059,8 +1046,7 @@ func TestGenericFunc(s string) {
 
 	case "conditionally unsafe":
 		if v, err := GenericFunc[int](); err != nil {
-			// TODO: This is a false negative since we do not support generics yet.
-			print(*v) // "dereferenced"
+			print(*v) //want "lacking guarding"
 		}
 
 	case "always safe":
@@ -1072,8 +1058,7 @@ func TestGenericFunc(s string) {
 
 	case "always unsafe":
 		if v, err := GenericFuncAlwaysUnsafe[int](); err == nil {
-			// TODO: This is a false negative since we do not support generics yet.
-			print(*v) // "dereferenced"
+			print(*v) //want "literal `nil` returned"
 		}
 	}
 }
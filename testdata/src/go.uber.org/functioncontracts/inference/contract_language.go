@@ -0,0 +1,136 @@
+//  Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package inference
+
+// This file tests the richer forms of hand-written contracts in full inference mode.
+
+import (
+	"errors"
+	"math/rand"
+	"unsafe"
+)
+
+// Test a contract with multiple parameters: the result is nonnil if both arguments are nonnil.
+// contract(nonnil, nonnil -> nonnil)
+func join(a, b *int) *int {
+	if a == nil || b == nil {
+		return nil
+	}
+	v := *a + *b
+	return &v
+}
+
+func useJoin() {
+	print(*join(new(int), new(int))) // No error here due to the contract.
+
+	var b *int
+	print(*join(new(int), b)) // want "result 0 of `join.*` .* dereferenced"
+}
+
+var _cache = map[string]*int{}
+
+// Test a contract with an unconditional output: the result is always nonnil.
+// contract(_ -> nonnil)
+func lookup(key string) *int {
+	return _cache[key]
+}
+
+func useLookup() {
+	print(*lookup("key")) // No error here due to the contract.
+}
+
+// Test a contract with nil outputs, which is not visible from the function body.
+// contract(nil -> nil)
+// contract(nonnil -> nonnil)
+func convert(p *int) *int64 {
+	return (*int64)(unsafe.Pointer(p))
+}
+
+func useConvert() {
+	print(*convert(new(int))) // No error here due to the contract.
+
+	var p *int
+	print(*convert(p)) // want "result 0 of `convert.*` .* dereferenced"
+}
+
+// Test an error-aware contract: the result is nonnil whenever the error is nil.
+// contract(_ -> nonnil, nil)
+func find(key string) (*int, error) {
+	if key == "" {
+		return nil, errors.New("empty key")
+	}
+	return _cache[key], nil
+}
+
+func useFind() {
+	v, err := find("key")
+	if err != nil {
+		return
+	}
+	print(*v) // No error here due to the contract.
+}
+
+// Test contracts with boolean outputs, which refine the conditionals on the calls: the result
+// being true implies that the argument is nonnil.
+// contract(nil -> false)
+func isValid(p *int) bool {
+	return p != nil && *p > 0
+}
+
+// contract(nil, _ -> false)
+// contract(_, nil -> false)
+func both(a, b *int) bool {
+	return a != nil && b != nil
+}
+
+// contract(nonnil, _ -> true)
+func either(a, b *int) bool {
+	return a != nil || b != nil
+}
+
+func useBooleanContracts() {
+	var p, q *int
+	if rand.Float64() > 0.5 {
+		p, q = new(int), new(int)
+	}
+
+	if isValid(p) {
+		print(*p) // No error here due to the contract.
+	}
+	if !isValid(p) {
+		print(*p) // want "dereferenced"
+	}
+	if ok := both(p, q); ok {
+		print(*p + *q) // No error here due to the contracts.
+	}
+	if !either(p, q) {
+		return
+	}
+	print(*q) // want "dereferenced"
+}
+
+// Test that invalid contracts are reported and ignored.
+/* want "Ignored invalid function contract `contract\\(nonil -> nonnil\\)`: unknown keyword" */ // contract(nonil -> nonnil)
+func invalidKeyword(p *int) *int {
+	return p
+}
+
+/* want "Ignored invalid function contract .* expected 2 input value" */ // contract(nonnil -> nonnil)
+func invalidArity(p, q *int) *int {
+	if rand.Float64() > 0.5 {
+		return p
+	}
+	return q
+}
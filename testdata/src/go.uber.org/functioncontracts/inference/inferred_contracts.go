@@ -0,0 +1,106 @@
+//  Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// This file tests the contracts beyond contract(nonnil -> nonnil) inferred in full inference mode.
+
+package inference
+
+import "math/rand"
+
+// contract(nonnil, _ -> nonnil) and contract(_, nonnil -> nonnil) are inferred.
+func firstNonnil(a, b *int) *int {
+	if a != nil {
+		return a
+	}
+	return b
+}
+
+func useFirstNonnil() {
+	var p *int
+	print(*firstNonnil(new(int), p)) // No error here due to the contract.
+	print(*firstNonnil(p, new(int))) // No error here due to the contract.
+	print(*firstNonnil(p, p))        // want "result 0 of `firstNonnil.*` .* dereferenced"
+}
+
+// contract(nonnil, nonnil -> nonnil) is inferred.
+func joinNonnil(a, b *int) *int {
+	if a == nil || b == nil {
+		return nil
+	}
+	sum := *a + *b
+	return &sum
+}
+
+func useJoinNonnil() {
+	var p *int
+	print(*joinNonnil(new(int), new(int))) // No error here due to the contract.
+	print(*joinNonnil(new(int), p))        // want "result 0 of `joinNonnil.*` .* dereferenced"
+}
+
+// contract(nonnil, _ -> nonnil) is inferred, the nil factor leads to a panic instead of a return.
+func scale(x *int, factor *int) *int {
+	if factor == nil {
+		panic("nil factor")
+	}
+	if x == nil {
+		return nil
+	}
+	y := *x * *factor
+	return &y
+}
+
+func useScale() {
+	n := 2
+	print(*scale(&n, &n)) // No error here due to the contract.
+
+	var p *int
+	print(*scale(p, &n)) // want "result 0 of `scale.*` .* dereferenced"
+}
+
+// contract(nil -> false) is inferred, which refines the conditionals on the calls.
+func isPositive(p *int) bool {
+	if p == nil {
+		return false
+	}
+	return *p > 0
+}
+
+func useIsPositive() {
+	var p *int
+	if rand.Float64() > 0.5 {
+		p = new(int)
+	}
+	if isPositive(p) {
+		print(*p) // No error here due to the contract.
+	}
+}
+
+var _okStore map[string]*int
+
+// contract(_ -> nonnil, true) is inferred: the result is nonnil whenever ok is true.
+func lookupOk(key string) (*int, bool) {
+	v := _okStore[key]
+	if v == nil {
+		return nil, false
+	}
+	return v, true
+}
+
+func useLookupOk() {
+	if v, ok := lookupOk("key"); ok {
+		print(*v) // No error here.
+	}
+	v, _ := lookupOk("key")
+	print(*v) // want "result 0 of `lookupOk.*` .* lacking guarding"
+}
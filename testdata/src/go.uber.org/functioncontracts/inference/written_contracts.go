@@ -18,9 +18,9 @@ package inference
 
 import "math/rand"
 
-// We set an incorrect manual contract here, but NilAway should still respect it.
-// TODO: NilAway should validate and warn users of incorrect manual contract annotations.
-// contract(nonnil -> nonnil)
+// We set an incorrect manual contract here. NilAway warns about the violating return statement at
+// the contract, but still respects it.
+/* want "Function contract `contract\\(nonnil -> nonnil\\)` is violated by the return statement at .*written_contracts.go:27:3: result 0 is nil while parameter `x` is nonnil" */ // contract(nonnil -> nonnil)
 func incorrectContract(x *int) *int {
 	if x != nil {
 		// Returns nil if the input is nonnil, violating the contract.
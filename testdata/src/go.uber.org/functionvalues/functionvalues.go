@@ -0,0 +1,178 @@
+//  Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package functionvalues tests the handling of calls to function values, i.e., calls through
+// variables, struct fields and parameters of function type, whose possible targets are resolved
+// within the package.
+package functionvalues
+
+import "errors"
+
+var dummy bool
+
+func retNil() *int {
+	return nil
+}
+
+func retNonNil() *int {
+	return new(int)
+}
+
+func derefParam(x *int) int {
+	return *x //want "passed as arg `x` to `derefParam\\(\\)`"
+}
+
+func localSingleTarget() int {
+	f := retNil
+	return *f() //want "result 0 of `retNil\\(\\)`"
+}
+
+func localMultipleTargets() int {
+	f := retNonNil
+	if dummy {
+		f = retNil
+	}
+	return *f() //want "returned via function value `f\\(\\)`"
+}
+
+func localNonNilTargets() int {
+	f := retNonNil
+	if dummy {
+		f = func() *int { return new(int) }
+	}
+	return *f()
+}
+
+func argToLocal() {
+	f := derefParam
+	f(nil)
+}
+
+type handlers struct {
+	onEvent func() *int
+}
+
+func newHandlers() *handlers {
+	h := &handlers{onEvent: retNonNil}
+	if dummy {
+		h.onEvent = retNil
+	}
+	return h
+}
+
+func fieldTargets(h *handlers) int {
+	return *h.onEvent() //want "returned via function value `onEvent\\(\\)`"
+}
+
+func apply(f func() *int) int {
+	return *f() //want "result 0 of `retNil\\(\\)`"
+}
+
+func callApply() {
+	apply(retNil)
+}
+
+// Apply is exported, hence the targets of `f` cannot be resolved within the package, and its
+// results are trusted to be nonnil.
+func Apply(f func() *int) int {
+	return *f()
+}
+
+// Opts has an exported field of function type, whose targets are resolved from the composite
+// literals passed by the callers of the unexported functions.
+type Opts struct {
+	OnError func(error) *int
+}
+
+func retNonNilOnError(error) *int {
+	return new(int)
+}
+
+func retNilOnError(error) *int {
+	return nil
+}
+
+func useOpts(o Opts) int {
+	return *o.OnError(nil)
+}
+
+func useOptsNil(o Opts) int {
+	return *o.OnError(nil) //want "result 0 of `retNilOnError\\(\\)`"
+}
+
+func callUseOpts() {
+	useOpts(Opts{OnError: retNonNilOnError})
+	useOptsNil(Opts{OnError: retNilOnError})
+}
+
+// ApplyErr is exported, but the results of the unresolved targets are guarded by the error.
+func ApplyErr(f func() (*int, error)) int {
+	x, err := f()
+	if err != nil {
+		return 0
+	}
+	return *x
+}
+
+// ApplyUnguarded is exported, and the results of the unresolved targets are not guarded.
+func ApplyUnguarded(f func() (*int, error)) int {
+	x, _ := f()
+	return *x //want "with unresolved targets.*lacking guarding"
+}
+
+type store struct {
+	v *int
+}
+
+func (s *store) get() *int {
+	if dummy {
+		return nil
+	}
+	return s.v
+}
+
+func methodValue() int {
+	s := &store{}
+	get := s.get
+	return *get() //want "result 0 of `get\\(\\)`"
+}
+
+func lookup(name string) func() (*int, error) {
+	if name == "" {
+		return nil
+	}
+	return func() (*int, error) { return nil, errors.New("not found") }
+}
+
+func resultOfCall(name string) int {
+	handler := lookup(name)
+	x, err := handler()
+	if err != nil {
+		return 0
+	}
+	return *x
+}
+
+func localFuncLitAndDeclared() int {
+	f := retNonNil
+	if dummy {
+		f = func() *int { return nil }
+	}
+	return *f() //want "returned via function value `f\\(\\)`"
+}
+
+func localOnlyFuncLit() int {
+	f := func() *int { return nil }
+	return *f() //want "result 0 of `__anonymousFunction.*` dereferenced"
+}
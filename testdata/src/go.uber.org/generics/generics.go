@@ -12,10 +12,9 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-// generics package tests NilAway's ability to handle generics introduced in Go 1.18.
-// Currently, we do not have support for generics yet, so this package simply tests that
-// NilAway should not panic when seeing ASTs related to generics.
-// TODO: Add support for generics.
+// generics package tests NilAway's ability to handle generics introduced in Go 1.18. This package
+// mostly tests that NilAway does not panic when seeing ASTs related to generics, see the inference
+// subpackage for the tests of nil flows through generic functions and types.
 //
 // <nilaway no inference>
 package generics
@@ -113,9 +112,8 @@ func defineInFunc(p1 any, p2 fooT1) {
 // Test for a case where we have a generic slice.
 func GenericSlice[S ~[]*E, E any](s S) int {
 	for _, element := range s {
-		// TODO: we do not really handle generics for now, so the elements inside any
-		//  generic slices are considered nonnil. We should handle that properly.
-		print(*element) // False negative.
+		// Similar to non-generic slices, the elements of `s` are deeply nonnil by default.
+		print(*element)
 	}
 	return -1
 }
@@ -0,0 +1,139 @@
+//  Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+These tests check the support for generics in full inference mode: the nilability of values of
+type parameters is only considered at instantiations with nilable type arguments.
+*/
+
+package inference
+
+var dummy bool
+
+// Get is a generic comma-ok function, whose first result is guarded by its second result.
+func Get[K comparable, V any](m map[K]*V, k K) (*V, bool) {
+	v, ok := m[k]
+	if !ok {
+		return nil, false
+	}
+	return v, true
+}
+
+func testGet(m map[string]*int) {
+	if v, ok := Get(m, "a"); ok {
+		print(*v)
+	}
+	v, ok := Get[string, int](m, "b")
+	if !ok {
+		return
+	}
+	print(*v)
+
+	v2, _ := Get(m, "c")
+	print(*v2) //want "lacking guarding"
+	v3, _ := Get[string, int](m, "d")
+	print(*v3) //want "lacking guarding"
+}
+
+func Zero[T any]() T {
+	var zero T
+	return zero
+}
+
+func Id[T any](x T) T {
+	return x
+}
+
+func testInstantiations() {
+	print(*Zero[*int]()) //want "unassigned variable `zero` returned"
+	print(Zero[int]() + 1)
+	print(Zero[string]() + "")
+
+	print(*Id[*int](nil)) //want "literal `nil` passed"
+	print(*Id(new(int)))
+	print(Id(1) + 1)
+}
+
+// PtrTo is a constraint whose type set only contains pointers to T.
+type PtrTo[T any] interface {
+	~*T
+}
+
+func Deref[P PtrTo[T], T any](p P) T {
+	return *p //want "literal `nil` passed"
+}
+
+func testDeref() {
+	i := 1
+	print(Deref(&i))
+	print(Deref[*int](nil))
+}
+
+// Stack is a generic container.
+type Stack[T any] struct {
+	items []T
+}
+
+func (s *Stack[T]) Push(v T) {
+	s.items = append(s.items, v)
+}
+
+func (s *Stack[T]) Pop() (T, bool) {
+	var zero T
+	if len(s.items) == 0 {
+		return zero, false
+	}
+	v := s.items[len(s.items)-1]
+	s.items = s.items[:len(s.items)-1]
+	return v, true
+}
+
+func testStack() {
+	s := &Stack[*int]{}
+	s.Push(new(int))
+	if v, ok := s.Pop(); ok {
+		print(*v)
+	}
+	v, _ := s.Pop()
+	print(*v) //want "lacking guarding"
+
+	ints := &Stack[int]{}
+	n, _ := ints.Pop()
+	print(n + 1)
+}
+
+// GenericSlice ranges over a type parameter whose core type is a slice.
+func GenericSlice[S ~[]*E, E any](s S) {
+	for _, e := range s {
+		print(*e) //want "deeply"
+	}
+	for i := range s {
+		print(i + 1)
+	}
+}
+
+func testGenericSlice() {
+	s := []*int{new(int)}
+	s[0] = nil
+	GenericSlice(s)
+}
+
+// GenericMap reads from a type parameter whose core type is a map.
+func GenericMap[M ~map[K]*V, K comparable, V any](m M, k K) {
+	if v, ok := m[k]; ok {
+		print(*v)
+	}
+	v := m[k]
+	print(*v) //want "lacking guarding"
+}
@@ -0,0 +1,157 @@
+//  Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+These tests check that the assignments in the init() functions initialize the global variables
+
+<nilaway no inference>
+*/
+package globalvars
+
+type logger struct {
+	name string
+}
+
+func cond() bool {
+	return true
+}
+
+// The variable is read before it is assigned in the init() function.
+var readEarly *logger
+
+// The variables may be assigned by the calls, or through their addresses, before they are read.
+var setupLogger *logger       //want "assigned into global variable"
+var unmarshaledLogger *logger //want "assigned into global variable"
+var addrLogger *logger        //want "assigned into global variable"
+var ptrLogger *logger         //want "assigned into global variable"
+
+func setup() {
+	setupLogger = &logger{}
+}
+
+func unmarshal(l **logger) {
+	*l = &logger{}
+}
+
+func init() {
+	print(readEarly.name) //want "unassigned variable `readEarly`"
+	readEarly = &logger{}
+	print(readEarly.name)
+
+	print(ptrLogger.name) //want "unassigned variable `ptrLogger`"
+	p := &ptrLogger
+	*p = &logger{}
+	print(ptrLogger.name)
+
+	print(setupLogger.name, unmarshaledLogger.name) //want "unassigned variable `setupLogger`" "unassigned variable `unmarshaledLogger`"
+	setup()
+	print(setupLogger.name)
+	unmarshal(&unmarshaledLogger)
+	print(unmarshaledLogger.name)
+}
+
+// The init() functions run before any other function, so the variables assigned there on all
+// paths are initialized.
+var initLogger *logger
+var initLoggerMulti, initErr *logger
+
+// Conditional assignments do not initialize the variable on all paths.
+var condLogger *logger //want "assigned into global variable"
+
+// The value assigned in the init() function is checked instead.
+var nilLogger *logger
+
+// nilable(result 1)
+func newLoggers() (*logger, *logger) {
+	return &logger{}, nil
+}
+
+func init() {
+	initLogger = &logger{}
+	initLoggerMulti, initErr = newLoggers() //want "assigned into global variable"
+	if cond() {
+		condLogger = &logger{}
+	}
+	nilLogger = nil //want "assigned into global variable"
+}
+
+func init() {
+	// These are assigned in the previous init() function, which runs before this one.
+	print(initLogger.name)
+	print(condLogger.name)
+
+	// The calls in the previous init() function may assign any variable.
+	print(addrLogger.name)
+}
+
+// The assignment after an early return does not initialize the variable on all paths.
+var afterReturn *logger //want "assigned into global variable"
+
+// nilable(nilableLogger)
+var nilableLogger *logger
+
+func init() {
+	if cond() {
+		return
+	}
+	afterReturn = &logger{}
+	print(nilableLogger.name) //want "global variable `nilableLogger` accessed field `name`"
+}
+
+// The variable is assigned in the init() function of an out-of-scope file, whose assigned value is
+// not analyzed.
+var generatedLogger *logger //want "assigned into global variable"
+
+// The package-level initializers run before the init() functions, so the variables assigned there
+// are still nil in the initializers.
+var earlyLogger *logger
+var earlyName = earlyLogger.name //want "unassigned variable `earlyLogger` accessed field `name`"
+var earlyCopy = earlyLogger      //want "unassigned variable `earlyLogger` assigned into global variable `earlyCopy`"
+var earlyFunc = func() string {
+	// The function literal may only be invoked after the init() functions.
+	return earlyLogger.name
+}
+
+func init() {
+	earlyLogger = &logger{}
+}
+
+// The functions called by the init() function before the assignment (directly or transitively)
+// read the variable while it is still nil, unlike the ones not referencing it.
+var registry *logger         //want "assigned into global variable"
+var indirectRegistry *logger //want "assigned into global variable"
+var unreadRegistry *logger
+
+func setupRegistry() {
+	print(registry.name)
+	useIndirectRegistry()
+}
+
+func useIndirectRegistry() {
+	print(indirectRegistry.name)
+}
+
+func unrelatedSetup() {}
+
+func init() {
+	unrelatedSetup()
+	unreadRegistry = &logger{}
+	setupRegistry()
+	registry = &logger{}
+	indirectRegistry = &logger{}
+}
+
+func useInitLoggers() {
+	print(initLogger.name, initLoggerMulti.name, readEarly.name, nilLogger.name, unreadRegistry.name)
+}
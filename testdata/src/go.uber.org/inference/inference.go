@@ -134,9 +134,7 @@ func f2() *S {
 
 func f3() {
 	s1 := f1()
-	// TODO: Error should be reported on the line below. It is currently not reported because of the suppression of
-	//  struct field assignment logic that we added until we add object sensitivity for precise handling (issue #339).
-	print(*s1.Field) // "dereferenced"
+	print(*s1.Field) //want "dereferenced"
 
 	s2 := f2()
 	print(*s2.Field) // safe
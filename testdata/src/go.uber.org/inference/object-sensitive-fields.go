@@ -0,0 +1,113 @@
+//  Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package inference
+
+// Below tests check that the nilability of struct fields is tracked per object: assigning nil to the field of one
+// object does not make the same field of other objects nilable.
+
+type objS struct {
+	f *int
+}
+
+func newObjS() *objS {
+	return &objS{f: new(int)}
+}
+
+func testObjectSensitiveFields(i int) {
+	a, b := newObjS(), newObjS()
+	switch i {
+	case 0:
+		a.f = nil
+		print(*b.f) // safe
+	case 1:
+		a.f = nil
+		print(*a.f) //want "dereferenced"
+	case 2:
+		a.f = nil
+		a.f = new(int)
+		print(*a.f) // safe
+	}
+}
+
+// reset and clear assign nil to the field of their param and receiver, which flows back to the callers.
+func reset(s *objS) {
+	s.f = nil
+}
+
+func (s *objS) clear() {
+	s.f = nil
+}
+
+func testObjectSensitiveFieldsSideEffects(i int) {
+	a, b := newObjS(), newObjS()
+	switch i {
+	case 0:
+		reset(a)
+		print(*a.f) //want "dereferenced"
+	case 1:
+		reset(a)
+		print(*b.f) // safe
+	case 2:
+		a.clear()
+		print(*a.f) //want "dereferenced"
+	case 3:
+		a.clear()
+		print(*b.f) // safe
+	}
+}
+
+// readF dereferences the field of its param, which is nilable only for the objects passed by some callers.
+func readF(s *objS) int {
+	return *s.f //want "literal `nil` passed as field `f` of argument 0 to `readF\\(\\)`"
+}
+
+func testObjectSensitiveFieldsArgs() {
+	a := newObjS()
+	a.f = nil
+	readF(a)
+	readF(newObjS())
+}
+
+// Below tests check that the assignments to the fields of objects that cannot be tracked per object (e.g., global
+// variables or results of calls) do not affect the same field of other objects.
+
+type globalObjS struct {
+	f *int
+}
+
+var globalObj = &globalObjS{f: new(int)}
+
+func getGlobalObj() *globalObjS {
+	return globalObj
+}
+
+func resetGlobalObj() {
+	globalObj.f = nil
+}
+
+func resetGlobalObjResult() {
+	getGlobalObj().f = nil
+}
+
+func testObjectSensitiveFieldsGlobal(other *globalObjS) {
+	fresh := &globalObjS{f: new(int)}
+	print(*fresh.f) // safe
+	print(*other.f) // safe
+}
+
+func testObjectSensitiveFieldsGlobalLocal() {
+	globalObj.f = nil
+	print(*globalObj.f) //want "dereferenced"
+}
@@ -0,0 +1,83 @@
+//  Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package protogetter tests the built-in model of the getters of protobuf messages (see
+// userpb/user.pb.go), which are generated to handle nil receivers but excluded from analysis.
+package protogetter
+
+import "go.uber.org/protogetter/userpb"
+
+func chain(req *userpb.GetUserRequest) string {
+	// The getters accept nilable receivers, so the chain is safe even if the fields are unset.
+	return req.GetUser().GetAddress().GetCity()
+}
+
+func nilReceiver() string {
+	var req *userpb.GetUserRequest
+	return req.GetUser().GetName()
+}
+
+func messageField(req *userpb.GetUserRequest) string {
+	return req.GetUser().Name //want "determined to be nilable by a trusted function.*accessed field `Name`"
+}
+
+func nestedMessageField(req *userpb.GetUserRequest) string {
+	return req.GetUser().GetAddress().City //want "accessed field `City`"
+}
+
+func checkedMessageField(req *userpb.GetUserRequest) string {
+	if user := req.GetUser(); user != nil {
+		return user.Name
+	}
+	return ""
+}
+
+func returnMessage(req *userpb.GetUserRequest) *userpb.User {
+	return req.GetUser()
+}
+
+func useReturnedMessage(req *userpb.GetUserRequest) string {
+	return returnMessage(req).Name //want "accessed field `Name`"
+}
+
+func scalarFields(user *userpb.User) (string, userpb.Status, string) {
+	return user.GetName(), user.GetStatus(), user.GetPhone()
+}
+
+func repeatedField(user *userpb.User) string {
+	// Repeated and map getters return safe (possibly empty) values.
+	for _, email := range user.GetEmails() {
+		return email
+	}
+	return user.GetEmails()[0] + user.GetLabels()["key"]
+}
+
+func repeatedMessageField(user *userpb.User) string {
+	return user.GetFriends()[0].Name
+}
+
+func directField(user *userpb.User) string {
+	// Direct field accesses are not modeled: they are analyzed as usual.
+	return user.Address.City
+}
+
+// nilable(user)
+func nilableReceiver(user *userpb.User) string {
+	return user.GetAddress().GetCity()
+}
+
+// nilable(user)
+func nilableDirectField(user *userpb.User) string {
+	return user.Name //want "accessed field `Name`"
+}
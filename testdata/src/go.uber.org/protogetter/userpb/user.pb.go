@@ -0,0 +1,121 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: user.proto
+
+package userpb
+
+import "stubs/google.golang.org/protobuf/reflect/protoreflect"
+
+type Status int32
+
+const (
+	Status_UNKNOWN Status = 0
+	Status_ACTIVE  Status = 1
+)
+
+type Address struct {
+	City   string
+	Street *string
+}
+
+func (x *Address) ProtoReflect() protoreflect.Message { return nil }
+
+func (x *Address) GetCity() string {
+	if x != nil {
+		return x.City
+	}
+	return ""
+}
+
+type User struct {
+	Name    string
+	Address *Address
+	Emails  []string
+	Labels  map[string]string
+	Status  Status
+	Friends []*User
+	// Types that are valid to be assigned to Contact:
+	//
+	//	*User_Phone
+	Contact isUser_Contact
+}
+
+func (x *User) ProtoReflect() protoreflect.Message { return nil }
+
+func (x *User) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *User) GetAddress() *Address {
+	if x != nil {
+		return x.Address
+	}
+	return nil
+}
+
+func (x *User) GetEmails() []string {
+	if x != nil {
+		return x.Emails
+	}
+	return nil
+}
+
+func (x *User) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *User) GetStatus() Status {
+	if x != nil {
+		return x.Status
+	}
+	return Status_UNKNOWN
+}
+
+func (x *User) GetFriends() []*User {
+	if x != nil {
+		return x.Friends
+	}
+	return nil
+}
+
+func (x *User) GetContact() isUser_Contact {
+	if x != nil {
+		return x.Contact
+	}
+	return nil
+}
+
+func (x *User) GetPhone() string {
+	if x, ok := x.GetContact().(*User_Phone); ok {
+		return x.Phone
+	}
+	return ""
+}
+
+type isUser_Contact interface {
+	isUser_Contact()
+}
+
+type User_Phone struct {
+	Phone string
+}
+
+func (*User_Phone) isUser_Contact() {}
+
+type GetUserRequest struct {
+	User *User
+}
+
+func (x *GetUserRequest) ProtoReflect() protoreflect.Message { return nil }
+
+func (x *GetUserRequest) GetUser() *User {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+//  Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package skippedfunc tests that functions skipped by the analysis (e.g., because they exceed the
+// configured size limit, see config.MaxFuncSizeFlag, set to 10 CFG blocks for this package) are
+// reported separately at their declarations, while the other functions of the package are still
+// checked. Functions that are too large are still summarized by their return statements.
+package skippedfunc
+
+var dummy bool
+
+func deref() int {
+	var p *int
+	return *p //want "Potential nil panic detected"
+}
+
+// The skipped function is summarized by its return statements, so the nil result is known here.
+func callSkipped() int {
+	return *tooLarge(1) //want "literal `nil` returned from `tooLarge\\(\\)`"
+}
+
+//nolint:nilaway
+func suppressedTooLarge(n int) *int {
+	if n == 0 {
+		n++
+	}
+	if n == 1 {
+		n++
+	}
+	if n == 2 {
+		n++
+	}
+	if n == 3 {
+		n++
+	}
+	if n == 4 {
+		n++
+	}
+	return nil
+}
+
+func tooLarge(n int) *int { //want "Skipped the analysis of function `tooLarge\\(\\)`, nil flows within it are not checked: function too large"
+	if n == 0 {
+		n++
+	}
+	if n == 1 {
+		n++
+	}
+	if n == 2 {
+		n++
+	}
+	if n == 3 {
+		n++
+	}
+	if n == 4 {
+		n++
+	}
+	if dummy {
+		var p *int
+		// The nil flows within the skipped function are not checked either.
+		print(*p)
+	}
+	return nil
+}
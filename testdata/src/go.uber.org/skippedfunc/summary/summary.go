@@ -0,0 +1,123 @@
+//  Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package summary tests that the functions exceeding the configured size limit (see
+// config.MaxFuncSizeFlag, set to 10 CFG blocks for this package) are summarized by their return
+// statements, such that their callers still get the nilability of their results.
+package summary
+
+import "errors"
+
+func returnsNil(n int) *int { //want "Skipped the analysis of function `returnsNil\\(\\)`, nil flows within it are not checked: function too large .*, only its return statements are summarized"
+	if n == 0 {
+		n++
+	}
+	if n == 1 {
+		n++
+	}
+	if n == 2 {
+		n++
+	}
+	if n == 3 {
+		n++
+	}
+	if n == 4 {
+		return new(int)
+	}
+	return nil
+}
+
+func returnsNonnil(n int) *int { //want "Skipped the analysis of function `returnsNonnil\\(\\)`"
+	if n == 0 {
+		n++
+	}
+	if n == 1 {
+		n++
+	}
+	if n == 2 {
+		n++
+	}
+	if n == 3 {
+		n++
+	}
+	if n == 4 {
+		return &n
+	}
+	return new(int)
+}
+
+func returnsLocal(n int) *int { //want "Skipped the analysis of function `returnsLocal\\(\\)`"
+	var p *int
+	if n == 0 {
+		n++
+	}
+	if n == 1 {
+		n++
+	}
+	if n == 2 {
+		n++
+	}
+	if n == 3 {
+		n++
+	}
+	if n == 4 {
+		p = &n
+	}
+	// The nilability of the returned variable requires the flow analysis, so it is not summarized.
+	return p
+}
+
+func errReturn(n int, err error) (*int, error) { //want "Skipped the analysis of function `errReturn\\(\\)`"
+	if n == 0 {
+		n++
+	}
+	if n == 1 {
+		n++
+	}
+	if n == 2 {
+		return nil, errors.New("two")
+	}
+	if n == 3 {
+		n++
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &n, nil
+}
+
+func callReturnsNil() int {
+	return *returnsNil(1) //want "literal `nil` returned from `returnsNil\\(\\)`"
+}
+
+func callReturnsNonnil() int {
+	return *returnsNonnil(1)
+}
+
+func callReturnsLocal() int {
+	return *returnsLocal(1)
+}
+
+func callErrReturn() int {
+	v, err := errReturn(1, nil)
+	if err != nil {
+		return 0
+	}
+	return *v
+}
+
+func callErrReturnUnchecked() int {
+	v, _ := errReturn(1, nil)
+	return *v //want "result 0 of `errReturn\\(\\)` lacking guarding"
+}
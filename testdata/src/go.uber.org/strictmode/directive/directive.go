@@ -0,0 +1,40 @@
+//  Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package directive tests that the strict directive runs the package in annotation-only mode:
+// nilabilities are not inferred, and missing annotations fall back to the defaults.
+//
+//nilaway:strict
+package directive
+
+// In strict mode, returning nil from an unannotated (hence nonnil) result is an error by itself,
+// regardless of whether the result is dereferenced.
+func unannotated() *int {
+	return nil //want "returned from `unannotated\\(\\)`"
+}
+
+// nilable(result 0)
+func annotated() *int {
+	return nil
+}
+
+// Slices are nilable by default.
+func slice() []int {
+	return nil
+}
+
+func caller() {
+	print(*annotated()) //want "dereferenced"
+	_ = slice()
+}
@@ -0,0 +1,31 @@
+//  Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package flag tests that the strict-pkgs flag runs the package in annotation-only mode, and
+// that the errors are grouped (if enabled) in this mode as well.
+package flag
+
+// nilable(p)
+func f(p *int) {
+	print(*p) //want "(?s)dereferenced.*Same nil source could also cause potential nil panic"
+	print(*p)
+}
+
+func g(p *int) {
+	print(*p)
+}
+
+func caller() {
+	g(nil) //want "passed as arg `p` to `g\\(\\)`"
+}
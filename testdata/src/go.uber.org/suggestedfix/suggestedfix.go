@@ -0,0 +1,140 @@
+//  Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+These tests check the suggested fixes attached to the diagnostics of common single-assertion
+conflicts. The expected results after applying the fixes are in the corresponding golden file.
+
+<nilaway no inference>
+*/
+package suggestedfix
+
+import (
+	"errors"
+	"strings"
+)
+
+type A struct {
+	f int
+}
+
+// nilable(result 0)
+func nilableA() *A {
+	return nil
+}
+
+func mapReadDeref(m map[string]*A) int {
+	a := m["key"]
+	return a.f //want "lacking guarding"
+}
+
+func mapReadDerefMultiResult(m map[string]*int) (int, string, error) {
+	v := m["key"]
+	return *v, "", nil //want "lacking guarding"
+}
+
+func mapReadDerefOkTaken(m map[string]*A, ok bool) (*A, strings.Builder, bool) {
+	a := m["key"]
+	print(a.f) //want "lacking guarding"
+	return &A{}, strings.Builder{}, ok
+}
+
+// The zero value of `T` is nil for pointer instantiations.
+// nilable(result 0)
+func mapReadDerefGeneric[T any](m map[string]*A) T {
+	a := m["key"]
+	print(a.f) //want "lacking guarding"
+	var t T
+	return t
+}
+
+// A map read assigned to an existing variable is not rewritten since `ok` would have to be
+// declared separately.
+func mapReadDerefNoDefine(m map[string]*A) {
+	var a *A
+	a = m["key"]
+	print(a.f) //want "lacking guarding"
+}
+
+// nilable(result 0)
+func nilableAWithErr() (*A, error) {
+	return nil, nil
+}
+
+func funcReturnDeref() (int, error) {
+	a := nilableA()
+	return a.f, nil //want "accessed field `f`"
+}
+
+func funcReturnDerefNoResult() {
+	a := nilableA()
+	print(a.f) //want "accessed field `f`"
+}
+
+func funcReturnDerefMultiResult() {
+	a, err := nilableAWithErr()
+	if err != nil {
+		return
+	}
+	print(a.f) //want "accessed field `f`"
+}
+
+// The guard cannot be inserted if the dereference is within the same statement.
+func funcReturnDerefSameStmt() {
+	if a := nilableA(); a.f > 0 { //want "accessed field `f`"
+		print(a)
+	}
+}
+
+type S struct {
+	name string
+}
+
+func funcReturnDerefStruct() (S, []int, bool, error) {
+	a := nilableA()
+	if a.f > 0 { //want "accessed field `f`"
+		return S{}, nil, false, errors.New("positive")
+	}
+	return S{name: "a"}, nil, true, nil
+}
+
+func returnNil() *A {
+	return nil //want "returned from `returnNil.*"
+}
+
+func takesPtr(a *A) int {
+	return a.f
+}
+
+func passNil() {
+	takesPtr(nil) //want "passed as arg `a`"
+}
+
+type B struct {
+	ptr *A
+}
+
+func assignFieldNil(b *B) {
+	b.ptr = nil //want "assigned into field `ptr`"
+}
+
+var (
+	globalA = &A{}
+	otherA  = &A{}
+)
+
+func assignGlobalNil() {
+	globalA = nil //want "assigned into global variable `globalA`"
+	print(otherA)
+}
@@ -0,0 +1,59 @@
+//  Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+Package helper hosts internal helpers that are modeled as trusted functions via the user-provided
+trusted models file (models.json in the parent directory), instead of the built-in hook tables.
+
+<nilaway no inference>
+*/
+package helper
+
+// Client is a dummy client.
+type Client struct {
+	Name string
+}
+
+// MustNewClient never returns nil in practice, but its declared contract says otherwise.
+// nilable(result 0)
+func MustNewClient() *Client {
+	return nil
+}
+
+// NewClient may return nil.
+// nilable(result 0)
+func NewClient() *Client {
+	return nil
+}
+
+// NotNil only returns if v is not nil.
+// nilable(v)
+func NotNil(_ string, v any) {}
+
+// IsTrue only returns if b is true.
+func IsTrue(b bool) {}
+
+// Decode populates the value pointed to by out if the returned error is nil.
+// nilable(out)
+func Decode(_ []byte, out any) error { return nil }
+
+// Logger is a dummy logger.
+type Logger struct{}
+
+// Die never returns.
+func (*Logger) Die(string) {}
+
+// Default is a global that is always initialized in practice.
+// nilable(Default)
+var Default *Client
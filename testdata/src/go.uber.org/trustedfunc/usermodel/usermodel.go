@@ -0,0 +1,66 @@
+//  Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+Package usermodel tests the trusted function models declared by users in a model file (see
+models.json), which are merged with the built-in models in the hook package.
+
+<nilaway no inference>
+*/
+package usermodel
+
+import "go.uber.org/trustedfunc/usermodel/helper"
+
+func nonnilReturn() string {
+	c := helper.MustNewClient()
+	return c.Name
+}
+
+func splitOnNonnil() string {
+	c := helper.NewClient()
+	helper.NotNil("client", c)
+	return c.Name
+}
+
+func splitOnTrue(m map[string]*helper.Client) string {
+	c, ok := m["key"]
+	helper.IsTrue(ok)
+	return c.Name
+}
+
+func noReturn(l *helper.Logger) string {
+	c := helper.NewClient()
+	if c == nil {
+		l.Die("nil client")
+	}
+	return c.Name
+}
+
+func errorReturnNonnilArg(data []byte) string {
+	var c *helper.Client
+	if err := helper.Decode(data, &c); err != nil {
+		return ""
+	}
+	return c.Name
+}
+
+func nonnilGlobal() string {
+	return helper.Default.Name
+}
+
+// unmodeled makes sure the models only apply to the matched names.
+func unmodeled() string {
+	c := helper.NewClient()
+	return c.Name //want "accessed field `Name`"
+}
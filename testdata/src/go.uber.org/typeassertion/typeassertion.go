@@ -0,0 +1,117 @@
+//  Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package typeassertion tests the handling of type assertions: the value of `v, ok := x.(T)` is
+// nonnil only if `ok` is checked when `T` is nilable, and the single-value `x.(T)` panics if `x` is nil.
+package typeassertion
+
+type A struct {
+	f int
+}
+
+type I interface {
+	m()
+}
+
+func (*A) m() {}
+
+func okChecked(x any) int {
+	if v, ok := x.(*A); ok {
+		return v.f
+	}
+	return 0
+}
+
+func okNotChecked(x any) int {
+	v, ok := x.(*A)
+	_ = ok
+	return v.f //want "result of type assertion to `\\*A` lacking guarding"
+}
+
+func okNegated(x any) int {
+	v, ok := x.(*A)
+	if !ok {
+		return v.f //want "lacking guarding"
+	}
+	return v.f
+}
+
+func okReassigned(x, y any) int {
+	v, ok := x.(*A)
+	_, ok = y.(*A)
+	if ok {
+		return v.f //want "lacking guarding"
+	}
+	return 0
+}
+
+func okMap(x any) int {
+	if v, ok := x.(map[string]int); ok {
+		v["a"] = 1
+		return len(v)
+	}
+	return 0
+}
+
+func okMapNotChecked(x any) {
+	v, _ := x.(map[string]int)
+	v["a"] = 1 //want "lacking guarding"
+}
+
+func okSlice(x any) int {
+	v, ok := x.([]int)
+	if ok {
+		return v[0]
+	}
+	return v[0] //want "lacking guarding"
+}
+
+func okNonNilableType(x any) int {
+	v, _ := x.(A)
+	return v.f
+}
+
+func okInterfaceChecked(x I) int {
+	if _, ok := x.(*A); ok {
+		x.m()
+	}
+	return 0
+}
+
+func singleValue(x I) int {
+	return x.(*A).f //want "type-asserted without `ok` check"
+}
+
+func callSingleValue() {
+	var x I
+	singleValue(x)
+}
+
+func singleValueOnNil() *A {
+	var x any
+	return x.(*A) //want "type-asserted without `ok` check"
+}
+
+func singleValueGuarded(x any) *A {
+	if x != nil {
+		return x.(*A)
+	}
+	return nil
+}
+
+func commaOkOnNil() (*A, bool) {
+	var x any
+	v, ok := x.(*A)
+	return v, ok
+}
@@ -334,7 +334,8 @@ func test24() {
 		b = a
 	}
 	print(*a) //want "`retMultiple\\(\\)` to `a`"
-	print(*b) //want "`a` to `b`"
+	// The error here is grouped with the one above since they share the same nil source.
+	print(*b)
 	print(*c) //want "`retMultiple\\(\\)` to `c`"
 }
 
@@ -0,0 +1,26 @@
+//  Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// <nilaway no inference>
+package protoreflect
+
+// Message is a reflective interface for a concrete message value.
+type Message interface {
+	IsValid() bool
+}
+
+// ProtoMessage is the top-level interface that all proto messages implement.
+type ProtoMessage interface {
+	ProtoReflect() Message
+}
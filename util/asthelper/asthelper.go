@@ -41,6 +41,23 @@ func DocContains(file *ast.File, s string) bool {
 	return false
 }
 
+// HasDirective returns true if the file has the given directive (e.g., "//nilaway:strict") as a
+// comment line before the package clause. Note that directives are stripped from the text of the
+// comment groups (see [ast.CommentGroup.Text]), so DocContains cannot be used for them.
+func HasDirective(file *ast.File, directive string) bool {
+	for _, group := range file.Comments {
+		if group.Pos() > file.Name.Pos() {
+			return false
+		}
+		for _, comment := range group.List {
+			if strings.TrimSpace(comment.Text) == directive {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 // PrintExpr converts AST expression to string, and shortens long expressions if isShortenExpr is true
 func PrintExpr(e ast.Expr, fset *token.FileSet, isShortenExpr bool) (string, error) {
 	builder := &strings.Builder{}
@@ -216,7 +233,11 @@ func GetFieldVal(compElts []ast.Expr, fieldName string, numFields int, index int
 	return nil
 }
 
-// FuncIdentFromCallExpr return a function identified from a call expression, nil otherwise
+// FuncIdentFromCallExpr return a function identified from a call expression, nil otherwise.
+// Explicit instantiations of generic functions (e.g., `foo[int](x)` or `pkg.Bar[K, V](x)`) are
+// unwrapped to the identifier of the generic function. Note that since the index of an
+// `ast.IndexExpr` can also be a value (e.g., `fs[0](x)`), the returned identifier is not
+// guaranteed to denote a function; callers should check its object.
 // nilable(result 0)
 func FuncIdentFromCallExpr(expr *ast.CallExpr) *ast.Ident {
 	switch fun := expr.Fun.(type) {
@@ -224,12 +245,35 @@ func FuncIdentFromCallExpr(expr *ast.CallExpr) *ast.Ident {
 		return fun
 	case *ast.SelectorExpr:
 		return fun.Sel
+	case *ast.IndexExpr, *ast.IndexListExpr:
+		return FuncIdentFromInstantiation(fun)
 	default:
 		// case of anonymous function
 		return nil
 	}
 }
 
+// FuncIdentFromInstantiation returns the identifier of the (possibly qualified) generic function
+// explicitly instantiated by an index expression (e.g., `foo` in `foo[int]`), nil otherwise.
+// nilable(result 0)
+func FuncIdentFromInstantiation(expr ast.Expr) *ast.Ident {
+	var x ast.Expr
+	switch expr := expr.(type) {
+	case *ast.IndexExpr:
+		x = expr.X
+	case *ast.IndexListExpr:
+		x = expr.X
+	}
+	switch x := x.(type) {
+	case *ast.Ident:
+		return x
+	case *ast.SelectorExpr:
+		return x.Sel
+	default:
+		return nil
+	}
+}
+
 // GetFunctionParamNode returns the ast param node matching the variable searchParam
 func GetFunctionParamNode(funcDecl *ast.FuncDecl, searchParam *types.Var) ast.Expr {
 	for _, params := range funcDecl.Type.Params.List {
@@ -16,6 +16,7 @@ package asthelper
 
 import (
 	"go/ast"
+	"go/parser"
 	"go/token"
 	"testing"
 
@@ -101,3 +102,21 @@ func TestDocContains(t *testing.T) {
 		})
 	}
 }
+
+func TestHasDirective(t *testing.T) {
+	t.Parallel()
+
+	file, err := parser.ParseFile(token.NewFileSet(), "a.go", `// Package a does something.
+//
+//nilaway:strict
+package a
+
+//nilaway:other
+`, parser.ParseComments)
+	require.NoError(t, err)
+	require.True(t, HasDirective(file, "//nilaway:strict"))
+	// Directives after the package clause are ignored.
+	require.False(t, HasDirective(file, "//nilaway:other"))
+	// Directives are not part of the doc text.
+	require.False(t, DocContains(file, "nilaway:strict"))
+}
@@ -48,8 +48,14 @@ var BuiltinNew = types.Universe.Lookup("new")
 
 // IsDeep checks if a type is an expression that admits deep nilability, such as maps, slices, arrays, etc.
 // Only consider pointers to deep types (e.g., `var x *[]int`) as deep type,
-// not pointers to basic types (e.g., `var x *int`) or struct types (e.g., `var x *S`)
+// not pointers to basic types (e.g., `var x *int`) or struct types (e.g., `var x *S`).
+// A type parameter is deep if its core type is (e.g., `S ~[]*E`).
 func IsDeep(t types.Type) bool {
+	if tp, ok := t.(*types.TypeParam); ok {
+		if t = CoreType(tp); t == nil {
+			return false
+		}
+	}
 	switch UnwrapPtr(t).(type) {
 	case *types.Slice, *types.Array, *types.Map, *types.Chan, *types.Struct:
 		return true
@@ -76,9 +82,9 @@ func IsSlice(t types.Type) bool {
 }
 
 // IsDeeplyArray returns true if `t` is of array type, including
-// transitively through Named types
+// transitively through Named types and core types of type parameters
 func IsDeeplyArray(t types.Type) bool {
-	switch tt := UnwrapPtr(t).(type) {
+	switch tt := unwrapTypeParam(UnwrapPtr(t)).(type) {
 	case *types.Array:
 		return true
 	case *types.Named:
@@ -88,8 +94,9 @@ func IsDeeplyArray(t types.Type) bool {
 }
 
 // IsDeeplySlice returns true if `t` is of slice type, including
-// transitively through Named types
+// transitively through Named types and core types of type parameters
 func IsDeeplySlice(t types.Type) bool {
+	t = unwrapTypeParam(t)
 	if IsSlice(t) {
 		return true
 	}
@@ -100,8 +107,9 @@ func IsDeeplySlice(t types.Type) bool {
 }
 
 // IsDeeplyMap returns true if `t` is of map type, including
-// transitively through Named types
+// transitively through Named types and core types of type parameters
 func IsDeeplyMap(t types.Type) bool {
+	t = unwrapTypeParam(t)
 	if _, ok := t.(*types.Map); ok {
 		return true
 	}
@@ -112,8 +120,9 @@ func IsDeeplyMap(t types.Type) bool {
 }
 
 // IsDeeplyPtr returns true if `t` is of pointer type, including
-// transitively through Named types
+// transitively through Named types and core types of type parameters
 func IsDeeplyPtr(t types.Type) bool {
+	t = unwrapTypeParam(t)
 	if _, ok := t.(*types.Pointer); ok {
 		return true
 	}
@@ -124,8 +133,9 @@ func IsDeeplyPtr(t types.Type) bool {
 }
 
 // IsDeeplyChan returns true if `t` is of channel type, including
-// transitively through Named types
+// transitively through Named types and core types of type parameters
 func IsDeeplyChan(t types.Type) bool {
+	t = unwrapTypeParam(t)
 	if _, ok := t.(*types.Chan); ok {
 		return true
 	}
@@ -354,7 +364,112 @@ func TypeBarsNilness(t types.Type) bool {
 	case *types.Basic:
 		// all basic types except UntypedNil are not inhabited by nil
 		return t.Kind() != types.UntypedNil
+	case *types.TypeParam:
+		// A type parameter is inhabited by nil iff any type in its type set is. A type parameter
+		// without restricting terms (e.g., `any`) can be instantiated by nilable types.
+		terms := TypeParamTerms(t)
+		if terms == nil {
+			return false
+		}
+		for _, term := range terms {
+			if !TypeBarsNilness(term) {
+				return false
+			}
+		}
+		return true
 	default:
 		return true
 	}
 }
+
+// OriginObject returns the generic field or method that `obj` is instantiated from (e.g., the field
+// `x` of `G[T]` for the field `x` of `G[*int]`), or `obj` itself otherwise. Since go/types creates
+// distinct objects for the fields and methods of every instantiation, this should be used to
+// canonicalize objects before they are used as annotation sites.
+func OriginObject(obj types.Object) types.Object {
+	switch obj := obj.(type) {
+	case *types.Var:
+		return obj.Origin()
+	case *types.Func:
+		return obj.Origin()
+	default:
+		return obj
+	}
+}
+
+// TypeParamTerms returns the underlying types of the terms restricting the type set of the type
+// parameter `tp` (e.g., `[]E` for `S ~[]E`, or `*A` and `*B` for `T *A | *B`). It returns nil if
+// the constraint does not restrict the type set by terms (e.g., `any`, `comparable` or interfaces
+// with methods only), i.e., `tp` can be instantiated by any type. If the constraint embeds more
+// than one restricting element, only the terms of the first one are returned, which is still a
+// superset of the type set.
+func TypeParamTerms(tp *types.TypeParam) []types.Type {
+	return constraintTerms(tp.Constraint())
+}
+
+// constraintTerms returns the terms of the first restricting element embedded in the constraint
+// interface, nil if there is none.
+func constraintTerms(constraint types.Type) []types.Type {
+	iface, ok := constraint.Underlying().(*types.Interface)
+	if !ok {
+		return nil
+	}
+	for i := 0; i < iface.NumEmbeddeds(); i++ {
+		switch e := iface.EmbeddedType(i).(type) {
+		case *types.Union:
+			terms := make([]types.Type, 0, e.Len())
+			for j := 0; j < e.Len(); j++ {
+				terms = append(terms, e.Term(j).Type().Underlying())
+			}
+			return terms
+		default:
+			if _, ok := e.Underlying().(*types.Interface); ok {
+				// Embedded interfaces (e.g., `interface{ AB; foo() }`) may restrict the type set.
+				if terms := constraintTerms(e); terms != nil {
+					return terms
+				}
+				continue
+			}
+			// A single term without tilde (e.g., `[T *int]`).
+			return []types.Type{e.Underlying()}
+		}
+	}
+	return nil
+}
+
+// unwrapTypeParam returns the core type of `t` if it is a type parameter with a core type, and `t`
+// itself otherwise.
+func unwrapTypeParam(t types.Type) types.Type {
+	if tp, ok := t.(*types.TypeParam); ok {
+		if core := CoreType(tp); core != nil {
+			return core
+		}
+	}
+	return t
+}
+
+// CoreType returns the underlying type of `t`, or for a type parameter, the single underlying
+// type shared by all the types in its type set (e.g., `[]E` for `S ~[]E`). Channel types with the
+// same element type but different directions share the core type of the first one. It returns
+// nil if the type parameter does not have such a core type.
+func CoreType(t types.Type) types.Type {
+	tp, ok := t.(*types.TypeParam)
+	if !ok {
+		return t.Underlying()
+	}
+	terms := TypeParamTerms(tp)
+	if len(terms) == 0 {
+		return nil
+	}
+	for _, term := range terms[1:] {
+		if types.Identical(term, terms[0]) {
+			continue
+		}
+		c1, ok1 := term.(*types.Chan)
+		c2, ok2 := terms[0].(*types.Chan)
+		if !ok1 || !ok2 || !types.Identical(c1.Elem(), c2.Elem()) {
+			return nil
+		}
+	}
+	return terms[0]
+}
@@ -15,6 +15,8 @@
 package typeshelper
 
 import (
+	"go/ast"
+	"go/parser"
 	"go/token"
 	"go/types"
 	"testing"
@@ -55,3 +57,64 @@ func TestIsIterType(t *testing.T) {
 		})
 	}
 }
+
+func TestTypeParams(t *testing.T) {
+	t.Parallel()
+
+	const src = `package testpkg
+
+type Ptr[T any] interface{ ~*T }
+
+type Stringer interface{ String() string }
+
+func f[
+	Any any,
+	Comparable comparable,
+	Methods Stringer,
+	Pointers *int | *string,
+	PtrTo Ptr[int],
+	Slice ~[]int,
+	Chans chan int | <-chan int,
+	Mixed []int | map[int]int,
+	Basics ~int | ~string,
+]() {}
+`
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "testpkg.go", src, 0)
+	require.NoError(t, err)
+	info := &types.Info{Defs: make(map[*ast.Ident]types.Object)}
+	_, err = (&types.Config{}).Check("testpkg", fset, []*ast.File{file}, info)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name            string
+		wantBarsNilness bool
+		wantCoreType    bool
+	}{
+		{"Any", false, false},
+		{"Comparable", false, false},
+		{"Methods", false, false},
+		{"Pointers", false, false},
+		{"PtrTo", false, true},
+		{"Slice", false, true},
+		{"Chans", false, true},
+		{"Mixed", false, false},
+		{"Basics", true, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var tp *types.TypeParam
+			for ident, obj := range info.Defs {
+				if ident.Name == tt.name {
+					tp, _ = obj.Type().(*types.TypeParam)
+				}
+			}
+			require.NotNil(t, tp)
+			require.Equal(t, tt.wantBarsNilness, TypeBarsNilness(tp))
+			require.Equal(t, tt.wantCoreType, CoreType(tp) != nil)
+		})
+	}
+}